@@ -9,6 +9,7 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // Часовые пояса пользователей не зависят от tzdata в образе
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/gorilla/mux"
@@ -18,21 +19,75 @@ import (
 	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/cancel_batch_notification"
 	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/cancel_notification"
 	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/create_batch_notification"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/create_company_link_code"
 	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/create_notification"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/create_start_link"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/create_template"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/delete_notification_message"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/delete_template"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/edit_notification_message"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/get_preferences"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/get_template"
 	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/health"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/list_company_chats"
 	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/list_notifications"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/list_support_conversations"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/list_support_messages"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/list_template_versions"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/list_templates"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/list_unreachable_chats"
+	previewhandler "github.com/m04kA/SMC-NotificationService/internal/api/handlers/preview_notification"
 	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/telegram_webhook"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/update_preferences"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/update_template"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/upload_media"
 	"github.com/m04kA/SMC-NotificationService/internal/api/middleware"
+	"github.com/m04kA/SMC-NotificationService/internal/api/updates"
 	"github.com/m04kA/SMC-NotificationService/internal/config"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/blobstore/localfs"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/botupdate"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/botuser"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/callbackaudit"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/groupchat"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/media"
 	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/notification"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/preferences"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/reachability"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/support"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/telegramfile"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/template"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/bookingservice"
 	"github.com/m04kA/SMC-NotificationService/internal/integrations/userservice"
+	"github.com/m04kA/SMC-NotificationService/internal/service/filecache"
+	groupchatservice "github.com/m04kA/SMC-NotificationService/internal/service/groupchat"
+	mediaservice "github.com/m04kA/SMC-NotificationService/internal/service/media"
 	"github.com/m04kA/SMC-NotificationService/internal/service/notifications"
+	preferencesservice "github.com/m04kA/SMC-NotificationService/internal/service/preferences"
+	reachabilityservice "github.com/m04kA/SMC-NotificationService/internal/service/reachability"
+	supportservice "github.com/m04kA/SMC-NotificationService/internal/service/support"
 	"github.com/m04kA/SMC-NotificationService/internal/service/telegram"
+	welcometemplates "github.com/m04kA/SMC-NotificationService/internal/service/telegram/templates"
+	"github.com/m04kA/SMC-NotificationService/internal/service/templates"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/bot_profile"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/callback_query"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/chat_member"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/contact_message"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/group_link"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/help_message"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/my_bookings"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/preview_notification"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/sent_message"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/settings_message"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/start_link"
 	"github.com/m04kA/SMC-NotificationService/internal/usecase/start_message"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/support_message"
 	"github.com/m04kA/SMC-NotificationService/internal/worker"
+	"github.com/m04kA/SMC-NotificationService/pkg/callbackdata"
 	"github.com/m04kA/SMC-NotificationService/pkg/dbmetrics"
 	"github.com/m04kA/SMC-NotificationService/pkg/logger"
 	"github.com/m04kA/SMC-NotificationService/pkg/metrics"
+	"github.com/m04kA/SMC-NotificationService/pkg/startparam"
 )
 
 func main() {
@@ -85,13 +140,43 @@ func main() {
 
 	// Инициализируем repository
 	var notificationRepo *notification.Repository
+	var templateRepo *template.Repository
+	var botUserRepo *botuser.Repository
+	var callbackAuditRepo *callbackaudit.Repository
+	var telegramFileRepo *telegramfile.Repository
+	var mediaRepo *media.Repository
+	var preferencesRepo *preferences.Repository
+	var reachabilityRepo *reachability.Repository
+	var botUpdateRepo *botupdate.Repository
+	var supportRepo *support.Repository
+	var groupChatRepo *groupchat.Repository
 
 	if cfg.Metrics.Enabled {
 		wrappedDB = dbmetrics.WrapWithDefault(db, metricsCollector, cfg.Metrics.ServiceName, stopMetricsCh)
 		log.Info("Database metrics collection started")
 		notificationRepo = notification.NewRepository(wrappedDB)
+		templateRepo = template.NewRepository(wrappedDB)
+		botUserRepo = botuser.NewRepository(wrappedDB)
+		callbackAuditRepo = callbackaudit.NewRepository(wrappedDB)
+		telegramFileRepo = telegramfile.NewRepository(wrappedDB)
+		mediaRepo = media.NewRepository(wrappedDB)
+		preferencesRepo = preferences.NewRepository(wrappedDB)
+		reachabilityRepo = reachability.NewRepository(wrappedDB)
+		botUpdateRepo = botupdate.NewRepository(wrappedDB)
+		supportRepo = support.NewRepository(wrappedDB)
+		groupChatRepo = groupchat.NewRepository(wrappedDB)
 	} else {
 		notificationRepo = notification.NewRepository(db)
+		templateRepo = template.NewRepository(db)
+		botUserRepo = botuser.NewRepository(db)
+		callbackAuditRepo = callbackaudit.NewRepository(db)
+		telegramFileRepo = telegramfile.NewRepository(db)
+		mediaRepo = media.NewRepository(db)
+		preferencesRepo = preferences.NewRepository(db)
+		reachabilityRepo = reachability.NewRepository(db)
+		botUpdateRepo = botupdate.NewRepository(db)
+		supportRepo = support.NewRepository(db)
+		groupChatRepo = groupchat.NewRepository(db)
 	}
 
 	// Создаём контекст с возможностью отмены для управления жизненным циклом горутин
@@ -105,6 +190,13 @@ func main() {
 	)
 	log.Info("UserService client initialized (url=%s)", cfg.UserService.URL)
 
+	// Инициализируем интеграцию с BookingService
+	bookingServiceClient := bookingservice.NewClient(
+		cfg.BookingService.URL,
+		time.Duration(cfg.BookingService.Timeout)*time.Second,
+	)
+	log.Info("BookingService client initialized (url=%s)", cfg.BookingService.URL)
+
 	// Инициализируем Telegram Bot API
 	bot, err := tgbotapi.NewBotAPI(cfg.Telegram.BotToken)
 	if err != nil {
@@ -112,49 +204,84 @@ func main() {
 	}
 	log.Info("Telegram Bot API initialized (@%s)", bot.Self.UserName)
 
+	// Инициализируем кэш file_id загруженных файлов
+	fileCache := filecache.NewService(telegramFileRepo, log)
+
+	// Инициализируем медиатеку
+	mediaStore, err := localfs.NewStore(cfg.Media.StorageDir)
+	if err != nil {
+		log.Fatal("Failed to initialize media storage: %v", err)
+	}
+	mediaSvc := mediaservice.NewService(mediaRepo, mediaStore, log)
+	log.Info("Media library initialized (storage: %s)", cfg.Media.StorageDir)
+
+	// Проверяем сценарий приветствия: ошибка настройки должна останавливать запуск, а не каждый /start
+	welcomeTexts := make(map[string]welcometemplates.WelcomeTexts, len(cfg.Welcome.Texts))
+	for locale, texts := range cfg.Welcome.Texts {
+		welcomeTexts[locale] = welcometemplates.WelcomeTexts{
+			Caption:      texts.Caption,
+			ButtonPrompt: texts.ButtonPrompt,
+			ButtonText:   texts.ButtonText,
+		}
+	}
+	welcomeVariants := make(map[string]welcometemplates.WelcomeVariant, len(cfg.Welcome.Variants))
+	for kind, variant := range cfg.Welcome.Variants {
+		variantTexts := make(map[string]welcometemplates.WelcomeTexts, len(variant.Texts))
+		for locale, texts := range variant.Texts {
+			variantTexts[locale] = welcometemplates.WelcomeTexts{
+				Caption:      texts.Caption,
+				ButtonPrompt: texts.ButtonPrompt,
+				ButtonText:   texts.ButtonText,
+			}
+		}
+		welcomeVariants[kind] = welcometemplates.WelcomeVariant{ButtonURL: variant.ButtonURL, Texts: variantTexts}
+	}
+	welcome, err := welcometemplates.NewWelcome(cfg.Welcome.Media, cfg.Welcome.ButtonURL, welcomeTexts, welcomeVariants, cfg.I18n.DefaultLocale)
+	if err != nil {
+		log.Fatal("Invalid welcome configuration: %v", err)
+	}
+	log.Info("Welcome flow loaded (%d images, %d locales, %d deep-link variants)", len(cfg.Welcome.Media), len(welcomeTexts), len(welcomeVariants))
+
 	// Инициализируем Telegram Service
-	telegramSvc := telegram.NewService(bot)
+	telegramSvc := telegram.NewService(bot, fileCache, mediaSvc, welcome)
 	log.Info("Telegram service initialized")
 
-	// Инициализируем use case для обработки /start
-	startMessageUC := start_message.New(telegramSvc, userServiceClient)
-	log.Info("Start message use case initialized")
+	// Инициализируем сервис доступности чатов (блокировка бота, удаление из группы)
+	reachabilitySvc := reachabilityservice.NewService(reachabilityRepo)
+	log.Info("Reachability service initialized")
 
-	// Определяем режим работы: Webhook или Long Polling
-	if cfg.Telegram.WebhookURL != "" {
-		// Режим Webhook
-		log.Info("Using Webhook mode")
+	// Инициализируем use case сбора номеров телефонов (кнопка request_contact после приветствия)
+	contactMessageUC := contact_message.New(telegramSvc, userServiceClient)
+	log.Info("Contact message use case initialized")
 
-		if err := telegramSvc.SetWebhook(cfg.Telegram.WebhookURL); err != nil {
-			log.Fatal("Failed to set Telegram webhook: %v", err)
-		}
-		log.Info("Telegram webhook set to %s", cfg.Telegram.WebhookURL)
-	} else {
-		// Режим Long Polling
-		log.Info("Using Long Polling mode")
+	// Инициализируем use case для обработки /start
+	// Подписанные deep-link ссылки /start: приглашения компаний, ссылки на бронирования, рефералы
+	startParamSigner := startparam.NewSigner(cfg.Telegram.StartSecret)
+	startMessageUC := start_message.New(telegramSvc, userServiceClient, botUserRepo, startParamSigner, reachabilitySvc, contactMessageUC, botUpdateRepo, log)
+	log.Info("Start message use case initialized")
 
-		if err := telegramSvc.DeleteWebhook(); err != nil {
-			log.Warn("Failed to delete webhook (may not exist): %v", err)
-		}
+	startLinkUC := start_link.New(startParamSigner, bot.Self.UserName)
+	log.Info("Start link use case initialized (bot @%s)", bot.Self.UserName)
 
-		// Создаём polling handler
-		pollingHandler := worker.NewPollingHandler(startMessageUC, log)
+	// Инициализируем реестр шаблонов
+	templateSvc := templates.NewService(templateRepo, bookingServiceClient, cfg.WebApp.BookingURL, cfg.BookingService.ServiceUserID, cfg.I18n.DefaultLocale)
+	log.Info("Template service initialized")
 
-		// Запускаем long polling в фоне
-		updatesChan := telegramSvc.GetUpdatesChan(0)
-		go pollingHandler.Start(ctx, updatesChan)
-		log.Info("Telegram long polling started")
-	}
+	// Инициализируем сервис настроек уведомлений пользователей
+	preferencesSvc := preferencesservice.NewService(preferencesRepo)
+	log.Info("Preferences service initialized")
 
 	// Инициализируем Notifications Service
-	notificationSvc := notifications.NewService(notificationRepo, userServiceClient)
+	notificationSvc := notifications.NewService(notificationRepo, userServiceClient, templateSvc, botUserRepo, mediaSvc, preferencesSvc)
 	log.Info("Notification service initialized")
 
 	// Инициализируем Worker компоненты
-	scheduler := worker.NewScheduler(notificationRepo, telegramSvc, log)
+	callbackSigner := callbackdata.NewSigner(cfg.Telegram.CallbackSecret)
+	sender := worker.NewSender(notificationRepo, templateSvc, telegramSvc, callbackSigner, preferencesSvc, reachabilitySvc, log, cfg.Worker.SendMaxAttempts)
+	scheduler := worker.NewScheduler(notificationRepo, sender, log)
 	processor := worker.NewProcessor(
 		notificationRepo,
-		telegramSvc,
+		sender,
 		log,
 		time.Duration(cfg.Worker.ProcessorInterval)*time.Second,
 		cfg.Worker.ProcessorBatchSize,
@@ -176,14 +303,166 @@ func main() {
 	log.Info("Notification processor started (interval=%ds, batch=%d)",
 		cfg.Worker.ProcessorInterval, cfg.Worker.ProcessorBatchSize)
 
+	// Инициализируем use case для нажатий callback-кнопок
+	callbackQueryUC := callback_query.New(
+		telegramSvc,
+		callbackSigner,
+		notificationRepo,
+		callbackAuditRepo,
+		bookingServiceClient,
+		scheduler,
+		cfg.Telegram.SnoozeMinutes,
+		log,
+	)
+	log.Info("Callback query use case initialized")
+
+	// Инициализируем use case для предпросмотра уведомлений
+	previewNotificationUC := preview_notification.New(notificationSvc, templateSvc, callbackSigner, telegramSvc, cfg.Telegram.TestChatIDs)
+	log.Info("Preview notification use case initialized (%d test chats)", len(cfg.Telegram.TestChatIDs))
+
+	// Инициализируем use case для изменения и удаления отправленных сообщений
+	sentMessageUC := sent_message.New(notificationRepo, templateSvc, callbackSigner, telegramSvc)
+	log.Info("Sent message use case initialized")
+
+	// Инициализируем use case настроек уведомлений (/settings)
+	settingsMessageUC := settings_message.New(telegramSvc, preferencesSvc)
+	log.Info("Settings message use case initialized")
+
+	// Инициализируем use case списка бронирований (/mybookings)
+	myBookingsUC := my_bookings.New(telegramSvc, bookingServiceClient, cfg.WebApp.BookingURL)
+	log.Info("My bookings use case initialized")
+
+	// Инициализируем реестр групповых чатов: регистрация по my_chat_member, привязка к компании командой /link
+	groupChatSvc := groupchatservice.NewService(groupChatRepo)
+	groupLinkUC := group_link.New(telegramSvc, groupChatSvc, startparam.NewSigner(cfg.Telegram.LinkSecret),
+		time.Duration(cfg.Telegram.LinkCodeTTLHours)*time.Hour, log)
+	log.Info("Group chat registry initialized")
+
+	// Инициализируем use case изменения статуса бота в чатах (my_chat_member)
+	chatMemberUC := chat_member.New(reachabilitySvc, groupChatSvc, log)
+	log.Info("Chat member use case initialized")
+
+	// Инициализируем переписку с поддержкой: сообщения пользователей пересылаются в чат операторов
+	supportSvc := supportservice.NewService(supportRepo)
+	supportMessageUC := support_message.New(telegramSvc, supportSvc, userServiceClient, reachabilitySvc, cfg.Support.OperatorChatID)
+	if cfg.Support.OperatorChatID != 0 {
+		log.Info("Support message use case initialized (operator chat %d)", cfg.Support.OperatorChatID)
+	} else {
+		log.Warn("Support operator chat is not configured: user messages are only stored")
+	}
+
+	// Инициализируем диспетчер обновлений бота: команды и обработчики регистрируются здесь для webhook и long polling
+	var updateMetrics updates.Metrics
+	if cfg.Metrics.Enabled {
+		updateMetrics = metricsCollector
+	}
+	dispatcher := updates.NewDispatcher(updateMetrics, cfg.Metrics.ServiceName, log)
+	helpMessageUC := help_message.New(telegramSvc, dispatcher)
+	dispatcher.HandleCommand("start", startMessageUC.Execute)
+	dispatcher.HandleCommand("help", helpMessageUC.Execute)
+	dispatcher.HandleCommand("settings", settingsMessageUC.Execute)
+	dispatcher.HandleCommand("mybookings", myBookingsUC.Execute)
+	dispatcher.HandleCommand("link", groupLinkUC.ExecuteLink)
+	dispatcher.HandleUnknownCommand(helpMessageUC.ExecuteUnknown)
+	dispatcher.HandleMessage("group:migrate", groupLinkUC.IsMigration, groupLinkUC.ExecuteMigration)
+	dispatcher.HandleMessage("contact", contactMessageUC.IsContact, contactMessageUC.Execute)
+	dispatcher.HandleMessage("support:operator_reply", supportMessageUC.IsOperatorReply, supportMessageUC.ExecuteOperatorReply)
+	dispatcher.HandleMessage("support:inbound", supportMessageUC.IsInbound, supportMessageUC.ExecuteInbound)
+	dispatcher.HandleCallbackPrefix(settings_message.CallbackPrefix, settingsMessageUC.Toggle)
+	dispatcher.HandleCallbackQuery(callbackQueryUC.Execute)
+	dispatcher.HandleMyChatMember(chatMemberUC.ExecuteMyChatMember)
+	log.Info("Update dispatcher initialized (commands: %v)", dispatcher.Commands())
+
+	// Запускаем фоновую обработку обновлений: webhook и long polling сохраняют обновление в очередь,
+	// update_id защищает от повторной обработки после перезапуска и повторной доставки
+	updateProcessor := worker.NewUpdateProcessor(
+		botUpdateRepo,
+		dispatcher,
+		log,
+		time.Duration(cfg.Worker.UpdateInterval)*time.Second,
+		cfg.Worker.UpdateMaxAttempts,
+		time.Duration(cfg.Worker.UpdateRetentionDays)*24*time.Hour,
+	)
+	updateProcessor.Start()
+
+	// Публикуем профиль бота: меню команд из реестра диспетчера, описание и кнопку меню с мини-приложением
+	botProfileTexts := make(map[string]domain.BotProfileTexts, len(cfg.Bot.Texts))
+	for locale, texts := range cfg.Bot.Texts {
+		botProfileTexts[locale] = domain.BotProfileTexts{
+			Description:      texts.Description,
+			ShortDescription: texts.ShortDescription,
+			Commands:         texts.Commands,
+		}
+	}
+	botProfileUC, err := bot_profile.New(telegramSvc, dispatcher, botProfileTexts, cfg.I18n.DefaultLocale, cfg.Bot.MenuButtonText, cfg.Bot.MenuButtonURL)
+	if err != nil {
+		log.Fatal("Invalid bot profile configuration: %v", err)
+	}
+	if err := botProfileUC.Sync(); err != nil {
+		// Профиль не влияет на обработку обновлений: продолжаем работу с прежним профилем
+		log.Warn("Failed to sync bot profile: %v", err)
+	} else {
+		log.Info("Bot profile synced (%d locales, menu button: %q)", len(botProfileTexts), cfg.Bot.MenuButtonText)
+	}
+
+	// Определяем режим работы: Webhook или Long Polling
+	if cfg.Telegram.WebhookURL != "" {
+		// Режим Webhook
+		log.Info("Using Webhook mode")
+
+		if err := telegramSvc.SetWebhook(cfg.Telegram.WebhookURL, cfg.Telegram.WebhookSecret); err != nil {
+			log.Fatal("Failed to set Telegram webhook: %v", err)
+		}
+		log.Info("Telegram webhook set to %s", cfg.Telegram.WebhookURL)
+	} else {
+		// Режим Long Polling
+		log.Info("Using Long Polling mode")
+
+		if err := telegramSvc.DeleteWebhook(); err != nil {
+			log.Warn("Failed to delete webhook (may not exist): %v", err)
+		}
+
+		// Создаём polling handler: обновления проходят через ту же очередь, что и webhook
+		pollingHandler := worker.NewPollingHandler(updateProcessor, botUpdateRepo, bot.Self.ID, log)
+
+		// Продолжаем с сохранённого смещения, чтобы после перезапуска не получить обработанные обновления заново
+		pollingOffset, err := botUpdateRepo.GetPollingOffset(ctx, bot.Self.ID)
+		if err != nil {
+			log.Fatal("Failed to load polling offset: %v", err)
+		}
+
+		// Запускаем long polling в фоне
+		updatesChan := telegramSvc.GetUpdatesChan(int(pollingOffset))
+		go pollingHandler.Start(ctx, updatesChan)
+		log.Info("Telegram long polling started (offset=%d)", pollingOffset)
+	}
+
 	// Инициализируем handlers
 	healthHandler := health.NewHandler()
 	createNotificationHandler := create_notification.NewHandler(notificationSvc, scheduler, log)
 	createBatchNotificationHandler := create_batch_notification.NewHandler(notificationSvc, scheduler, log)
+	previewNotificationHandler := previewhandler.NewHandler(previewNotificationUC, log)
 	listNotificationsHandler := list_notifications.NewHandler(notificationSvc, log)
 	cancelNotificationHandler := cancel_notification.NewHandler(notificationSvc, scheduler, log)
 	cancelBatchNotificationHandler := cancel_batch_notification.NewHandler(notificationSvc, log)
-	telegramWebhookHandler := telegram_webhook.NewHandler(startMessageUC, log)
+	editNotificationMessageHandler := edit_notification_message.NewHandler(sentMessageUC, log)
+	deleteNotificationMessageHandler := delete_notification_message.NewHandler(sentMessageUC, log)
+	createStartLinkHandler := create_start_link.NewHandler(startLinkUC, log)
+	getPreferencesHandler := get_preferences.NewHandler(preferencesSvc, log)
+	updatePreferencesHandler := update_preferences.NewHandler(preferencesSvc, log)
+	listUnreachableChatsHandler := list_unreachable_chats.NewHandler(reachabilitySvc, log)
+	listSupportConversationsHandler := list_support_conversations.NewHandler(supportSvc, log)
+	listSupportMessagesHandler := list_support_messages.NewHandler(supportSvc, log)
+	createCompanyLinkCodeHandler := create_company_link_code.NewHandler(groupLinkUC, log)
+	listCompanyChatsHandler := list_company_chats.NewHandler(groupChatSvc, log)
+	telegramWebhookHandler := telegram_webhook.NewHandler(updateProcessor, cfg.Telegram.WebhookSecret, log)
+	createTemplateHandler := create_template.NewHandler(templateSvc, log)
+	listTemplatesHandler := list_templates.NewHandler(templateSvc, log)
+	getTemplateHandler := get_template.NewHandler(templateSvc, log)
+	listTemplateVersionsHandler := list_template_versions.NewHandler(templateSvc, log)
+	updateTemplateHandler := update_template.NewHandler(templateSvc, log)
+	deleteTemplateHandler := delete_template.NewHandler(templateSvc, log)
+	uploadMediaHandler := upload_media.NewHandler(mediaSvc, log)
 
 	// Настраиваем роутер
 	r := mux.NewRouter()
@@ -210,9 +489,41 @@ func main() {
 	// Notifications endpoints
 	api.HandleFunc("/notifications", createNotificationHandler.Handle).Methods(http.MethodPost)
 	api.HandleFunc("/notifications/batch", createBatchNotificationHandler.Handle).Methods(http.MethodPost)
+	api.HandleFunc("/notifications/preview", previewNotificationHandler.Handle).Methods(http.MethodPost)
 	api.HandleFunc("/notifications", listNotificationsHandler.Handle).Methods(http.MethodGet)
 	api.HandleFunc("/notifications/{id}", cancelNotificationHandler.Handle).Methods(http.MethodDelete)
 	api.HandleFunc("/notifications/batch/{span_id}", cancelBatchNotificationHandler.Handle).Methods(http.MethodDelete)
+	api.HandleFunc("/notifications/{id}/message", editNotificationMessageHandler.Handle).Methods(http.MethodPatch)
+	api.HandleFunc("/notifications/{id}/message", deleteNotificationMessageHandler.Handle).Methods(http.MethodDelete)
+
+	// Templates endpoints
+	api.HandleFunc("/templates", createTemplateHandler.Handle).Methods(http.MethodPost)
+	api.HandleFunc("/templates", listTemplatesHandler.Handle).Methods(http.MethodGet)
+	api.HandleFunc("/templates/{name}", getTemplateHandler.Handle).Methods(http.MethodGet)
+	api.HandleFunc("/templates/{name}/versions", listTemplateVersionsHandler.Handle).Methods(http.MethodGet)
+	api.HandleFunc("/templates/{name}", updateTemplateHandler.Handle).Methods(http.MethodPut)
+	api.HandleFunc("/templates/{name}", deleteTemplateHandler.Handle).Methods(http.MethodDelete)
+
+	// Preferences endpoints
+	api.HandleFunc("/users/{tg_user_id}/preferences", getPreferencesHandler.Handle).Methods(http.MethodGet)
+	api.HandleFunc("/users/{tg_user_id}/preferences", updatePreferencesHandler.Handle).Methods(http.MethodPatch)
+
+	// Reachability endpoints
+	api.HandleFunc("/chats/unreachable", listUnreachableChatsHandler.Handle).Methods(http.MethodGet)
+
+	// Support endpoints
+	api.HandleFunc("/support/conversations", listSupportConversationsHandler.Handle).Methods(http.MethodGet)
+	api.HandleFunc("/support/conversations/{tg_user_id}/messages", listSupportMessagesHandler.Handle).Methods(http.MethodGet)
+
+	// Company chat endpoints
+	api.HandleFunc("/companies/{company_id}/link-code", createCompanyLinkCodeHandler.Handle).Methods(http.MethodPost)
+	api.HandleFunc("/companies/{company_id}/chats", listCompanyChatsHandler.Handle).Methods(http.MethodGet)
+
+	// Deep-link endpoints
+	api.HandleFunc("/start-links", createStartLinkHandler.Handle).Methods(http.MethodPost)
+
+	// Media endpoints
+	api.HandleFunc("/media", uploadMediaHandler.Handle).Methods(http.MethodPost)
 
 	// Создаем HTTP сервер
 	addr := fmt.Sprintf(":%d", cfg.Server.HTTPPort)
@@ -242,6 +553,7 @@ func main() {
 	// КРИТИЧНО: Останавливаем Worker ПЕРЕД сервером
 	processor.Stop()
 	scheduler.Stop()
+	updateProcessor.Stop()
 	log.Info("Worker components stopped")
 
 	// Останавливаем сбор метрик
@@ -13,6 +13,8 @@ import (
 const (
 	msgInvalidRequestBody = "неверный формат тела запроса"
 	msgEmptyRecipientList = "список telegram_user_ids не может быть пустым"
+	msgTemplateNotFound   = "шаблон не найден"
+	msgBookingNotFound    = "бронирование не найдено"
 )
 
 type Handler struct {
@@ -53,6 +55,14 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 			handlers.RespondBadRequest(w, err.Error())
 			return
 		}
+		if errors.Is(err, notificationsSvc.ErrTemplateNotFound) {
+			handlers.RespondBadRequest(w, msgTemplateNotFound)
+			return
+		}
+		if errors.Is(err, notificationsSvc.ErrBookingNotFound) {
+			handlers.RespondBadRequest(w, msgBookingNotFound)
+			return
+		}
 
 		h.logger.Error("Failed to create batch notification: %v", err)
 		handlers.RespondInternalError(w)
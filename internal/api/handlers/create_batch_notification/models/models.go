@@ -10,9 +10,15 @@ import (
 // CreateBatchNotificationRequest HTTP запрос на создание массовой рассылки
 type CreateBatchNotificationRequest struct {
 	TelegramUserIDs []int64                 `json:"telegram_user_ids"`
-	MessageText     string                  `json:"message_text"`
+	MessageText     string                  `json:"message_text,omitempty"`
+	ParseMode       *string                 `json:"parse_mode,omitempty"` // HTML (по умолчанию) или "" - без форматирования
+	Template        *string                 `json:"template,omitempty"`   // Имя шаблона из реестра (вместо message_text)
+	Variables       domain.Metadata         `json:"variables,omitempty"`  // Переменные для рендеринга шаблона
+	Locale          *string                 `json:"locale,omitempty"`     // Язык (по умолчанию - язык получателя)
 	ImageURLs       []string                `json:"image_urls,omitempty"`
+	Attachments     []domain.Attachment     `json:"attachments,omitempty"`
 	InlineButtons   []domain.InlineButton   `json:"inline_buttons,omitempty"`
+	DeliveryOptions *domain.DeliveryOptions `json:"delivery_options,omitempty"`
 	Type            domain.NotificationType `json:"type"`
 	ScheduledFor    *time.Time              `json:"scheduled_for,omitempty"`
 	Metadata        domain.Metadata         `json:"metadata,omitempty"`
@@ -21,13 +27,19 @@ type CreateBatchNotificationRequest struct {
 // ToServiceInput преобразует HTTP модель в сервисную модель
 func (r *CreateBatchNotificationRequest) ToServiceInput() *serviceModels.CreateBatchNotificationInput {
 	return &serviceModels.CreateBatchNotificationInput{
-		TelegramUserIDs: r.TelegramUserIDs,
-		MessageText:     r.MessageText,
-		ImageURLs:       r.ImageURLs,
-		InlineButtons:   r.InlineButtons,
-		Type:            r.Type,
-		ScheduledFor:    r.ScheduledFor,
-		Metadata:        r.Metadata,
+		TelegramUserIDs:   r.TelegramUserIDs,
+		MessageText:       r.MessageText,
+		ParseMode:         r.ParseMode,
+		TemplateName:      r.Template,
+		TemplateVariables: r.Variables,
+		Locale:            r.Locale,
+		ImageURLs:         r.ImageURLs,
+		Attachments:       r.Attachments,
+		InlineButtons:     r.InlineButtons,
+		DeliveryOptions:   r.DeliveryOptions,
+		Type:              r.Type,
+		ScheduledFor:      r.ScheduledFor,
+		Metadata:          r.Metadata,
 	}
 }
 
@@ -37,6 +49,7 @@ type BatchNotificationResponse struct {
 	TotalCreated    int     `json:"total_created"`
 	NotificationIDs []int64 `json:"notification_ids"`
 	FailedUserIDs   []int64 `json:"failed_user_ids,omitempty"`
+	SkippedUserIDs  []int64 `json:"skipped_user_ids,omitempty"` // Получатели, отключившие этот тип уведомлений
 }
 
 // FromServiceResult преобразует сервисный результат в HTTP ответ
@@ -46,5 +59,6 @@ func FromServiceResult(result *serviceModels.BatchNotificationResult) *BatchNoti
 		TotalCreated:    result.TotalCreated,
 		NotificationIDs: result.NotificationIDs,
 		FailedUserIDs:   result.FailedUserIDs,
+		SkippedUserIDs:  result.SkippedUserIDs,
 	}
 }
@@ -0,0 +1,15 @@
+package create_company_link_code
+
+import "github.com/m04kA/SMC-NotificationService/internal/usecase/group_link"
+
+// GroupLinkUseCase интерфейс use case привязки групповых чатов
+type GroupLinkUseCase interface {
+	CreateCode(companyID int64) (*group_link.LinkCode, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
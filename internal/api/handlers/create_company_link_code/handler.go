@@ -0,0 +1,56 @@
+package create_company_link_code
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/create_company_link_code/models"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/group_link"
+)
+
+const (
+	msgInvalidCompanyID = "неверный ID компании"
+)
+
+type Handler struct {
+	useCase GroupLinkUseCase
+	logger  Logger
+}
+
+func NewHandler(useCase GroupLinkUseCase, logger Logger) *Handler {
+	return &Handler{
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["company_id"]
+
+	companyID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("Invalid company ID: %s", idStr)
+		handlers.RespondBadRequest(w, msgInvalidCompanyID)
+		return
+	}
+
+	code, err := h.useCase.CreateCode(companyID)
+	if err != nil {
+		// Обработка ошибок use case
+		if errors.Is(err, group_link.ErrInvalidInput) {
+			handlers.RespondBadRequest(w, msgInvalidCompanyID)
+			return
+		}
+
+		h.logger.Error("Failed to create link code for company %d: %v", companyID, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	h.logger.Info("Created link code for company %d", companyID)
+
+	handlers.RespondJSON(w, http.StatusCreated, models.FromUseCaseLinkCode(code))
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/group_link"
+)
+
+// LinkCodeResponse HTTP ответ с кодом привязки группового чата к компании
+type LinkCodeResponse struct {
+	CompanyID int64     `json:"company_id"`
+	Code      string    `json:"code"`
+	Command   string    `json:"command"`    // Команда для отправки в группе сотрудников
+	ExpiresAt time.Time `json:"expires_at"` // После этого времени код не принимается
+}
+
+// FromUseCaseLinkCode преобразует модель use case в HTTP ответ
+func FromUseCaseLinkCode(code *group_link.LinkCode) *LinkCodeResponse {
+	return &LinkCodeResponse{
+		CompanyID: code.CompanyID,
+		Code:      code.Code,
+		Command:   code.Command,
+		ExpiresAt: code.ExpiresAt,
+	}
+}
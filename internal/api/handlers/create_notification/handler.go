@@ -14,6 +14,8 @@ const (
 	msgInvalidRequestBody = "неверный формат тела запроса"
 	msgInvalidRecipient   = "необходимо указать telegram_user_id или chat_id"
 	msgUserNotFound       = "пользователь не найден в системе"
+	msgTemplateNotFound   = "шаблон не найден"
+	msgBookingNotFound    = "бронирование не найдено"
 )
 
 type Handler struct {
@@ -58,6 +60,18 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 			handlers.RespondBadRequest(w, msgUserNotFound)
 			return
 		}
+		if errors.Is(err, notificationsSvc.ErrTemplateNotFound) {
+			handlers.RespondBadRequest(w, msgTemplateNotFound)
+			return
+		}
+		if errors.Is(err, notificationsSvc.ErrBookingNotFound) {
+			handlers.RespondBadRequest(w, msgBookingNotFound)
+			return
+		}
+		if errors.Is(err, notificationsSvc.ErrInvalidInput) {
+			handlers.RespondBadRequest(w, err.Error())
+			return
+		}
 
 		h.logger.Error("Failed to create notification: %v", err)
 		handlers.RespondInternalError(w)
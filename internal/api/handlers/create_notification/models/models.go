@@ -9,66 +9,96 @@ import (
 
 // CreateNotificationRequest HTTP запрос на создание уведомления
 type CreateNotificationRequest struct {
-	TelegramUserID *int64                    `json:"telegram_user_id,omitempty"`
-	ChatID         *int64                    `json:"chat_id,omitempty"`
-	MessageText    string                    `json:"message_text"`
-	ImageURLs      []string                  `json:"image_urls,omitempty"`
-	InlineButtons  []domain.InlineButton     `json:"inline_buttons,omitempty"`
-	Type           domain.NotificationType   `json:"type"`
-	ScheduledFor   *time.Time                `json:"scheduled_for,omitempty"`
-	Metadata       domain.Metadata           `json:"metadata,omitempty"`
+	TelegramUserID  *int64                  `json:"telegram_user_id,omitempty"`
+	ChatID          *int64                  `json:"chat_id,omitempty"`
+	MessageText     string                  `json:"message_text,omitempty"`
+	ParseMode       *string                 `json:"parse_mode,omitempty"` // HTML (по умолчанию) или "" - без форматирования
+	Template        *string                 `json:"template,omitempty"`   // Имя шаблона из реестра (вместо message_text)
+	Variables       domain.Metadata         `json:"variables,omitempty"`  // Переменные для рендеринга шаблона
+	Locale          *string                 `json:"locale,omitempty"`     // Язык (по умолчанию - язык получателя)
+	ImageURLs       []string                `json:"image_urls,omitempty"`
+	Attachments     []domain.Attachment     `json:"attachments,omitempty"`
+	InlineButtons   []domain.InlineButton   `json:"inline_buttons,omitempty"`
+	DeliveryOptions *domain.DeliveryOptions `json:"delivery_options,omitempty"`
+	Type            domain.NotificationType `json:"type"`
+	ScheduledFor    *time.Time              `json:"scheduled_for,omitempty"`
+	Metadata        domain.Metadata         `json:"metadata,omitempty"`
 }
 
 // ToServiceInput преобразует HTTP модель в сервисную модель
 func (r *CreateNotificationRequest) ToServiceInput() *serviceModels.CreateNotificationInput {
 	return &serviceModels.CreateNotificationInput{
-		TelegramUserID: r.TelegramUserID,
-		ChatID:         r.ChatID,
-		MessageText:    r.MessageText,
-		ImageURLs:      r.ImageURLs,
-		InlineButtons:  r.InlineButtons,
-		Type:           r.Type,
-		ScheduledFor:   r.ScheduledFor,
-		Metadata:       r.Metadata,
+		TelegramUserID:    r.TelegramUserID,
+		ChatID:            r.ChatID,
+		MessageText:       r.MessageText,
+		ParseMode:         r.ParseMode,
+		TemplateName:      r.Template,
+		TemplateVariables: r.Variables,
+		Locale:            r.Locale,
+		ImageURLs:         r.ImageURLs,
+		Attachments:       r.Attachments,
+		InlineButtons:     r.InlineButtons,
+		DeliveryOptions:   r.DeliveryOptions,
+		Type:              r.Type,
+		ScheduledFor:      r.ScheduledFor,
+		Metadata:          r.Metadata,
 	}
 }
 
 // NotificationResponse HTTP ответ с данными уведомления
 type NotificationResponse struct {
-	ID             int64                     `json:"id"`
-	TelegramUserID *int64                    `json:"telegram_user_id,omitempty"`
-	ChatID         *int64                    `json:"chat_id,omitempty"`
-	MessageText    string                    `json:"message_text"`
-	ImageURLs      []string                  `json:"image_urls,omitempty"`
-	InlineButtons  []domain.InlineButton     `json:"inline_buttons,omitempty"`
-	Type           domain.NotificationType   `json:"type"`
-	Status         domain.NotificationStatus `json:"status"`
-	ScheduledFor   *time.Time                `json:"scheduled_for,omitempty"`
-	SentAt         *time.Time                `json:"sent_at,omitempty"`
-	Metadata       domain.Metadata           `json:"metadata,omitempty"`
-	ErrorMessage   *string                   `json:"error_message,omitempty"`
-	RetryCount     int                       `json:"retry_count"`
-	CreatedAt      time.Time                 `json:"created_at"`
-	UpdatedAt      time.Time                 `json:"updated_at"`
+	ID              int64                     `json:"id"`
+	TelegramUserID  *int64                    `json:"telegram_user_id,omitempty"`
+	ChatID          *int64                    `json:"chat_id,omitempty"`
+	MessageText     string                    `json:"message_text"`
+	ParseMode       *string                   `json:"parse_mode,omitempty"`
+	Template        *string                   `json:"template,omitempty"`
+	TemplateVersion *int                      `json:"template_version,omitempty"`
+	Variables       domain.Metadata           `json:"variables,omitempty"`
+	Locale          *string                   `json:"locale,omitempty"`
+	ImageURLs       []string                  `json:"image_urls,omitempty"`
+	Attachments     []domain.Attachment       `json:"attachments,omitempty"`
+	InlineButtons   []domain.InlineButton     `json:"inline_buttons,omitempty"`
+	DeliveryOptions *domain.DeliveryOptions   `json:"delivery_options,omitempty"`
+	Type            domain.NotificationType   `json:"type"`
+	Status          domain.NotificationStatus `json:"status"`
+	ScheduledFor    *time.Time                `json:"scheduled_for,omitempty"`
+	SentAt          *time.Time                `json:"sent_at,omitempty"`
+	Metadata        domain.Metadata           `json:"metadata,omitempty"`
+	ErrorMessage    *string                   `json:"error_message,omitempty"`
+	SkipReason      *domain.SkipReason        `json:"skip_reason,omitempty"`    // Причина пропуска для статуса skipped: type_disabled, channel_disabled, chat_unreachable
+	DeferredUntil   *time.Time                `json:"deferred_until,omitempty"` // Отложено до конца тихих часов получателя
+	RetryCount      int                       `json:"retry_count"`
+	CreatedAt       time.Time                 `json:"created_at"`
+	UpdatedAt       time.Time                 `json:"updated_at"`
 }
 
 // FromDomainNotification преобразует доменную модель в HTTP ответ
 func FromDomainNotification(n *domain.Notification) *NotificationResponse {
 	return &NotificationResponse{
-		ID:             n.ID,
-		TelegramUserID: n.TelegramUserID,
-		ChatID:         n.ChatID,
-		MessageText:    n.MessageText,
-		ImageURLs:      n.ImageURLs,
-		InlineButtons:  n.InlineButtons,
-		Type:           n.Type,
-		Status:         n.Status,
-		ScheduledFor:   n.ScheduledFor,
-		SentAt:         n.SentAt,
-		Metadata:       n.Metadata,
-		ErrorMessage:   n.ErrorMessage,
-		RetryCount:     n.RetryCount,
-		CreatedAt:      n.CreatedAt,
-		UpdatedAt:      n.UpdatedAt,
+		ID:              n.ID,
+		TelegramUserID:  n.TelegramUserID,
+		ChatID:          n.ChatID,
+		MessageText:     n.MessageText,
+		ParseMode:       n.ParseMode,
+		Template:        n.TemplateName,
+		TemplateVersion: n.TemplateVersion,
+		Variables:       n.TemplateVariables,
+		Locale:          n.Locale,
+		ImageURLs:       n.ImageURLs,
+		Attachments:     n.Attachments,
+		InlineButtons:   n.InlineButtons,
+		DeliveryOptions: n.DeliveryOptions,
+		Type:            n.Type,
+		Status:          n.Status,
+		ScheduledFor:    n.ScheduledFor,
+		SentAt:          n.SentAt,
+		Metadata:        n.Metadata,
+		ErrorMessage:    n.ErrorMessage,
+		SkipReason:      n.SkipReason,
+		DeferredUntil:   n.DeferredUntil,
+		RetryCount:      n.RetryCount,
+		CreatedAt:       n.CreatedAt,
+		UpdatedAt:       n.UpdatedAt,
 	}
 }
@@ -0,0 +1,18 @@
+package create_start_link
+
+import (
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/start_link"
+)
+
+// StartLinkUseCase интерфейс use case формирования deep-link ссылок
+type StartLinkUseCase interface {
+	Create(kind domain.StartKind, value string) (*start_link.StartLink, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
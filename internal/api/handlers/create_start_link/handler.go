@@ -0,0 +1,54 @@
+package create_start_link
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/create_start_link/models"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/start_link"
+)
+
+const (
+	msgInvalidRequestBody = "неверный формат тела запроса"
+)
+
+type Handler struct {
+	useCase StartLinkUseCase
+	logger  Logger
+}
+
+func NewHandler(useCase StartLinkUseCase, logger Logger) *Handler {
+	return &Handler{
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// Парсинг request body
+	var req models.CreateStartLinkRequest
+	if err := handlers.DecodeJSON(r, &req); err != nil {
+		h.logger.Warn("Failed to decode request body: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidRequestBody)
+		return
+	}
+
+	// Формируем подписанную ссылку
+	link, err := h.useCase.Create(req.Kind, req.Value)
+	if err != nil {
+		// Обработка ошибок use case
+		if errors.Is(err, start_link.ErrInvalidInput) {
+			handlers.RespondBadRequest(w, err.Error())
+			return
+		}
+
+		h.logger.Error("Failed to create start link %s=%s: %v", req.Kind, req.Value, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	h.logger.Info("Created start link %s", link.StartParam)
+
+	handlers.RespondJSON(w, http.StatusCreated, models.FromUseCaseLink(link))
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/start_link"
+)
+
+// CreateStartLinkRequest HTTP запрос на формирование deep-link ссылки
+type CreateStartLinkRequest struct {
+	Kind  domain.StartKind `json:"kind"`  // company, booking, ref, source
+	Value string           `json:"value"` // ID компании или бронирования, реферальный код, метка источника
+}
+
+// StartLinkResponse HTTP ответ с deep-link ссылкой
+type StartLinkResponse struct {
+	Kind       domain.StartKind `json:"kind"`
+	Value      string           `json:"value"`
+	StartParam string           `json:"start_param"`
+	URL        string           `json:"url"`
+}
+
+// FromUseCaseLink преобразует модель use case в HTTP ответ
+func FromUseCaseLink(link *start_link.StartLink) *StartLinkResponse {
+	return &StartLinkResponse{
+		Kind:       link.Kind,
+		Value:      link.Value,
+		StartParam: link.StartParam,
+		URL:        link.URL,
+	}
+}
@@ -0,0 +1,59 @@
+package create_template
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/create_template/models"
+	templatesSvc "github.com/m04kA/SMC-NotificationService/internal/service/templates"
+)
+
+const (
+	msgInvalidRequestBody = "неверный формат тела запроса"
+	msgTemplateExists     = "шаблон с таким именем уже существует"
+)
+
+type Handler struct {
+	service TemplateService
+	logger  Logger
+}
+
+func NewHandler(service TemplateService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// Парсинг request body
+	var req models.CreateTemplateRequest
+	if err := handlers.DecodeJSON(r, &req); err != nil {
+		h.logger.Warn("Failed to decode request body: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidRequestBody)
+		return
+	}
+
+	// Создаём шаблон через сервисный слой
+	template, err := h.service.Create(r.Context(), req.ToServiceInput())
+	if err != nil {
+		// Обработка ошибок сервисного слоя
+		if errors.Is(err, templatesSvc.ErrInvalidInput) || errors.Is(err, templatesSvc.ErrInvalidTemplate) {
+			handlers.RespondBadRequest(w, err.Error())
+			return
+		}
+		if errors.Is(err, templatesSvc.ErrTemplateAlreadyExists) {
+			handlers.RespondError(w, http.StatusConflict, msgTemplateExists)
+			return
+		}
+
+		h.logger.Error("Failed to create template %s: %v", req.Name, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	h.logger.Info("Created template %s (version: %d, type: %s)", template.Name, template.Version, template.Type)
+
+	handlers.RespondJSON(w, http.StatusCreated, models.FromDomainTemplate(template))
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	serviceModels "github.com/m04kA/SMC-NotificationService/internal/service/templates/models"
+)
+
+// CreateTemplateRequest HTTP запрос на создание шаблона
+type CreateTemplateRequest struct {
+	Name          string                  `json:"name"`
+	Locale        string                  `json:"locale,omitempty"` // По умолчанию язык по умолчанию сервиса
+	Type          domain.NotificationType `json:"type"`
+	ParseMode     *string                 `json:"parse_mode,omitempty"` // По умолчанию HTML
+	Text          string                  `json:"text"`
+	InlineButtons []domain.InlineButton   `json:"inline_buttons,omitempty"`
+	Description   *string                 `json:"description,omitempty"`
+}
+
+// ToServiceInput преобразует HTTP модель в сервисную модель
+func (r *CreateTemplateRequest) ToServiceInput() *serviceModels.CreateTemplateInput {
+	parseMode := domain.ParseModeHTML
+	if r.ParseMode != nil {
+		parseMode = *r.ParseMode
+	}
+
+	return &serviceModels.CreateTemplateInput{
+		Name:          r.Name,
+		Locale:        r.Locale,
+		Type:          r.Type,
+		ParseMode:     parseMode,
+		Text:          r.Text,
+		InlineButtons: r.InlineButtons,
+		Description:   r.Description,
+	}
+}
+
+// TemplateResponse HTTP ответ с данными шаблона
+type TemplateResponse struct {
+	Name          string                  `json:"name"`
+	Version       int                     `json:"version"`
+	Locale        string                  `json:"locale"`
+	Type          domain.NotificationType `json:"type"`
+	ParseMode     string                  `json:"parse_mode"`
+	Text          string                  `json:"text"`
+	InlineButtons []domain.InlineButton   `json:"inline_buttons,omitempty"`
+	Description   *string                 `json:"description,omitempty"`
+	CreatedAt     time.Time               `json:"created_at"`
+}
+
+// FromDomainTemplate преобразует доменную модель в HTTP ответ
+func FromDomainTemplate(t *domain.Template) *TemplateResponse {
+	return &TemplateResponse{
+		Name:          t.Name,
+		Version:       t.Version,
+		Locale:        t.Locale,
+		Type:          t.Type,
+		ParseMode:     t.ParseMode,
+		Text:          t.Text,
+		InlineButtons: t.InlineButtons,
+		Description:   t.Description,
+		CreatedAt:     t.CreatedAt,
+	}
+}
@@ -0,0 +1,17 @@
+package delete_notification_message
+
+import (
+	"context"
+)
+
+// SentMessageUseCase интерфейс use case работы с отправленными сообщениями
+type SentMessageUseCase interface {
+	Delete(ctx context.Context, id int64) error
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
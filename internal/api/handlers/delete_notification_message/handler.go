@@ -0,0 +1,74 @@
+package delete_notification_message
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/sent_message"
+)
+
+const (
+	msgInvalidID            = "неверный ID уведомления"
+	msgNotificationNotFound = "уведомление не найдено"
+	msgNotSent              = "уведомление ещё не отправлено"
+	msgAlreadyDeleted       = "сообщения уведомления уже удалены из чата"
+	msgDeleteFailed         = "Telegram не удалил сообщения (бот может удалять сообщения не старше 48 часов)"
+)
+
+type Handler struct {
+	useCase SentMessageUseCase
+	logger  Logger
+}
+
+func NewHandler(useCase SentMessageUseCase, logger Logger) *Handler {
+	return &Handler{
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// Извлекаем ID из URL параметров
+	idStr := mux.Vars(r)["id"]
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("Invalid notification ID: %s", idStr)
+		handlers.RespondBadRequest(w, msgInvalidID)
+		return
+	}
+
+	// Удаляем отправленные сообщения из чата
+	if err := h.useCase.Delete(r.Context(), id); err != nil {
+		// Обработка ошибок use case
+		if errors.Is(err, sent_message.ErrNotificationNotFound) {
+			handlers.RespondNotFound(w, msgNotificationNotFound)
+			return
+		}
+		if errors.Is(err, sent_message.ErrNotSent) {
+			handlers.RespondError(w, http.StatusConflict, msgNotSent)
+			return
+		}
+		if errors.Is(err, sent_message.ErrAlreadyDeleted) {
+			handlers.RespondError(w, http.StatusConflict, msgAlreadyDeleted)
+			return
+		}
+		if errors.Is(err, sent_message.ErrTelegram) {
+			h.logger.Warn("Failed to delete messages of notification %d: %v", id, err)
+			handlers.RespondError(w, http.StatusBadGateway, msgDeleteFailed)
+			return
+		}
+
+		h.logger.Error("Failed to delete messages of notification %d: %v", id, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	h.logger.Info("Deleted messages of notification %d", id)
+
+	// Возвращаем успех без тела ответа
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,17 @@
+package delete_template
+
+import (
+	"context"
+)
+
+// TemplateService интерфейс сервиса шаблонов
+type TemplateService interface {
+	Delete(ctx context.Context, name string, locale *string) error
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,54 @@
+package delete_template
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	templatesSvc "github.com/m04kA/SMC-NotificationService/internal/service/templates"
+)
+
+const (
+	msgTemplateNotFound = "шаблон не найден"
+)
+
+type Handler struct {
+	service TemplateService
+	logger  Logger
+}
+
+func NewHandler(service TemplateService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// Извлекаем имя шаблона из URL параметров
+	name := mux.Vars(r)["name"]
+
+	// Без параметра locale удаляются все языковые варианты
+	var locale *string
+	if l := r.URL.Query().Get("locale"); l != "" {
+		locale = &l
+	}
+
+	// Удаляем шаблон через сервисный слой
+	if err := h.service.Delete(r.Context(), name, locale); err != nil {
+		if errors.Is(err, templatesSvc.ErrTemplateNotFound) {
+			handlers.RespondNotFound(w, msgTemplateNotFound)
+			return
+		}
+
+		h.logger.Error("Failed to delete template %s: %v", name, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	h.logger.Info("Deleted template %s", name)
+
+	// Возвращаем успех без тела ответа
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,20 @@
+package edit_notification_message
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/sent_message"
+)
+
+// SentMessageUseCase интерфейс use case работы с отправленными сообщениями
+type SentMessageUseCase interface {
+	Edit(ctx context.Context, id int64, input *sent_message.EditInput) (*domain.Notification, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
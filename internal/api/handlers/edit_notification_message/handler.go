@@ -0,0 +1,92 @@
+package edit_notification_message
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/edit_notification_message/models"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/sent_message"
+)
+
+const (
+	msgInvalidID            = "неверный ID уведомления"
+	msgInvalidRequestBody   = "неверный формат тела запроса"
+	msgNotificationNotFound = "уведомление не найдено"
+	msgNotSent              = "уведомление ещё не отправлено"
+	msgAlreadyDeleted       = "сообщения уведомления удалены из чата"
+	msgEditFailed           = "Telegram не принял изменение сообщения"
+)
+
+type Handler struct {
+	useCase SentMessageUseCase
+	logger  Logger
+}
+
+func NewHandler(useCase SentMessageUseCase, logger Logger) *Handler {
+	return &Handler{
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// Извлекаем ID из URL параметров
+	idStr := mux.Vars(r)["id"]
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("Invalid notification ID: %s", idStr)
+		handlers.RespondBadRequest(w, msgInvalidID)
+		return
+	}
+
+	// Парсинг request body
+	var req models.EditMessageRequest
+	if err := handlers.DecodeJSON(r, &req); err != nil {
+		h.logger.Warn("Failed to decode request body: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidRequestBody)
+		return
+	}
+
+	// Изменяем отправленные сообщения на месте
+	notification, err := h.useCase.Edit(r.Context(), id, req.ToUseCaseInput())
+	if err != nil {
+		// Обработка ошибок use case
+		if errors.Is(err, sent_message.ErrNotificationNotFound) {
+			handlers.RespondNotFound(w, msgNotificationNotFound)
+			return
+		}
+		if errors.Is(err, sent_message.ErrNotSent) {
+			handlers.RespondError(w, http.StatusConflict, msgNotSent)
+			return
+		}
+		if errors.Is(err, sent_message.ErrAlreadyDeleted) {
+			handlers.RespondError(w, http.StatusConflict, msgAlreadyDeleted)
+			return
+		}
+		if errors.Is(err, sent_message.ErrInvalidInput) {
+			handlers.RespondBadRequest(w, err.Error())
+			return
+		}
+		if errors.Is(err, sent_message.ErrNotEditable) {
+			handlers.RespondError(w, http.StatusConflict, err.Error())
+			return
+		}
+		if errors.Is(err, sent_message.ErrTelegram) {
+			h.logger.Warn("Failed to edit messages of notification %d: %v", id, err)
+			handlers.RespondError(w, http.StatusBadGateway, msgEditFailed)
+			return
+		}
+
+		h.logger.Error("Failed to edit messages of notification %d: %v", id, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	h.logger.Info("Edited messages of notification %d", id)
+
+	handlers.RespondJSON(w, http.StatusOK, models.FromDomainNotification(notification))
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/sent_message"
+)
+
+// EditMessageRequest HTTP запрос на изменение отправленного уведомления
+// Незаданные поля остаются такими, какими были при отправке
+type EditMessageRequest struct {
+	MessageText   *string                `json:"message_text,omitempty"`
+	ParseMode     *string                `json:"parse_mode,omitempty"`
+	InlineButtons *[]domain.InlineButton `json:"inline_buttons,omitempty"` // Пустой список убирает кнопки
+}
+
+// ToUseCaseInput преобразует HTTP модель в модель use case
+func (r *EditMessageRequest) ToUseCaseInput() *sent_message.EditInput {
+	return &sent_message.EditInput{
+		Text:          r.MessageText,
+		ParseMode:     r.ParseMode,
+		InlineButtons: r.InlineButtons,
+	}
+}
+
+// EditMessageResponse HTTP ответ с сообщениями уведомления после изменения
+type EditMessageResponse struct {
+	ID               int64                `json:"id"`
+	SentMessages     []domain.SentMessage `json:"sent_messages"`
+	MessagesEditedAt *time.Time           `json:"messages_edited_at"`
+}
+
+// FromDomainNotification преобразует доменную модель в HTTP ответ
+func FromDomainNotification(n *domain.Notification) *EditMessageResponse {
+	return &EditMessageResponse{
+		ID:               n.ID,
+		SentMessages:     n.SentMessages,
+		MessagesEditedAt: n.MessagesEditedAt,
+	}
+}
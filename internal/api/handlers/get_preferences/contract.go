@@ -0,0 +1,19 @@
+package get_preferences
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// PreferencesService интерфейс сервиса настроек уведомлений
+type PreferencesService interface {
+	Get(ctx context.Context, tgUserID int64) (*domain.UserPreferences, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,48 @@
+package get_preferences
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/get_preferences/models"
+)
+
+const (
+	msgInvalidUserID = "неверный Telegram ID пользователя"
+)
+
+type Handler struct {
+	service PreferencesService
+	logger  Logger
+}
+
+func NewHandler(service PreferencesService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// Извлекаем Telegram ID из URL параметров
+	idStr := mux.Vars(r)["tg_user_id"]
+
+	tgUserID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("Invalid Telegram user ID: %s", idStr)
+		handlers.RespondBadRequest(w, msgInvalidUserID)
+		return
+	}
+
+	// Пользователь без сохранённых настроек получает настройки по умолчанию
+	preferences, err := h.service.Get(r.Context(), tgUserID)
+	if err != nil {
+		h.logger.Error("Failed to get preferences of user %d: %v", tgUserID, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	handlers.RespondJSON(w, http.StatusOK, models.FromDomainPreferences(preferences))
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/types"
+)
+
+// PreferencesResponse HTTP ответ с настройками уведомлений пользователя
+type PreferencesResponse struct {
+	TgUserID       int64                               `json:"tg_user_id"`
+	Types          map[domain.NotificationType]bool    `json:"types"`           // Типы, которые пользователь может отключить
+	Channels       map[domain.NotificationChannel]bool `json:"channels"`        // Каналы доставки
+	MandatoryTypes []domain.NotificationType           `json:"mandatory_types"` // Уведомления о бронированиях, доставляются всегда
+	TimeZone       string                              `json:"time_zone"`       // Часовой пояс IANA (UTC по умолчанию)
+	QuietHours     *QuietHours                         `json:"quiet_hours"`     // Тихие часы (null - не заданы)
+}
+
+// QuietHours тихие часы в формате HH:MM по часовому поясу пользователя
+type QuietHours struct {
+	Start types.TimeString `json:"start"`
+	End   types.TimeString `json:"end"`
+}
+
+// FromDomainPreferences преобразует доменную модель в HTTP ответ
+func FromDomainPreferences(p *domain.UserPreferences) *PreferencesResponse {
+	response := &PreferencesResponse{
+		TgUserID:       p.TgUserID,
+		Types:          make(map[domain.NotificationType]bool, len(domain.OptionalNotificationTypes)),
+		Channels:       make(map[domain.NotificationChannel]bool, len(domain.NotificationChannels)),
+		MandatoryTypes: domain.MandatoryNotificationTypes,
+		TimeZone:       p.Location().String(),
+	}
+	if p.QuietHours != nil {
+		response.QuietHours = &QuietHours{Start: p.QuietHours.Start, End: p.QuietHours.End}
+	}
+	for _, t := range domain.OptionalNotificationTypes {
+		response.Types[t] = p.IsTypeEnabled(t)
+	}
+	for _, c := range domain.NotificationChannels {
+		response.Channels[c] = p.IsChannelEnabled(c)
+	}
+	return response
+}
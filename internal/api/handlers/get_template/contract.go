@@ -0,0 +1,20 @@
+package get_template
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// TemplateService интерфейс сервиса шаблонов
+type TemplateService interface {
+	Get(ctx context.Context, name, locale string) (*domain.Template, error)
+	GetVersion(ctx context.Context, name, locale string, version int) (*domain.Template, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,71 @@
+package get_template
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/get_template/models"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	templatesSvc "github.com/m04kA/SMC-NotificationService/internal/service/templates"
+)
+
+const (
+	msgInvalidVersion   = "неверный номер версии"
+	msgTemplateNotFound = "шаблон не найден"
+)
+
+type Handler struct {
+	service TemplateService
+	logger  Logger
+}
+
+func NewHandler(service TemplateService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// Извлекаем имя шаблона из URL параметров
+	name := mux.Vars(r)["name"]
+
+	// Без параметра locale возвращаем вариант на языке по умолчанию
+	locale := r.URL.Query().Get("locale")
+
+	var template *domain.Template
+	var err error
+
+	// Без параметра version возвращаем последнюю версию
+	if versionStr := r.URL.Query().Get("version"); versionStr != "" {
+		version, parseErr := strconv.Atoi(versionStr)
+		if parseErr != nil || version < 1 {
+			h.logger.Warn("Invalid template version: %s", versionStr)
+			handlers.RespondBadRequest(w, msgInvalidVersion)
+			return
+		}
+		template, err = h.service.GetVersion(r.Context(), name, locale, version)
+	} else {
+		template, err = h.service.Get(r.Context(), name, locale)
+	}
+
+	if err != nil {
+		if errors.Is(err, templatesSvc.ErrTemplateNotFound) {
+			handlers.RespondNotFound(w, msgTemplateNotFound)
+			return
+		}
+		if errors.Is(err, templatesSvc.ErrInvalidInput) {
+			handlers.RespondBadRequest(w, err.Error())
+			return
+		}
+
+		h.logger.Error("Failed to get template %s: %v", name, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	handlers.RespondJSON(w, http.StatusOK, models.FromDomainTemplate(template))
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// TemplateResponse HTTP ответ с данными шаблона
+type TemplateResponse struct {
+	Name          string                  `json:"name"`
+	Version       int                     `json:"version"`
+	Locale        string                  `json:"locale"`
+	Type          domain.NotificationType `json:"type"`
+	ParseMode     string                  `json:"parse_mode"`
+	Text          string                  `json:"text"`
+	InlineButtons []domain.InlineButton   `json:"inline_buttons,omitempty"`
+	Description   *string                 `json:"description,omitempty"`
+	CreatedAt     time.Time               `json:"created_at"`
+}
+
+// FromDomainTemplate преобразует доменную модель в HTTP ответ
+func FromDomainTemplate(t *domain.Template) *TemplateResponse {
+	return &TemplateResponse{
+		Name:          t.Name,
+		Version:       t.Version,
+		Locale:        t.Locale,
+		Type:          t.Type,
+		ParseMode:     t.ParseMode,
+		Text:          t.Text,
+		InlineButtons: t.InlineButtons,
+		Description:   t.Description,
+		CreatedAt:     t.CreatedAt,
+	}
+}
@@ -0,0 +1,19 @@
+package list_company_chats
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// GroupChatService интерфейс сервиса групповых чатов
+type GroupChatService interface {
+	ListByCompany(ctx context.Context, companyID int64, limit, offset int) ([]*domain.GroupChat, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,86 @@
+package list_company_chats
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/list_company_chats/models"
+)
+
+const (
+	msgInvalidCompanyID = "неверный ID компании"
+)
+
+type Handler struct {
+	service GroupChatService
+	logger  Logger
+}
+
+func NewHandler(service GroupChatService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["company_id"]
+
+	companyID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("Invalid company ID: %s", idStr)
+		handlers.RespondBadRequest(w, msgInvalidCompanyID)
+		return
+	}
+
+	// Парсим query параметры
+	page, limit, err := h.parseQuery(r)
+	if err != nil {
+		h.logger.Warn("Invalid query parameters: %v", err)
+		handlers.RespondBadRequest(w, err.Error())
+		return
+	}
+
+	chats, err := h.service.ListByCompany(r.Context(), companyID, limit, (page-1)*limit)
+	if err != nil {
+		h.logger.Error("Failed to list chats of company %d: %v", companyID, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	handlers.RespondJSON(w, http.StatusOK, models.FromDomainChats(companyID, chats, page, limit))
+}
+
+// parseQuery парсит параметры пагинации из HTTP запроса
+func (h *Handler) parseQuery(r *http.Request) (int, int, error) {
+	queryParams := r.URL.Query()
+
+	page := models.DefaultPage
+	limit := models.DefaultLimit
+
+	// Парсим page
+	if pageStr := queryParams.Get("page"); pageStr != "" {
+		p, err := strconv.Atoi(pageStr)
+		if err != nil || p < 1 {
+			return 0, 0, fmt.Errorf("invalid page: %s", pageStr)
+		}
+		page = p
+	}
+
+	// Парсим limit
+	if limitStr := queryParams.Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l < 1 {
+			return 0, 0, fmt.Errorf("invalid limit: %s", limitStr)
+		}
+		limit = l
+	}
+	if limit > models.MaxLimit {
+		limit = models.MaxLimit
+	}
+
+	return page, limit, nil
+}
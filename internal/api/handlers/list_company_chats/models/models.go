@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+const (
+	DefaultPage  = 1
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// CompanyChatResponse HTTP ответ с групповым чатом компании
+type CompanyChatResponse struct {
+	ChatID           int64                `json:"chat_id"` // Используется как chat_id получателя уведомлений
+	Type             domain.GroupChatType `json:"type"`    // group, supergroup
+	Title            string               `json:"title"`
+	LinkedByTgUserID *int64               `json:"linked_by_tg_user_id,omitempty"`
+	LinkedAt         *time.Time           `json:"linked_at"`
+	UpdatedAt        time.Time            `json:"updated_at"`
+}
+
+// FromDomainChat преобразует доменную модель в HTTP ответ
+func FromDomainChat(c *domain.GroupChat) *CompanyChatResponse {
+	return &CompanyChatResponse{
+		ChatID:           c.ChatID,
+		Type:             c.Type,
+		Title:            c.Title,
+		LinkedByTgUserID: c.LinkedByTgUserID,
+		LinkedAt:         c.LinkedAt,
+		UpdatedAt:        c.UpdatedAt,
+	}
+}
+
+// ListCompanyChatsResponse HTTP ответ со списком групповых чатов компании
+type ListCompanyChatsResponse struct {
+	CompanyID int64                  `json:"company_id"`
+	Chats     []*CompanyChatResponse `json:"chats"`
+	Page      int                    `json:"page"`
+	Limit     int                    `json:"limit"`
+}
+
+// FromDomainChats преобразует доменные модели в HTTP ответ
+func FromDomainChats(companyID int64, chats []*domain.GroupChat, page, limit int) *ListCompanyChatsResponse {
+	items := make([]*CompanyChatResponse, len(chats))
+	for i, c := range chats {
+		items[i] = FromDomainChat(c)
+	}
+
+	return &ListCompanyChatsResponse{
+		CompanyID: companyID,
+		Chats:     items,
+		Page:      page,
+		Limit:     limit,
+	}
+}
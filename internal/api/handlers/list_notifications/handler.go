@@ -40,6 +40,7 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 	serviceFilter := serviceModels.ListNotificationsFilter{
 		Status:         filter.Status,
 		Type:           filter.Type,
+		SkipReason:     filter.SkipReason,
 		TelegramUserID: filter.TelegramUserID,
 		SpanID:         filter.SpanID,
 		Limit:          filter.Limit,
@@ -76,6 +77,11 @@ func (h *Handler) parseQuery(r *http.Request) (*models.ListNotificationsQuery, e
 		query.Type = &typeStr
 	}
 
+	// Парсим skip_reason
+	if skipReasonStr := queryParams.Get("skip_reason"); skipReasonStr != "" {
+		query.SkipReason = &skipReasonStr
+	}
+
 	// Парсим telegram_user_id
 	if userIDStr := queryParams.Get("telegram_user_id"); userIDStr != "" {
 		userID, err := strconv.ParseInt(userIDStr, 10, 64)
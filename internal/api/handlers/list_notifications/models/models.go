@@ -18,6 +18,7 @@ const (
 type ListNotificationsQuery struct {
 	Status         *string `json:"status,omitempty"`
 	Type           *string `json:"type,omitempty"`
+	SkipReason     *string `json:"skip_reason,omitempty"`
 	TelegramUserID *int64  `json:"telegram_user_id,omitempty"`
 	SpanID         *string `json:"span_id,omitempty"`
 	Page           int     `json:"page"`
@@ -61,48 +62,77 @@ func (q *ListNotificationsQuery) ToRepositoryFilter() *repoModels.ListFilter {
 		filter.Type = &notifType
 	}
 
+	if q.SkipReason != nil {
+		skipReason := domain.SkipReason(*q.SkipReason)
+		filter.SkipReason = &skipReason
+	}
+
 	return filter
 }
 
 // NotificationResponse HTTP ответ с данными уведомления
 type NotificationResponse struct {
-	ID             int64                     `json:"id"`
-	TelegramUserID *int64                    `json:"telegram_user_id,omitempty"`
-	ChatID         *int64                    `json:"chat_id,omitempty"`
-	SpanID         *string                   `json:"span_id,omitempty"`
-	MessageText    string                    `json:"message_text"`
-	ImageURLs      []string                  `json:"image_urls,omitempty"`
-	InlineButtons  []domain.InlineButton     `json:"inline_buttons,omitempty"`
-	Type           domain.NotificationType   `json:"type"`
-	Status         domain.NotificationStatus `json:"status"`
-	ScheduledFor   *time.Time                `json:"scheduled_for,omitempty"`
-	SentAt         *time.Time                `json:"sent_at,omitempty"`
-	Metadata       domain.Metadata           `json:"metadata,omitempty"`
-	ErrorMessage   *string                   `json:"error_message,omitempty"`
-	RetryCount     int                       `json:"retry_count"`
-	CreatedAt      time.Time                 `json:"created_at"`
-	UpdatedAt      time.Time                 `json:"updated_at"`
+	ID              int64                     `json:"id"`
+	TelegramUserID  *int64                    `json:"telegram_user_id,omitempty"`
+	ChatID          *int64                    `json:"chat_id,omitempty"`
+	SpanID          *string                   `json:"span_id,omitempty"`
+	MessageText     string                    `json:"message_text"`
+	ParseMode       *string                   `json:"parse_mode,omitempty"`
+	Template        *string                   `json:"template,omitempty"`
+	TemplateVersion *int                      `json:"template_version,omitempty"`
+	Variables       domain.Metadata           `json:"variables,omitempty"`
+	Locale          *string                   `json:"locale,omitempty"`
+	ImageURLs       []string                  `json:"image_urls,omitempty"`
+	Attachments     []domain.Attachment       `json:"attachments,omitempty"`
+	InlineButtons   []domain.InlineButton     `json:"inline_buttons,omitempty"`
+	DeliveryOptions *domain.DeliveryOptions   `json:"delivery_options,omitempty"`
+	Type            domain.NotificationType   `json:"type"`
+	Status          domain.NotificationStatus `json:"status"`
+	ScheduledFor    *time.Time                `json:"scheduled_for,omitempty"`
+	SentAt          *time.Time                `json:"sent_at,omitempty"`
+	SentMessages    []domain.SentMessage      `json:"sent_messages,omitempty"`       // Отправленные сообщения Telegram
+	MessagesEdited  *time.Time                `json:"messages_edited_at,omitempty"`  // Время редактирования отправленных сообщений
+	MessagesDeleted *time.Time                `json:"messages_deleted_at,omitempty"` // Время удаления отправленных сообщений
+	Metadata        domain.Metadata           `json:"metadata,omitempty"`
+	ErrorMessage    *string                   `json:"error_message,omitempty"`
+	SkipReason      *domain.SkipReason        `json:"skip_reason,omitempty"`    // Причина пропуска для статуса skipped: type_disabled, channel_disabled, chat_unreachable
+	DeferredUntil   *time.Time                `json:"deferred_until,omitempty"` // Отложено до конца тихих часов получателя
+	RetryCount      int                       `json:"retry_count"`
+	CreatedAt       time.Time                 `json:"created_at"`
+	UpdatedAt       time.Time                 `json:"updated_at"`
 }
 
 // FromDomainNotification преобразует доменную модель в HTTP ответ
 func FromDomainNotification(n *domain.Notification) *NotificationResponse {
 	return &NotificationResponse{
-		ID:             n.ID,
-		TelegramUserID: n.TelegramUserID,
-		ChatID:         n.ChatID,
-		SpanID:         n.SpanID,
-		MessageText:    n.MessageText,
-		ImageURLs:      n.ImageURLs,
-		InlineButtons:  n.InlineButtons,
-		Type:           n.Type,
-		Status:         n.Status,
-		ScheduledFor:   n.ScheduledFor,
-		SentAt:         n.SentAt,
-		Metadata:       n.Metadata,
-		ErrorMessage:   n.ErrorMessage,
-		RetryCount:     n.RetryCount,
-		CreatedAt:      n.CreatedAt,
-		UpdatedAt:      n.UpdatedAt,
+		ID:              n.ID,
+		TelegramUserID:  n.TelegramUserID,
+		ChatID:          n.ChatID,
+		SpanID:          n.SpanID,
+		MessageText:     n.MessageText,
+		ParseMode:       n.ParseMode,
+		Template:        n.TemplateName,
+		TemplateVersion: n.TemplateVersion,
+		Variables:       n.TemplateVariables,
+		Locale:          n.Locale,
+		ImageURLs:       n.ImageURLs,
+		Attachments:     n.Attachments,
+		InlineButtons:   n.InlineButtons,
+		DeliveryOptions: n.DeliveryOptions,
+		Type:            n.Type,
+		Status:          n.Status,
+		ScheduledFor:    n.ScheduledFor,
+		SentAt:          n.SentAt,
+		SentMessages:    n.SentMessages,
+		MessagesEdited:  n.MessagesEditedAt,
+		MessagesDeleted: n.MessagesDeletedAt,
+		Metadata:        n.Metadata,
+		ErrorMessage:    n.ErrorMessage,
+		SkipReason:      n.SkipReason,
+		DeferredUntil:   n.DeferredUntil,
+		RetryCount:      n.RetryCount,
+		CreatedAt:       n.CreatedAt,
+		UpdatedAt:       n.UpdatedAt,
 	}
 }
 
@@ -130,21 +160,33 @@ func FromServiceOutputs(outputs []*serviceModels.NotificationOutput, page, limit
 // FromServiceOutput преобразует сервисную модель в HTTP модель
 func FromServiceOutput(output *serviceModels.NotificationOutput) *NotificationResponse {
 	return &NotificationResponse{
-		ID:             output.ID,
-		TelegramUserID: output.TelegramUserID,
-		ChatID:         output.ChatID,
-		SpanID:         output.SpanID,
-		MessageText:    output.MessageText,
-		ImageURLs:      output.ImageURLs,
-		InlineButtons:  output.InlineButtons,
-		Type:           output.Type,
-		Status:         output.Status,
-		ScheduledFor:   output.ScheduledFor,
-		SentAt:         output.SentAt,
-		Metadata:       output.Metadata,
-		ErrorMessage:   output.ErrorMessage,
-		RetryCount:     output.RetryCount,
-		CreatedAt:      output.CreatedAt,
-		UpdatedAt:      output.UpdatedAt,
+		ID:              output.ID,
+		TelegramUserID:  output.TelegramUserID,
+		ChatID:          output.ChatID,
+		SpanID:          output.SpanID,
+		MessageText:     output.MessageText,
+		ParseMode:       output.ParseMode,
+		Template:        output.TemplateName,
+		TemplateVersion: output.TemplateVersion,
+		Variables:       output.TemplateVariables,
+		Locale:          output.Locale,
+		ImageURLs:       output.ImageURLs,
+		Attachments:     output.Attachments,
+		InlineButtons:   output.InlineButtons,
+		DeliveryOptions: output.DeliveryOptions,
+		Type:            output.Type,
+		Status:          output.Status,
+		ScheduledFor:    output.ScheduledFor,
+		SentAt:          output.SentAt,
+		SentMessages:    output.SentMessages,
+		MessagesEdited:  output.MessagesEditedAt,
+		MessagesDeleted: output.MessagesDeletedAt,
+		Metadata:        output.Metadata,
+		ErrorMessage:    output.ErrorMessage,
+		SkipReason:      output.SkipReason,
+		DeferredUntil:   output.DeferredUntil,
+		RetryCount:      output.RetryCount,
+		CreatedAt:       output.CreatedAt,
+		UpdatedAt:       output.UpdatedAt,
 	}
 }
@@ -0,0 +1,72 @@
+package list_support_conversations
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/list_support_conversations/models"
+)
+
+type Handler struct {
+	service SupportService
+	logger  Logger
+}
+
+func NewHandler(service SupportService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// Парсим query параметры
+	page, limit, err := h.parseQuery(r)
+	if err != nil {
+		h.logger.Warn("Invalid query parameters: %v", err)
+		handlers.RespondBadRequest(w, err.Error())
+		return
+	}
+
+	conversations, err := h.service.ListConversations(r.Context(), limit, (page-1)*limit)
+	if err != nil {
+		h.logger.Error("Failed to list support conversations: %v", err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	handlers.RespondJSON(w, http.StatusOK, models.FromDomainConversations(conversations, page, limit))
+}
+
+// parseQuery парсит параметры пагинации из HTTP запроса
+func (h *Handler) parseQuery(r *http.Request) (int, int, error) {
+	queryParams := r.URL.Query()
+
+	page := models.DefaultPage
+	limit := models.DefaultLimit
+
+	// Парсим page
+	if pageStr := queryParams.Get("page"); pageStr != "" {
+		p, err := strconv.Atoi(pageStr)
+		if err != nil || p < 1 {
+			return 0, 0, fmt.Errorf("invalid page: %s", pageStr)
+		}
+		page = p
+	}
+
+	// Парсим limit
+	if limitStr := queryParams.Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l < 1 {
+			return 0, 0, fmt.Errorf("invalid limit: %s", limitStr)
+		}
+		limit = l
+	}
+	if limit > models.MaxLimit {
+		limit = models.MaxLimit
+	}
+
+	return page, limit, nil
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+const (
+	DefaultPage  = 1
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// ConversationResponse HTTP ответ с данными переписки
+type ConversationResponse struct {
+	TgUserID      int64                   `json:"tg_user_id"`
+	MessageCount  int                     `json:"message_count"`
+	LastText      string                  `json:"last_text"`
+	LastDirection domain.SupportDirection `json:"last_direction"` // inbound - ждёт ответа оператора
+	LastMessageAt time.Time               `json:"last_message_at"`
+}
+
+// FromDomainConversation преобразует доменную модель в HTTP ответ
+func FromDomainConversation(c *domain.SupportConversation) *ConversationResponse {
+	return &ConversationResponse{
+		TgUserID:      c.TgUserID,
+		MessageCount:  c.MessageCount,
+		LastText:      c.LastText,
+		LastDirection: c.LastDirection,
+		LastMessageAt: c.LastMessageAt,
+	}
+}
+
+// ListConversationsResponse HTTP ответ со списком переписок
+type ListConversationsResponse struct {
+	Conversations []*ConversationResponse `json:"conversations"`
+	Page          int                     `json:"page"`
+	Limit         int                     `json:"limit"`
+}
+
+// FromDomainConversations преобразует доменные модели в HTTP ответ
+func FromDomainConversations(conversations []*domain.SupportConversation, page, limit int) *ListConversationsResponse {
+	items := make([]*ConversationResponse, len(conversations))
+	for i, c := range conversations {
+		items[i] = FromDomainConversation(c)
+	}
+
+	return &ListConversationsResponse{
+		Conversations: items,
+		Page:          page,
+		Limit:         limit,
+	}
+}
@@ -0,0 +1,19 @@
+package list_support_messages
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// SupportService интерфейс сервиса переписки с поддержкой
+type SupportService interface {
+	ListMessages(ctx context.Context, tgUserID int64, limit, offset int) ([]*domain.SupportMessage, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
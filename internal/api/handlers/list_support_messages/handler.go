@@ -0,0 +1,86 @@
+package list_support_messages
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/list_support_messages/models"
+)
+
+const (
+	msgInvalidUserID = "неверный Telegram ID пользователя"
+)
+
+type Handler struct {
+	service SupportService
+	logger  Logger
+}
+
+func NewHandler(service SupportService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	idStr := mux.Vars(r)["tg_user_id"]
+
+	tgUserID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("Invalid Telegram user ID: %s", idStr)
+		handlers.RespondBadRequest(w, msgInvalidUserID)
+		return
+	}
+
+	// Парсим query параметры
+	page, limit, err := h.parseQuery(r)
+	if err != nil {
+		h.logger.Warn("Invalid query parameters: %v", err)
+		handlers.RespondBadRequest(w, err.Error())
+		return
+	}
+
+	messages, err := h.service.ListMessages(r.Context(), tgUserID, limit, (page-1)*limit)
+	if err != nil {
+		h.logger.Error("Failed to list support messages of user %d: %v", tgUserID, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	handlers.RespondJSON(w, http.StatusOK, models.FromDomainMessages(tgUserID, messages, page, limit))
+}
+
+// parseQuery парсит параметры пагинации из HTTP запроса
+func (h *Handler) parseQuery(r *http.Request) (int, int, error) {
+	queryParams := r.URL.Query()
+
+	page := models.DefaultPage
+	limit := models.DefaultLimit
+
+	// Парсим page
+	if pageStr := queryParams.Get("page"); pageStr != "" {
+		p, err := strconv.Atoi(pageStr)
+		if err != nil || p < 1 {
+			return 0, 0, fmt.Errorf("invalid page: %s", pageStr)
+		}
+		page = p
+	}
+
+	// Парсим limit
+	if limitStr := queryParams.Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l < 1 {
+			return 0, 0, fmt.Errorf("invalid limit: %s", limitStr)
+		}
+		limit = l
+	}
+	if limit > models.MaxLimit {
+		limit = models.MaxLimit
+	}
+
+	return page, limit, nil
+}
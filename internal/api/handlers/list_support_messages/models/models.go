@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+const (
+	DefaultPage  = 1
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// MessageResponse HTTP ответ с сообщением переписки
+type MessageResponse struct {
+	ID               int64                   `json:"id"`
+	Direction        domain.SupportDirection `json:"direction"` // inbound - от пользователя, outbound - ответ оператора
+	Text             string                  `json:"text"`
+	OperatorTgUserID *int64                  `json:"operator_tg_user_id,omitempty"` // Оператор, ответивший пользователю
+	CreatedAt        time.Time               `json:"created_at"`
+}
+
+// FromDomainMessage преобразует доменную модель в HTTP ответ
+func FromDomainMessage(m *domain.SupportMessage) *MessageResponse {
+	return &MessageResponse{
+		ID:               m.ID,
+		Direction:        m.Direction,
+		Text:             m.Text,
+		OperatorTgUserID: m.OperatorTgUserID,
+		CreatedAt:        m.CreatedAt,
+	}
+}
+
+// ListMessagesResponse HTTP ответ со списком сообщений переписки
+type ListMessagesResponse struct {
+	TgUserID int64              `json:"tg_user_id"`
+	Messages []*MessageResponse `json:"messages"`
+	Page     int                `json:"page"`
+	Limit    int                `json:"limit"`
+}
+
+// FromDomainMessages преобразует доменные модели в HTTP ответ
+func FromDomainMessages(tgUserID int64, messages []*domain.SupportMessage, page, limit int) *ListMessagesResponse {
+	items := make([]*MessageResponse, len(messages))
+	for i, m := range messages {
+		items[i] = FromDomainMessage(m)
+	}
+
+	return &ListMessagesResponse{
+		TgUserID: tgUserID,
+		Messages: items,
+		Page:     page,
+		Limit:    limit,
+	}
+}
@@ -0,0 +1,19 @@
+package list_template_versions
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// TemplateService интерфейс сервиса шаблонов
+type TemplateService interface {
+	ListVersions(ctx context.Context, name string, locale *string) ([]*domain.Template, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,81 @@
+package list_template_versions
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	templatesSvc "github.com/m04kA/SMC-NotificationService/internal/service/templates"
+)
+
+const (
+	msgTemplateNotFound = "шаблон не найден"
+)
+
+type Handler struct {
+	service TemplateService
+	logger  Logger
+}
+
+func NewHandler(service TemplateService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// VersionResponse краткая информация о версии шаблона
+type VersionResponse struct {
+	Version   int        `json:"version"`
+	Locale    string     `json:"locale"`
+	Text      string     `json:"text"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// ListVersionsResponse HTTP ответ с историей версий шаблона
+type ListVersionsResponse struct {
+	Name     string             `json:"name"`
+	Versions []*VersionResponse `json:"versions"`
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// Извлекаем имя шаблона из URL параметров
+	name := mux.Vars(r)["name"]
+
+	// Без параметра locale возвращаем версии всех языковых вариантов
+	var locale *string
+	if l := r.URL.Query().Get("locale"); l != "" {
+		locale = &l
+	}
+
+	versions, err := h.service.ListVersions(r.Context(), name, locale)
+	if err != nil {
+		if errors.Is(err, templatesSvc.ErrTemplateNotFound) {
+			handlers.RespondNotFound(w, msgTemplateNotFound)
+			return
+		}
+
+		h.logger.Error("Failed to list versions of template %s: %v", name, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	response := &ListVersionsResponse{
+		Name:     name,
+		Versions: make([]*VersionResponse, len(versions)),
+	}
+	for i, v := range versions {
+		response.Versions[i] = &VersionResponse{
+			Version:   v.Version,
+			Locale:    v.Locale,
+			Text:      v.Text,
+			CreatedAt: v.CreatedAt,
+			DeletedAt: v.DeletedAt,
+		}
+	}
+
+	handlers.RespondJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,89 @@
+package list_templates
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/list_templates/models"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	serviceModels "github.com/m04kA/SMC-NotificationService/internal/service/templates/models"
+)
+
+type Handler struct {
+	service TemplateService
+	logger  Logger
+}
+
+func NewHandler(service TemplateService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// Парсим query параметры
+	filter, page, limit, err := h.parseQuery(r)
+	if err != nil {
+		h.logger.Warn("Invalid query parameters: %v", err)
+		handlers.RespondBadRequest(w, err.Error())
+		return
+	}
+
+	templates, err := h.service.List(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("Failed to list templates: %v", err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	handlers.RespondJSON(w, http.StatusOK, models.FromDomainTemplates(templates, page, limit))
+}
+
+// parseQuery парсит query параметры из HTTP запроса
+func (h *Handler) parseQuery(r *http.Request) (serviceModels.ListTemplatesFilter, int, int, error) {
+	queryParams := r.URL.Query()
+
+	page := models.DefaultPage
+	limit := models.DefaultLimit
+	filter := serviceModels.ListTemplatesFilter{}
+
+	// Парсим type
+	if typeStr := queryParams.Get("type"); typeStr != "" {
+		notifType := domain.NotificationType(typeStr)
+		filter.Type = &notifType
+	}
+
+	// Парсим locale
+	if locale := queryParams.Get("locale"); locale != "" {
+		filter.Locale = &locale
+	}
+
+	// Парсим page
+	if pageStr := queryParams.Get("page"); pageStr != "" {
+		p, err := strconv.Atoi(pageStr)
+		if err != nil || p < 1 {
+			return filter, 0, 0, fmt.Errorf("invalid page: %s", pageStr)
+		}
+		page = p
+	}
+
+	// Парсим limit
+	if limitStr := queryParams.Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l < 1 {
+			return filter, 0, 0, fmt.Errorf("invalid limit: %s", limitStr)
+		}
+		limit = l
+	}
+	if limit > models.MaxLimit {
+		limit = models.MaxLimit
+	}
+
+	filter.Limit = limit
+	filter.Offset = (page - 1) * limit
+
+	return filter, page, limit, nil
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+const (
+	DefaultPage  = 1
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// TemplateResponse HTTP ответ с данными шаблона
+type TemplateResponse struct {
+	Name          string                  `json:"name"`
+	Version       int                     `json:"version"`
+	Locale        string                  `json:"locale"`
+	Type          domain.NotificationType `json:"type"`
+	ParseMode     string                  `json:"parse_mode"`
+	Text          string                  `json:"text"`
+	InlineButtons []domain.InlineButton   `json:"inline_buttons,omitempty"`
+	Description   *string                 `json:"description,omitempty"`
+	CreatedAt     time.Time               `json:"created_at"`
+}
+
+// FromDomainTemplate преобразует доменную модель в HTTP ответ
+func FromDomainTemplate(t *domain.Template) *TemplateResponse {
+	return &TemplateResponse{
+		Name:          t.Name,
+		Version:       t.Version,
+		Locale:        t.Locale,
+		Type:          t.Type,
+		ParseMode:     t.ParseMode,
+		Text:          t.Text,
+		InlineButtons: t.InlineButtons,
+		Description:   t.Description,
+		CreatedAt:     t.CreatedAt,
+	}
+}
+
+// ListTemplatesResponse HTTP ответ со списком шаблонов
+type ListTemplatesResponse struct {
+	Templates []*TemplateResponse `json:"templates"`
+	Page      int                 `json:"page"`
+	Limit     int                 `json:"limit"`
+}
+
+// FromDomainTemplates преобразует доменные модели в HTTP ответ
+func FromDomainTemplates(templates []*domain.Template, page, limit int) *ListTemplatesResponse {
+	items := make([]*TemplateResponse, len(templates))
+	for i, t := range templates {
+		items[i] = FromDomainTemplate(t)
+	}
+
+	return &ListTemplatesResponse{
+		Templates: items,
+		Page:      page,
+		Limit:     limit,
+	}
+}
@@ -0,0 +1,19 @@
+package list_unreachable_chats
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// ReachabilityService интерфейс сервиса доступности чатов
+type ReachabilityService interface {
+	ListUnreachable(ctx context.Context, limit, offset int) ([]*domain.ChatReachability, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+const (
+	DefaultPage  = 1
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// UnreachableChatResponse HTTP ответ с данными недоступного чата
+type UnreachableChatResponse struct {
+	ChatID           int64                     `json:"chat_id"`
+	Reason           *domain.UnreachableReason `json:"reason"`                  // blocked, kicked, deactivated, forbidden
+	Source           domain.ReachabilitySource `json:"source"`                  // send_error, my_chat_member
+	ErrorMessage     *string                   `json:"error_message,omitempty"` // Описание ошибки Telegram
+	UnreachableSince *time.Time                `json:"unreachable_since"`
+	UpdatedAt        time.Time                 `json:"updated_at"`
+}
+
+// FromDomainChat преобразует доменную модель в HTTP ответ
+func FromDomainChat(c *domain.ChatReachability) *UnreachableChatResponse {
+	return &UnreachableChatResponse{
+		ChatID:           c.ChatID,
+		Reason:           c.Reason,
+		Source:           c.Source,
+		ErrorMessage:     c.ErrorMessage,
+		UnreachableSince: c.UnreachableSince,
+		UpdatedAt:        c.UpdatedAt,
+	}
+}
+
+// ListUnreachableChatsResponse HTTP ответ со списком недоступных чатов
+type ListUnreachableChatsResponse struct {
+	Chats []*UnreachableChatResponse `json:"chats"`
+	Page  int                        `json:"page"`
+	Limit int                        `json:"limit"`
+}
+
+// FromDomainChats преобразует доменные модели в HTTP ответ
+func FromDomainChats(chats []*domain.ChatReachability, page, limit int) *ListUnreachableChatsResponse {
+	items := make([]*UnreachableChatResponse, len(chats))
+	for i, c := range chats {
+		items[i] = FromDomainChat(c)
+	}
+
+	return &ListUnreachableChatsResponse{
+		Chats: items,
+		Page:  page,
+		Limit: limit,
+	}
+}
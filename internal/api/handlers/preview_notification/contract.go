@@ -0,0 +1,20 @@
+package preview_notification
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/preview_notification"
+)
+
+// PreviewUseCase интерфейс use case предпросмотра уведомления
+type PreviewUseCase interface {
+	Execute(ctx context.Context, input *models.CreateNotificationInput, sendToChatID *int64) (*preview_notification.Result, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
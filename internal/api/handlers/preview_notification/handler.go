@@ -0,0 +1,88 @@
+package preview_notification
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/preview_notification/models"
+	notificationsSvc "github.com/m04kA/SMC-NotificationService/internal/service/notifications"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/preview_notification"
+)
+
+const (
+	msgInvalidRequestBody = "неверный формат тела запроса"
+	msgInvalidRecipient   = "необходимо указать telegram_user_id или chat_id"
+	msgUserNotFound       = "пользователь не найден в системе"
+	msgTemplateNotFound   = "шаблон не найден"
+	msgBookingNotFound    = "бронирование не найдено"
+	msgChatNotAllowed     = "чат не входит в список разрешённых для тестовой отправки"
+	msgSendFailed         = "Telegram не принял тестовую отправку"
+)
+
+type Handler struct {
+	useCase PreviewUseCase
+	logger  Logger
+}
+
+func NewHandler(useCase PreviewUseCase, logger Logger) *Handler {
+	return &Handler{
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// Парсинг request body
+	var req models.PreviewNotificationRequest
+	if err := handlers.DecodeJSON(r, &req); err != nil {
+		h.logger.Warn("Failed to decode request body: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidRequestBody)
+		return
+	}
+
+	// Проверяем и рендерим уведомление без сохранения
+	result, err := h.useCase.Execute(r.Context(), req.ToServiceInput(), req.SendToChatID)
+	if err != nil {
+		// Обработка ошибок сервисного слоя
+		if errors.Is(err, preview_notification.ErrChatNotAllowed) {
+			handlers.RespondForbidden(w, msgChatNotAllowed)
+			return
+		}
+		if errors.Is(err, notificationsSvc.ErrInvalidRecipient) {
+			handlers.RespondBadRequest(w, msgInvalidRecipient)
+			return
+		}
+		if errors.Is(err, notificationsSvc.ErrUserNotFound) {
+			handlers.RespondBadRequest(w, msgUserNotFound)
+			return
+		}
+		if errors.Is(err, notificationsSvc.ErrTemplateNotFound) {
+			handlers.RespondBadRequest(w, msgTemplateNotFound)
+			return
+		}
+		if errors.Is(err, notificationsSvc.ErrBookingNotFound) {
+			handlers.RespondBadRequest(w, msgBookingNotFound)
+			return
+		}
+		if errors.Is(err, notificationsSvc.ErrInvalidInput) {
+			handlers.RespondBadRequest(w, err.Error())
+			return
+		}
+		if errors.Is(err, preview_notification.ErrSend) {
+			h.logger.Warn("Failed to send notification preview: %v", err)
+			handlers.RespondError(w, http.StatusBadGateway, msgSendFailed)
+			return
+		}
+
+		h.logger.Error("Failed to preview notification: %v", err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	if result.SentChatID != nil {
+		h.logger.Info("Sent notification preview to test chat %d (message_ids=%v)", *result.SentChatID, result.SentMessageIDs)
+	}
+
+	handlers.RespondJSON(w, http.StatusOK, models.FromResult(result))
+}
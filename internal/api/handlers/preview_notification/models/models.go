@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	serviceModels "github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+	"github.com/m04kA/SMC-NotificationService/internal/usecase/preview_notification"
+)
+
+// PreviewNotificationRequest HTTP запрос на предпросмотр уведомления
+// Поля совпадают с запросом на создание уведомления
+type PreviewNotificationRequest struct {
+	TelegramUserID  *int64                  `json:"telegram_user_id,omitempty"`
+	ChatID          *int64                  `json:"chat_id,omitempty"`
+	MessageText     string                  `json:"message_text,omitempty"`
+	ParseMode       *string                 `json:"parse_mode,omitempty"`
+	Template        *string                 `json:"template,omitempty"`
+	Variables       domain.Metadata         `json:"variables,omitempty"`
+	Locale          *string                 `json:"locale,omitempty"`
+	ImageURLs       []string                `json:"image_urls,omitempty"`
+	Attachments     []domain.Attachment     `json:"attachments,omitempty"`
+	InlineButtons   []domain.InlineButton   `json:"inline_buttons,omitempty"`
+	DeliveryOptions *domain.DeliveryOptions `json:"delivery_options,omitempty"`
+	Type            domain.NotificationType `json:"type"`
+	ScheduledFor    *time.Time              `json:"scheduled_for,omitempty"`
+	Metadata        domain.Metadata         `json:"metadata,omitempty"`
+	SendToChatID    *int64                  `json:"send_to_chat_id,omitempty"` // Отправить предпросмотр в тестовый чат из списка разрешённых
+}
+
+// ToServiceInput преобразует HTTP модель в сервисную модель
+func (r *PreviewNotificationRequest) ToServiceInput() *serviceModels.CreateNotificationInput {
+	return &serviceModels.CreateNotificationInput{
+		TelegramUserID:    r.TelegramUserID,
+		ChatID:            r.ChatID,
+		MessageText:       r.MessageText,
+		ParseMode:         r.ParseMode,
+		TemplateName:      r.Template,
+		TemplateVariables: r.Variables,
+		Locale:            r.Locale,
+		ImageURLs:         r.ImageURLs,
+		Attachments:       r.Attachments,
+		InlineButtons:     r.InlineButtons,
+		DeliveryOptions:   r.DeliveryOptions,
+		Type:              r.Type,
+		ScheduledFor:      r.ScheduledFor,
+		Metadata:          r.Metadata,
+	}
+}
+
+// PreviewNotificationResponse HTTP ответ с результатом предпросмотра
+type PreviewNotificationResponse struct {
+	Type            domain.NotificationType  `json:"type"`
+	Template        *string                  `json:"template,omitempty"`
+	TemplateVersion *int                     `json:"template_version,omitempty"`
+	Locale          *string                  `json:"locale,omitempty"`
+	Requests        []domain.TelegramRequest `json:"requests"` // Запросы к Telegram Bot API в порядке выполнения
+	Sent            *SentPreviewResponse     `json:"sent,omitempty"`
+}
+
+// SentPreviewResponse данные тестовой отправки
+type SentPreviewResponse struct {
+	ChatID     int64 `json:"chat_id"`
+	MessageIDs []int `json:"message_ids"`
+}
+
+// FromResult преобразует результат use case в HTTP ответ
+func FromResult(r *preview_notification.Result) *PreviewNotificationResponse {
+	resp := &PreviewNotificationResponse{
+		Type:            r.Notification.Type,
+		Template:        r.Notification.TemplateName,
+		TemplateVersion: r.Notification.TemplateVersion,
+		Locale:          r.Notification.Locale,
+		Requests:        r.Requests,
+	}
+
+	if r.SentChatID != nil {
+		resp.Sent = &SentPreviewResponse{
+			ChatID:     *r.SentChatID,
+			MessageIDs: r.SentMessageIDs,
+		}
+	}
+
+	return resp
+}
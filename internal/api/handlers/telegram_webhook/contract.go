@@ -6,9 +6,10 @@ import (
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
-// StartMessageUseCase интерфейс для обработки команды /start
-type StartMessageUseCase interface {
-	Execute(ctx context.Context, from *tgbotapi.User, chatID int64) error
+// UpdateQueue интерфейс очереди обновлений Telegram
+// Обновление сохраняется и обрабатывается в фоне, повторная доставка того же update_id игнорируется
+type UpdateQueue interface {
+	Enqueue(ctx context.Context, update tgbotapi.Update) error
 }
 
 // Logger интерфейс для логирования
@@ -1,30 +1,44 @@
 package telegram_webhook
 
 import (
+	"crypto/subtle"
 	"net/http"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
 )
 
+// headerSecretToken заголовок с secret_token, заданным при установке webhook
+const headerSecretToken = "X-Telegram-Bot-Api-Secret-Token"
+
 const (
+	msgInvalidSecretToken = "неверный секретный токен webhook"
 	msgInvalidRequestBody = "неверный формат тела запроса"
-	commandStart          = "/start"
 )
 
 type Handler struct {
-	startMessageUseCase StartMessageUseCase
-	logger              Logger
+	queue       UpdateQueue
+	secretToken string
+	logger      Logger
 }
 
-func NewHandler(startMessageUseCase StartMessageUseCase, logger Logger) *Handler {
+func NewHandler(queue UpdateQueue, secretToken string, logger Logger) *Handler {
 	return &Handler{
-		startMessageUseCase: startMessageUseCase,
-		logger:              logger,
+		queue:       queue,
+		secretToken: secretToken,
+		logger:      logger,
 	}
 }
 
 func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// Запросы не от Telegram отклоняем до разбора тела
+	token := r.Header.Get(headerSecretToken)
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.secretToken)) != 1 {
+		h.logger.Warn("Rejected telegram webhook with invalid secret token from %s", r.RemoteAddr)
+		handlers.RespondUnauthorized(w, msgInvalidSecretToken)
+		return
+	}
+
 	// Парсим webhook update от Telegram
 	var update tgbotapi.Update
 	if err := handlers.DecodeJSON(r, &update); err != nil {
@@ -33,28 +47,13 @@ func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Обрабатываем только текстовые сообщения
-	if update.Message == nil || update.Message.Text == "" {
-		w.WriteHeader(http.StatusOK)
-		return
-	}
-
-	// Обрабатываем только команду /start
-	if update.Message.Text != commandStart {
-		w.WriteHeader(http.StatusOK)
-		return
-	}
-
-	// Обработка команды /start через use case
-	chatID := update.Message.Chat.ID
-	tgUserID := update.Message.From.ID
-
-	if err := h.startMessageUseCase.Execute(r.Context(), update.Message.From, chatID); err != nil {
-		h.logger.Error("Failed to handle /start command for user %d: %v", tgUserID, err)
+	// Обновление обрабатывается в фоне, Telegram получает ответ сразу после сохранения.
+	// Если сохранить не удалось, 500 заставит Telegram доставить обновление повторно
+	if err := h.queue.Enqueue(r.Context(), update); err != nil {
+		h.logger.Error("Failed to enqueue telegram update %d: %v", update.UpdateID, err)
 		handlers.RespondInternalError(w)
 		return
 	}
 
-	h.logger.Info("Successfully processed /start command for user %d (chat %d)", tgUserID, chatID)
 	w.WriteHeader(http.StatusOK)
 }
@@ -0,0 +1,20 @@
+package update_preferences
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/service/preferences/models"
+)
+
+// PreferencesService интерфейс сервиса настроек уведомлений
+type PreferencesService interface {
+	Update(ctx context.Context, tgUserID int64, input *models.UpdatePreferencesInput) (*domain.UserPreferences, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
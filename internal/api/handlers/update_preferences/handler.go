@@ -0,0 +1,66 @@
+package update_preferences
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/update_preferences/models"
+	preferencesSvc "github.com/m04kA/SMC-NotificationService/internal/service/preferences"
+)
+
+const (
+	msgInvalidUserID      = "неверный Telegram ID пользователя"
+	msgInvalidRequestBody = "неверный формат тела запроса"
+)
+
+type Handler struct {
+	service PreferencesService
+	logger  Logger
+}
+
+func NewHandler(service PreferencesService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// Извлекаем Telegram ID из URL параметров
+	idStr := mux.Vars(r)["tg_user_id"]
+
+	tgUserID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.logger.Warn("Invalid Telegram user ID: %s", idStr)
+		handlers.RespondBadRequest(w, msgInvalidUserID)
+		return
+	}
+
+	// Парсинг request body
+	var req models.UpdatePreferencesRequest
+	if err := handlers.DecodeJSON(r, &req); err != nil {
+		h.logger.Warn("Failed to decode request body: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidRequestBody)
+		return
+	}
+
+	preferences, err := h.service.Update(r.Context(), tgUserID, req.ToServiceInput())
+	if err != nil {
+		// Обработка ошибок сервиса
+		if errors.Is(err, preferencesSvc.ErrInvalidInput) {
+			handlers.RespondBadRequest(w, err.Error())
+			return
+		}
+
+		h.logger.Error("Failed to update preferences of user %d: %v", tgUserID, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	h.logger.Info("Updated notification preferences of user %d", tgUserID)
+
+	handlers.RespondJSON(w, http.StatusOK, models.FromDomainPreferences(preferences))
+}
@@ -0,0 +1,20 @@
+package update_template
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/service/templates/models"
+)
+
+// TemplateService интерфейс сервиса шаблонов
+type TemplateService interface {
+	Update(ctx context.Context, name string, input *models.UpdateTemplateInput) (*domain.Template, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,63 @@
+package update_template
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/update_template/models"
+	templatesSvc "github.com/m04kA/SMC-NotificationService/internal/service/templates"
+)
+
+const (
+	msgInvalidRequestBody = "неверный формат тела запроса"
+	msgTemplateNotFound   = "шаблон не найден"
+)
+
+type Handler struct {
+	service TemplateService
+	logger  Logger
+}
+
+func NewHandler(service TemplateService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	// Извлекаем имя шаблона из URL параметров
+	name := mux.Vars(r)["name"]
+
+	// Парсинг request body
+	var req models.UpdateTemplateRequest
+	if err := handlers.DecodeJSON(r, &req); err != nil {
+		h.logger.Warn("Failed to decode request body: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidRequestBody)
+		return
+	}
+
+	// Создаём новую версию шаблона через сервисный слой
+	template, err := h.service.Update(r.Context(), name, req.ToServiceInput())
+	if err != nil {
+		// Обработка ошибок сервисного слоя
+		if errors.Is(err, templatesSvc.ErrTemplateNotFound) {
+			handlers.RespondNotFound(w, msgTemplateNotFound)
+			return
+		}
+		if errors.Is(err, templatesSvc.ErrInvalidInput) || errors.Is(err, templatesSvc.ErrInvalidTemplate) {
+			handlers.RespondBadRequest(w, err.Error())
+			return
+		}
+
+		h.logger.Error("Failed to update template %s: %v", name, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	h.logger.Info("Updated template %s (new version: %d)", template.Name, template.Version)
+
+	handlers.RespondJSON(w, http.StatusOK, models.FromDomainTemplate(template))
+}
@@ -0,0 +1,20 @@
+package upload_media
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/service/media/models"
+)
+
+// MediaService интерфейс сервиса медиатеки
+type MediaService interface {
+	Upload(ctx context.Context, input *models.UploadMediaInput) (*domain.Media, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,93 @@
+package upload_media
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers"
+	"github.com/m04kA/SMC-NotificationService/internal/api/handlers/upload_media/models"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	mediaSvc "github.com/m04kA/SMC-NotificationService/internal/service/media"
+	serviceModels "github.com/m04kA/SMC-NotificationService/internal/service/media/models"
+)
+
+const (
+	// maxRequestSize максимальный размер запроса: самый большой допустимый файл и поля формы
+	maxRequestSize = domain.MaxMediaFileSize + 1<<20
+
+	// maxFormMemory часть формы, которая держится в памяти; остальное пишется во временные файлы
+	maxFormMemory = 8 << 20
+)
+
+const (
+	msgInvalidForm  = "ожидается multipart/form-data с файлом в поле file"
+	msgFileTooLarge = "файл превышает допустимый размер"
+)
+
+type Handler struct {
+	service MediaService
+	logger  Logger
+}
+
+func NewHandler(service MediaService, logger Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	// Парсинг multipart формы
+	if err := r.ParseMultipartForm(maxFormMemory); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			handlers.RespondError(w, http.StatusRequestEntityTooLarge, msgFileTooLarge)
+			return
+		}
+		h.logger.Warn("Failed to parse multipart form: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidForm)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		h.logger.Warn("Failed to read uploaded file: %v", err)
+		handlers.RespondBadRequest(w, msgInvalidForm)
+		return
+	}
+	defer file.Close()
+
+	// Загружаем файл через сервисный слой
+	media, err := h.service.Upload(r.Context(), &serviceModels.UploadMediaInput{
+		Filename: header.Filename,
+		Type:     domain.AttachmentType(r.FormValue("type")),
+		Size:     header.Size,
+		Content:  file,
+	})
+	if err != nil {
+		// Обработка ошибок сервисного слоя
+		if errors.Is(err, mediaSvc.ErrInvalidInput) {
+			handlers.RespondBadRequest(w, err.Error())
+			return
+		}
+		if errors.Is(err, mediaSvc.ErrUnsupportedMediaType) {
+			handlers.RespondError(w, http.StatusUnsupportedMediaType, err.Error())
+			return
+		}
+		if errors.Is(err, mediaSvc.ErrFileTooLarge) {
+			handlers.RespondError(w, http.StatusRequestEntityTooLarge, err.Error())
+			return
+		}
+
+		h.logger.Error("Failed to upload media %s: %v", header.Filename, err)
+		handlers.RespondInternalError(w)
+		return
+	}
+
+	h.logger.Info("Uploaded media %s (type: %s, size: %d)", media.ID, media.Type, media.Size)
+
+	handlers.RespondJSON(w, http.StatusCreated, models.FromDomainMedia(media))
+}
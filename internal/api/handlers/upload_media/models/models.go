@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// MediaResponse HTTP ответ с данными загруженного файла
+type MediaResponse struct {
+	MediaID     string                `json:"media_id"`
+	Type        domain.AttachmentType `json:"type"`
+	Filename    string                `json:"filename"`
+	ContentType string                `json:"content_type"`
+	Size        int64                 `json:"size"`
+	CreatedAt   time.Time             `json:"created_at"`
+}
+
+// FromDomainMedia преобразует доменную модель в HTTP ответ
+func FromDomainMedia(m *domain.Media) *MediaResponse {
+	return &MediaResponse{
+		MediaID:     m.ID,
+		Type:        m.Type,
+		Filename:    m.Filename,
+		ContentType: m.ContentType,
+		Size:        m.Size,
+		CreatedAt:   m.CreatedAt,
+	}
+}
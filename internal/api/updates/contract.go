@@ -0,0 +1,13 @@
+package updates
+
+// Metrics интерфейс для записи метрик обработки обновлений
+type Metrics interface {
+	RecordBotUpdate(service, updateType, handler, status string, duration float64)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
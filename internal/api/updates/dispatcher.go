@@ -0,0 +1,248 @@
+package updates
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Типы обновлений для метрик
+const (
+	updateTypeMessage       = "message"
+	updateTypeCallbackQuery = "callback_query"
+	updateTypeMyChatMember  = "my_chat_member"
+	updateTypeChatMember    = "chat_member"
+	updateTypeOther         = "other"
+)
+
+// Статусы обработки для метрик
+const (
+	statusOK      = "ok"
+	statusError   = "error"
+	statusPanic   = "panic"
+	statusIgnored = "ignored"
+)
+
+// handlerNone имя обработчика в метриках для обновлений без обработчика
+const handlerNone = "none"
+
+// CommandHandler обработчик команды бота; args - текст после команды
+type CommandHandler func(ctx context.Context, msg *tgbotapi.Message, args string) error
+
+// MessageHandler обработчик сообщения, не являющегося командой
+type MessageHandler func(ctx context.Context, msg *tgbotapi.Message) error
+
+// MessageMatcher проверяет, подходит ли сообщение обработчику
+type MessageMatcher func(msg *tgbotapi.Message) bool
+
+// CallbackQueryHandler обработчик нажатия callback-кнопки
+type CallbackQueryHandler func(ctx context.Context, query *tgbotapi.CallbackQuery) error
+
+// ChatMemberHandler обработчик изменения статуса участника чата
+type ChatMemberHandler func(ctx context.Context, update *tgbotapi.ChatMemberUpdated) error
+
+// messageRoute обработчик сообщений с условием
+type messageRoute struct {
+	name    string
+	match   MessageMatcher
+	handler MessageHandler
+}
+
+// callbackRoute обработчик callback-запросов с заданным префиксом данных
+type callbackRoute struct {
+	prefix  string
+	handler CallbackQueryHandler
+}
+
+// Dispatcher направляет обновления Telegram зарегистрированным обработчикам
+// Используется обоими транспортами (webhook и long polling), поэтому новая команда регистрируется в одном месте
+type Dispatcher struct {
+	commands       map[string]CommandHandler
+	commandNames   []string
+	unknownCommand CommandHandler
+	messageRoutes  []messageRoute
+	callbackQuery  CallbackQueryHandler
+	callbackRoutes []callbackRoute
+	myChatMember   ChatMemberHandler
+	chatMember     ChatMemberHandler
+	metrics        Metrics
+	serviceName    string
+	logger         Logger
+}
+
+// NewDispatcher создаёт диспетчер обновлений
+// metrics может быть nil, если метрики отключены
+func NewDispatcher(metrics Metrics, serviceName string, logger Logger) *Dispatcher {
+	return &Dispatcher{
+		commands:    make(map[string]CommandHandler),
+		metrics:     metrics,
+		serviceName: serviceName,
+		logger:      logger,
+	}
+}
+
+// HandleCommand регистрирует обработчик команды (имя без "/", например "start")
+func (d *Dispatcher) HandleCommand(name string, handler CommandHandler) {
+	name = strings.ToLower(strings.TrimPrefix(name, "/"))
+	if _, ok := d.commands[name]; !ok {
+		d.commandNames = append(d.commandNames, name)
+	}
+	d.commands[name] = handler
+}
+
+// HandleUnknownCommand регистрирует обработчик команд, для которых нет обработчика
+func (d *Dispatcher) HandleUnknownCommand(handler CommandHandler) {
+	d.unknownCommand = handler
+}
+
+// HandleMessage регистрирует обработчик сообщений, не являющихся командами
+// Сообщение получает первый обработчик, условие которого выполнено; name используется в логах и метриках
+func (d *Dispatcher) HandleMessage(name string, match MessageMatcher, handler MessageHandler) {
+	d.messageRoutes = append(d.messageRoutes, messageRoute{name: name, match: match, handler: handler})
+}
+
+// HandleCallbackQuery регистрирует обработчик нажатий callback-кнопок
+func (d *Dispatcher) HandleCallbackQuery(handler CallbackQueryHandler) {
+	d.callbackQuery = handler
+}
+
+// HandleCallbackPrefix регистрирует обработчик callback-запросов, данные которых начинаются с prefix
+// Используется для кнопок бота, не связанных с уведомлениями (например, настройки); остальные нажатия
+// получает обработчик HandleCallbackQuery. prefix используется в логах и метриках
+func (d *Dispatcher) HandleCallbackPrefix(prefix string, handler CallbackQueryHandler) {
+	d.callbackRoutes = append(d.callbackRoutes, callbackRoute{prefix: prefix, handler: handler})
+}
+
+// HandleMyChatMember регистрирует обработчик изменения статуса самого бота в чате (блокировка, добавление в группу)
+func (d *Dispatcher) HandleMyChatMember(handler ChatMemberHandler) {
+	d.myChatMember = handler
+}
+
+// HandleChatMember регистрирует обработчик изменения статуса участников чата
+func (d *Dispatcher) HandleChatMember(handler ChatMemberHandler) {
+	d.chatMember = handler
+}
+
+// Commands возвращает имена зарегистрированных команд в порядке регистрации
+func (d *Dispatcher) Commands() []string {
+	names := make([]string, len(d.commandNames))
+	copy(names, d.commandNames)
+	return names
+}
+
+// Dispatch обрабатывает одно обновление
+// Паника обработчика перехватывается и возвращается ошибкой ErrPanic, чтобы одно обновление не остановило бота
+func (d *Dispatcher) Dispatch(ctx context.Context, update tgbotapi.Update) (err error) {
+	updateType, handlerName, handle := d.route(update)
+	start := time.Now()
+
+	defer func() {
+		status := statusOK
+		if r := recover(); r != nil {
+			status = statusPanic
+			err = fmt.Errorf("%w: update %d (%s): %v", ErrPanic, update.UpdateID, handlerName, r)
+			d.logger.Error("Panic while handling update %d (%s): %v\n%s", update.UpdateID, handlerName, r, debug.Stack())
+		} else if err != nil {
+			status = statusError
+			d.logger.Error("Failed to handle update %d (%s): %v", update.UpdateID, handlerName, err)
+		} else if handle == nil {
+			status = statusIgnored
+		} else {
+			d.logger.Info("Handled update %d (%s) in %s", update.UpdateID, handlerName, time.Since(start))
+		}
+
+		if d.metrics != nil {
+			d.metrics.RecordBotUpdate(d.serviceName, updateType, handlerName, status, time.Since(start).Seconds())
+		}
+	}()
+
+	if handle == nil {
+		return nil
+	}
+
+	return handle(ctx)
+}
+
+// route определяет тип обновления и обработчик
+// Возвращает nil вместо обработчика, если обновление не обрабатывается
+func (d *Dispatcher) route(update tgbotapi.Update) (string, string, func(ctx context.Context) error) {
+	switch {
+	case update.Message != nil:
+		handlerName, handle := d.routeMessage(update.Message)
+		return updateTypeMessage, handlerName, handle
+
+	case update.CallbackQuery != nil:
+		for _, route := range d.callbackRoutes {
+			if strings.HasPrefix(update.CallbackQuery.Data, route.prefix) {
+				handler := route.handler
+				return updateTypeCallbackQuery, "callback:" + strings.TrimRight(route.prefix, ":"), func(ctx context.Context) error {
+					return handler(ctx, update.CallbackQuery)
+				}
+			}
+		}
+		if d.callbackQuery == nil {
+			return updateTypeCallbackQuery, handlerNone, nil
+		}
+		return updateTypeCallbackQuery, updateTypeCallbackQuery, func(ctx context.Context) error {
+			return d.callbackQuery(ctx, update.CallbackQuery)
+		}
+
+	case update.MyChatMember != nil:
+		return chatMemberRoute(updateTypeMyChatMember, d.myChatMember, update.MyChatMember)
+
+	case update.ChatMember != nil:
+		return chatMemberRoute(updateTypeChatMember, d.chatMember, update.ChatMember)
+
+	default:
+		return updateTypeOther, handlerNone, nil
+	}
+}
+
+// routeMessage выбирает обработчик сообщения: команда из реестра или первый подходящий обработчик сообщений
+func (d *Dispatcher) routeMessage(msg *tgbotapi.Message) (string, func(ctx context.Context) error) {
+	if msg.IsCommand() {
+		name := strings.ToLower(msg.Command())
+
+		handler, ok := d.commands[name]
+		if !ok {
+			handler = d.unknownCommand
+		}
+		if handler == nil {
+			return handlerNone, nil
+		}
+
+		// Для неизвестных команд имя не попадает в метрики, чтобы не плодить метки
+		handlerName := "command:unknown"
+		if ok {
+			handlerName = "command:" + name
+		}
+		return handlerName, func(ctx context.Context) error {
+			return handler(ctx, msg, msg.CommandArguments())
+		}
+	}
+
+	for _, route := range d.messageRoutes {
+		if route.match(msg) {
+			handler := route.handler
+			return route.name, func(ctx context.Context) error {
+				return handler(ctx, msg)
+			}
+		}
+	}
+
+	return handlerNone, nil
+}
+
+// chatMemberRoute возвращает обработчик изменения статуса участника чата
+func chatMemberRoute(updateType string, handler ChatMemberHandler, update *tgbotapi.ChatMemberUpdated) (string, string, func(ctx context.Context) error) {
+	if handler == nil {
+		return updateType, handlerNone, nil
+	}
+	return updateType, updateType, func(ctx context.Context) error {
+		return handler(ctx, update)
+	}
+}
@@ -0,0 +1,8 @@
+package updates
+
+import "errors"
+
+var (
+	// ErrPanic возвращается, если обработчик обновления завершился паникой
+	ErrPanic = errors.New("api.updates: handler panicked")
+)
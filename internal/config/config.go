@@ -1,22 +1,37 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/BurntSushi/toml"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
 )
 
+// webhookSecretPattern допустимые значения secret_token webhook (требование Telegram Bot API)
+var webhookSecretPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,256}$`)
+
 // Config представляет полную конфигурацию приложения
 type Config struct {
-	Logs        LogsConfig        `toml:"logs"`
-	Server      ServerConfig      `toml:"server"`
-	Database    DatabaseConfig    `toml:"database"`
-	Metrics     MetricsConfig     `toml:"metrics"`
-	Telegram    TelegramConfig    `toml:"telegram"`
-	UserService UserServiceConfig `toml:"userservice"`
-	Worker      WorkerConfig      `toml:"worker"`
+	Logs           LogsConfig           `toml:"logs"`
+	Server         ServerConfig         `toml:"server"`
+	Database       DatabaseConfig       `toml:"database"`
+	Metrics        MetricsConfig        `toml:"metrics"`
+	Telegram       TelegramConfig       `toml:"telegram"`
+	UserService    UserServiceConfig    `toml:"userservice"`
+	BookingService BookingServiceConfig `toml:"bookingservice"`
+	WebApp         WebAppConfig         `toml:"webapp"`
+	I18n           I18nConfig           `toml:"i18n"`
+	Worker         WorkerConfig         `toml:"worker"`
+	Media          MediaConfig          `toml:"media"`
+	Welcome        WelcomeConfig        `toml:"welcome"`
+	Support        SupportConfig        `toml:"support"`
+	Bot            BotConfig            `toml:"bot"`
 }
 
 // LogsConfig содержит настройки логирования
@@ -27,10 +42,10 @@ type LogsConfig struct {
 
 // ServerConfig содержит настройки HTTP сервера
 type ServerConfig struct {
-	HTTPPort       int `toml:"http_port"`
-	ReadTimeout    int `toml:"read_timeout"`
-	WriteTimeout   int `toml:"write_timeout"`
-	IdleTimeout    int `toml:"idle_timeout"`
+	HTTPPort        int `toml:"http_port"`
+	ReadTimeout     int `toml:"read_timeout"`
+	WriteTimeout    int `toml:"write_timeout"`
+	IdleTimeout     int `toml:"idle_timeout"`
 	ShutdownTimeout int `toml:"shutdown_timeout"`
 }
 
@@ -56,8 +71,15 @@ type MetricsConfig struct {
 
 // TelegramConfig содержит настройки Telegram Bot
 type TelegramConfig struct {
-	BotToken   string `toml:"bot_token"`
-	WebhookURL string `toml:"webhook_url"` // Опционально для production
+	BotToken         string  `toml:"bot_token"`
+	WebhookURL       string  `toml:"webhook_url"`         // Опционально для production
+	WebhookSecret    string  `toml:"webhook_secret"`      // secret_token webhook: Telegram присылает его в X-Telegram-Bot-Api-Secret-Token (по умолчанию - производный от токена бота)
+	CallbackSecret   string  `toml:"callback_secret"`     // Ключ подписи callback_data (по умолчанию - токен бота)
+	StartSecret      string  `toml:"start_secret"`        // Ключ подписи deep-link ссылок /start (по умолчанию - ключ callback_data)
+	LinkSecret       string  `toml:"link_secret"`         // Ключ подписи кодов привязки групп к компаниям (/link; по умолчанию - производный от ключа /start)
+	LinkCodeTTLHours int     `toml:"link_code_ttl_hours"` // Срок действия кода привязки группы к компании (часы)
+	SnoozeMinutes    int     `toml:"snooze_minutes"`      // На сколько минут откладывается напоминание кнопкой snooze без аргумента
+	TestChatIDs      []int64 `toml:"test_chat_ids"`       // Чаты, в которые разрешено отправлять предпросмотр уведомлений
 }
 
 // UserServiceConfig содержит настройки интеграции с UserService
@@ -66,10 +88,80 @@ type UserServiceConfig struct {
 	Timeout int    `toml:"timeout"` // в секундах
 }
 
+// BookingServiceConfig содержит настройки интеграции с BookingService
+type BookingServiceConfig struct {
+	URL           string `toml:"url"`
+	Timeout       int    `toml:"timeout"`         // в секундах
+	ServiceUserID int64  `toml:"service_user_id"` // X-User-ID для запросов без конкретного пользователя (например, уведомления в чат)
+}
+
+// WebAppConfig содержит настройки Telegram Mini App
+type WebAppConfig struct {
+	URL        string `toml:"url"`         // Базовый URL мини-приложения
+	BookingURL string `toml:"booking_url"` // Шаблон ссылки на бронирование: {user_id} и {booking_id} заменяются значениями
+}
+
+// I18nConfig содержит настройки локализации
+type I18nConfig struct {
+	DefaultLocale string `toml:"default_locale"` // Язык по умолчанию (если у получателя язык неизвестен или нет перевода)
+}
+
 // WorkerConfig содержит настройки worker'ов
 type WorkerConfig struct {
-	ProcessorInterval int `toml:"processor_interval"` // интервал опроса pending уведомлений (в секундах)
-	ProcessorBatchSize int `toml:"processor_batch_size"` // размер батча для обработки
+	ProcessorInterval   int `toml:"processor_interval"`    // интервал опроса pending уведомлений (в секундах)
+	ProcessorBatchSize  int `toml:"processor_batch_size"`  // размер батча для обработки
+	SendMaxAttempts     int `toml:"send_max_attempts"`     // количество попыток отправки одного уведомления
+	UpdateInterval      int `toml:"update_interval"`       // интервал опроса очереди обновлений Telegram из webhook (в секундах)
+	UpdateMaxAttempts   int `toml:"update_max_attempts"`   // количество попыток обработки одного обновления
+	UpdateRetentionDays int `toml:"update_retention_days"` // срок хранения обработанных обновлений и защиты от повторной доставки (в днях)
+}
+
+// MediaConfig содержит настройки медиатеки
+type MediaConfig struct {
+	StorageDir string `toml:"storage_dir"` // Каталог для содержимого загруженных файлов
+}
+
+// SupportConfig содержит настройки переписки пользователей с поддержкой
+type SupportConfig struct {
+	OperatorChatID int64 `toml:"operator_chat_id"` // Чат операторов для сообщений пользователей (0 - сообщения только сохраняются)
+}
+
+// BotConfig содержит профиль бота, публикуемый в Telegram при запуске
+type BotConfig struct {
+	MenuButtonText string                    `toml:"menu_button_text"` // Текст кнопки меню, открывающей мини-приложение (пусто - кнопка не меняется)
+	MenuButtonURL  string                    `toml:"menu_button_url"`  // URL мини-приложения для кнопки меню (по умолчанию - webapp.url)
+	Texts          map[string]BotTextsConfig `toml:"texts"`            // Описание и команды по языкам
+}
+
+// BotTextsConfig содержит тексты профиля бота на одном языке
+type BotTextsConfig struct {
+	Description      string            `toml:"description"`       // «Что умеет этот бот?» в пустом чате (до 512 символов)
+	ShortDescription string            `toml:"short_description"` // Описание в профиле бота (до 120 символов)
+	Commands         map[string]string `toml:"commands"`          // Описания команд меню (имя без "/")
+}
+
+// WelcomeConfig содержит сценарий приветствия по команде /start
+type WelcomeConfig struct {
+	Media     []string                      `toml:"media"`      // Пути к изображениям в порядке отправки (до 10)
+	ButtonURL string                        `toml:"button_url"` // Шаблон URL кнопки мини-приложения: {user_id} заменяется Telegram ID
+	Texts     map[string]WelcomeTextsConfig `toml:"texts"`      // Тексты по языкам
+
+	// Варианты по видам deep-link ссылок /start: company, booking, ref, source
+	Variants map[string]WelcomeVariantConfig `toml:"variants"`
+}
+
+// WelcomeVariantConfig содержит вариант приветствия для вида deep-link ссылки
+// Незаданные поля берутся из основного сценария
+type WelcomeVariantConfig struct {
+	ButtonURL string                        `toml:"button_url"` // {user_id} - Telegram ID, {start_value} - значение ссылки
+	Texts     map[string]WelcomeTextsConfig `toml:"texts"`
+}
+
+// WelcomeTextsConfig содержит тексты приветствия на одном языке
+type WelcomeTextsConfig struct {
+	Caption      string `toml:"caption"`       // Подпись к изображениям
+	ButtonPrompt string `toml:"button_prompt"` // Текст сообщения с кнопкой после медиагруппы
+	ButtonText   string `toml:"button_text"`   // Текст кнопки
 }
 
 // DSN формирует строку подключения к PostgreSQL
@@ -159,6 +251,37 @@ func overrideFromEnv(cfg *Config) {
 	if v := os.Getenv("TELEGRAM_WEBHOOK_URL"); v != "" {
 		cfg.Telegram.WebhookURL = v
 	}
+	if v := os.Getenv("TELEGRAM_WEBHOOK_SECRET"); v != "" {
+		cfg.Telegram.WebhookSecret = v
+	}
+	if v := os.Getenv("TELEGRAM_CALLBACK_SECRET"); v != "" {
+		cfg.Telegram.CallbackSecret = v
+	}
+	if v := os.Getenv("TELEGRAM_START_SECRET"); v != "" {
+		cfg.Telegram.StartSecret = v
+	}
+	if v := os.Getenv("TELEGRAM_LINK_SECRET"); v != "" {
+		cfg.Telegram.LinkSecret = v
+	}
+	if v := os.Getenv("TELEGRAM_LINK_CODE_TTL_HOURS"); v != "" {
+		if hours, err := strconv.Atoi(v); err == nil {
+			cfg.Telegram.LinkCodeTTLHours = hours
+		}
+	}
+	if v := os.Getenv("TELEGRAM_SNOOZE_MINUTES"); v != "" {
+		if minutes, err := strconv.Atoi(v); err == nil {
+			cfg.Telegram.SnoozeMinutes = minutes
+		}
+	}
+	if v := os.Getenv("TELEGRAM_TEST_CHAT_IDS"); v != "" {
+		var ids []int64
+		for _, part := range strings.Split(v, ",") {
+			if id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64); err == nil {
+				ids = append(ids, id)
+			}
+		}
+		cfg.Telegram.TestChatIDs = ids
+	}
 
 	// UserService
 	if v := os.Getenv("USERSERVICE_URL"); v != "" {
@@ -170,6 +293,29 @@ func overrideFromEnv(cfg *Config) {
 		}
 	}
 
+	// BookingService
+	if v := os.Getenv("BOOKINGSERVICE_URL"); v != "" {
+		cfg.BookingService.URL = v
+	}
+	if v := os.Getenv("BOOKINGSERVICE_TIMEOUT"); v != "" {
+		if timeout, err := strconv.Atoi(v); err == nil {
+			cfg.BookingService.Timeout = timeout
+		}
+	}
+
+	// WebApp
+	if v := os.Getenv("WEBAPP_URL"); v != "" {
+		cfg.WebApp.URL = v
+	}
+	if v := os.Getenv("WEBAPP_BOOKING_URL"); v != "" {
+		cfg.WebApp.BookingURL = v
+	}
+
+	// I18n
+	if v := os.Getenv("I18N_DEFAULT_LOCALE"); v != "" {
+		cfg.I18n.DefaultLocale = v
+	}
+
 	// Worker
 	if v := os.Getenv("WORKER_PROCESSOR_INTERVAL"); v != "" {
 		if interval, err := strconv.Atoi(v); err == nil {
@@ -181,6 +327,51 @@ func overrideFromEnv(cfg *Config) {
 			cfg.Worker.ProcessorBatchSize = batchSize
 		}
 	}
+	if v := os.Getenv("WORKER_SEND_MAX_ATTEMPTS"); v != "" {
+		if attempts, err := strconv.Atoi(v); err == nil {
+			cfg.Worker.SendMaxAttempts = attempts
+		}
+	}
+	if v := os.Getenv("WORKER_UPDATE_INTERVAL"); v != "" {
+		if interval, err := strconv.Atoi(v); err == nil {
+			cfg.Worker.UpdateInterval = interval
+		}
+	}
+	if v := os.Getenv("WORKER_UPDATE_MAX_ATTEMPTS"); v != "" {
+		if attempts, err := strconv.Atoi(v); err == nil {
+			cfg.Worker.UpdateMaxAttempts = attempts
+		}
+	}
+	if v := os.Getenv("WORKER_UPDATE_RETENTION_DAYS"); v != "" {
+		if days, err := strconv.Atoi(v); err == nil {
+			cfg.Worker.UpdateRetentionDays = days
+		}
+	}
+
+	// Media
+	if v := os.Getenv("MEDIA_STORAGE_DIR"); v != "" {
+		cfg.Media.StorageDir = v
+	}
+
+	// Welcome
+	if v := os.Getenv("WELCOME_BUTTON_URL"); v != "" {
+		cfg.Welcome.ButtonURL = v
+	}
+
+	// Bot
+	if v := os.Getenv("BOT_MENU_BUTTON_TEXT"); v != "" {
+		cfg.Bot.MenuButtonText = v
+	}
+	if v := os.Getenv("BOT_MENU_BUTTON_URL"); v != "" {
+		cfg.Bot.MenuButtonURL = v
+	}
+
+	// Support
+	if v := os.Getenv("SUPPORT_OPERATOR_CHAT_ID"); v != "" {
+		if chatID, err := strconv.ParseInt(v, 10, 64); err == nil {
+			cfg.Support.OperatorChatID = chatID
+		}
+	}
 }
 
 // validate проверяет корректность конфигурации
@@ -249,6 +440,34 @@ func validate(cfg *Config) error {
 	if cfg.Telegram.BotToken == "" {
 		return fmt.Errorf("telegram bot token is required")
 	}
+	if cfg.Telegram.CallbackSecret == "" {
+		cfg.Telegram.CallbackSecret = cfg.Telegram.BotToken
+	}
+	if cfg.Telegram.WebhookSecret == "" {
+		// Токен бота содержит ":", который Telegram не принимает в secret_token
+		sum := sha256.Sum256([]byte("webhook:" + cfg.Telegram.BotToken))
+		cfg.Telegram.WebhookSecret = hex.EncodeToString(sum[:])
+	}
+	if !webhookSecretPattern.MatchString(cfg.Telegram.WebhookSecret) {
+		return fmt.Errorf("telegram webhook secret must be 1-256 characters A-Z, a-z, 0-9, _ or -")
+	}
+	if cfg.Telegram.StartSecret == "" {
+		cfg.Telegram.StartSecret = cfg.Telegram.CallbackSecret
+	}
+	if cfg.Telegram.LinkSecret == "" {
+		// Отдельный ключ: приглашение компании из ссылки /start не должно подходить как код /link
+		sum := sha256.Sum256([]byte("link:" + cfg.Telegram.StartSecret))
+		cfg.Telegram.LinkSecret = hex.EncodeToString(sum[:])
+	}
+	if cfg.Telegram.LinkCodeTTLHours == 0 {
+		cfg.Telegram.LinkCodeTTLHours = 24 // 1 day default
+	}
+	if cfg.Telegram.LinkCodeTTLHours < 0 {
+		return fmt.Errorf("telegram link code TTL must be positive")
+	}
+	if cfg.Telegram.SnoozeMinutes == 0 {
+		cfg.Telegram.SnoozeMinutes = 60 // 1 hour default
+	}
 
 	// UserService validation and defaults
 	if cfg.UserService.URL == "" {
@@ -258,6 +477,28 @@ func validate(cfg *Config) error {
 		cfg.UserService.Timeout = 10 // 10 seconds default
 	}
 
+	// BookingService validation and defaults
+	if cfg.BookingService.URL == "" {
+		cfg.BookingService.URL = "http://localhost:8083/api/v1" // default
+	}
+	if cfg.BookingService.Timeout == 0 {
+		cfg.BookingService.Timeout = 10 // 10 seconds default
+	}
+
+	// WebApp defaults
+	if cfg.WebApp.URL == "" {
+		cfg.WebApp.URL = "https://faberon24.vercel.app/index.html"
+	}
+	if cfg.WebApp.BookingURL == "" {
+		cfg.WebApp.BookingURL = cfg.WebApp.URL + "?X-UserID={user_id}&booking_id={booking_id}"
+	}
+
+	// I18n defaults
+	if cfg.I18n.DefaultLocale == "" {
+		cfg.I18n.DefaultLocale = domain.DefaultLocale
+	}
+	cfg.I18n.DefaultLocale = domain.NormalizeLocale(cfg.I18n.DefaultLocale)
+
 	// Worker validation and defaults
 	if cfg.Worker.ProcessorInterval == 0 {
 		cfg.Worker.ProcessorInterval = 30 // 30 seconds default
@@ -265,6 +506,37 @@ func validate(cfg *Config) error {
 	if cfg.Worker.ProcessorBatchSize == 0 {
 		cfg.Worker.ProcessorBatchSize = 100 // 100 notifications per batch default
 	}
+	if cfg.Worker.SendMaxAttempts == 0 {
+		cfg.Worker.SendMaxAttempts = 3 // 3 attempts default
+	}
+	if cfg.Worker.UpdateInterval == 0 {
+		cfg.Worker.UpdateInterval = 5 // 5 seconds default
+	}
+	if cfg.Worker.UpdateMaxAttempts == 0 {
+		cfg.Worker.UpdateMaxAttempts = 5 // 5 attempts default
+	}
+	if cfg.Worker.UpdateRetentionDays == 0 {
+		cfg.Worker.UpdateRetentionDays = 2 // 2 days default
+	}
+	// Telegram начинает update_id заново после недели без обновлений: дольше хранить нельзя, иначе новые обновления отбросятся как повторные
+	if cfg.Worker.UpdateRetentionDays < 0 || cfg.Worker.UpdateRetentionDays > 7 {
+		return fmt.Errorf("worker update retention must be between 1 and 7 days")
+	}
+
+	// Media defaults
+	if cfg.Media.StorageDir == "" {
+		cfg.Media.StorageDir = "./data/media"
+	}
+
+	// Welcome defaults (изображения и тексты проверяются при создании сценария)
+	if cfg.Welcome.ButtonURL == "" {
+		cfg.Welcome.ButtonURL = cfg.WebApp.URL + "?X-UserID={user_id}"
+	}
+
+	// Bot profile defaults (тексты проверяются при создании профиля)
+	if cfg.Bot.MenuButtonURL == "" {
+		cfg.Bot.MenuButtonURL = cfg.WebApp.URL
+	}
 
 	return nil
 }
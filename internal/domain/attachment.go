@@ -0,0 +1,230 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/m04kA/SMC-NotificationService/pkg/tghtml"
+)
+
+// AttachmentType тип вложения уведомления
+type AttachmentType string
+
+const (
+	AttachmentTypePhoto    AttachmentType = "photo"
+	AttachmentTypeVideo    AttachmentType = "video"
+	AttachmentTypeDocument AttachmentType = "document"
+	AttachmentTypeAudio    AttachmentType = "audio"
+	AttachmentTypeLocation AttachmentType = "location" // Точка на карте
+	AttachmentTypeVenue    AttachmentType = "venue"    // Точка на карте с названием и адресом
+	AttachmentTypeContact  AttachmentType = "contact"  // Карточка контакта
+)
+
+const (
+	// MaxAttachments максимальное количество вложений в одном уведомлении
+	MaxAttachments = 20
+
+	// MaxMediaGroupSize максимальное количество элементов в медиагруппе Telegram
+	MaxMediaGroupSize = 10
+)
+
+// IsValid проверяет, поддерживается ли тип вложения
+func (t AttachmentType) IsValid() bool {
+	switch t {
+	case AttachmentTypePhoto, AttachmentTypeVideo, AttachmentTypeDocument, AttachmentTypeAudio,
+		AttachmentTypeLocation, AttachmentTypeVenue, AttachmentTypeContact:
+		return true
+	}
+	return false
+}
+
+// IsMedia проверяет, является ли вложение файлом (фото, видео, документ, аудио)
+func (t AttachmentType) IsMedia() bool {
+	switch t {
+	case AttachmentTypePhoto, AttachmentTypeVideo, AttachmentTypeDocument, AttachmentTypeAudio:
+		return true
+	}
+	return false
+}
+
+// MediaGroupKind возвращает вид медиагруппы, в которую может входить вложение
+// Telegram допускает смешивать в одной медиагруппе только фото и видео; документы и аудио группируются отдельно
+func (t AttachmentType) MediaGroupKind() string {
+	switch t {
+	case AttachmentTypePhoto, AttachmentTypeVideo:
+		return "visual"
+	default:
+		return string(t)
+	}
+}
+
+// Attachment вложение уведомления
+// Для файлов указывается URL, file_id Telegram или media_id файла медиатеки, для точек на карте - координаты, для контакта - телефон и имя
+type Attachment struct {
+	Type     AttachmentType `json:"type"`
+	URL      string         `json:"url,omitempty"`      // URL файла (Telegram скачает его сам)
+	FileID   string         `json:"file_id,omitempty"`  // file_id ранее загруженного в Telegram файла
+	MediaID  string         `json:"media_id,omitempty"` // ID файла, загруженного в медиатеку через POST /api/v1/media
+	Caption  string         `json:"caption,omitempty"`  // Подпись к файлу (если текст уведомления отправлен отдельно)
+	Filename string         `json:"filename,omitempty"` // Имя файла для документа
+
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+	Title     string   `json:"title,omitempty"`   // Название места (venue)
+	Address   string   `json:"address,omitempty"` // Адрес места (venue)
+
+	PhoneNumber string `json:"phone_number,omitempty"`
+	FirstName   string `json:"first_name,omitempty"`
+	LastName    string `json:"last_name,omitempty"`
+}
+
+// NewPhotoAttachment создает вложение-фото по URL
+func NewPhotoAttachment(url string) Attachment {
+	return Attachment{Type: AttachmentTypePhoto, URL: url}
+}
+
+// Validate проверяет вложение по ограничениям Telegram Bot API
+func (a Attachment) Validate() error {
+	if !a.Type.IsValid() {
+		return fmt.Errorf("unknown type %q", a.Type)
+	}
+
+	if a.Type.IsMedia() {
+		return a.validateFile()
+	}
+
+	switch a.Type {
+	case AttachmentTypeLocation, AttachmentTypeVenue:
+		if a.Latitude == nil || a.Longitude == nil {
+			return errors.New("latitude and longitude are required")
+		}
+		if *a.Latitude < -90 || *a.Latitude > 90 || *a.Longitude < -180 || *a.Longitude > 180 {
+			return errors.New("coordinates are out of range")
+		}
+		if a.Type == AttachmentTypeVenue && (a.Title == "" || a.Address == "") {
+			return errors.New("title and address are required for venue")
+		}
+	case AttachmentTypeContact:
+		if a.PhoneNumber == "" || a.FirstName == "" {
+			return errors.New("phone_number and first_name are required for contact")
+		}
+	}
+
+	return nil
+}
+
+// validateFile проверяет источник и подпись файла
+func (a Attachment) validateFile() error {
+	sources := 0
+	for _, source := range []string{a.URL, a.FileID, a.MediaID} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return errors.New("exactly one of url, file_id or media_id is required")
+	}
+
+	if a.MediaID != "" {
+		if _, err := uuid.Parse(a.MediaID); err != nil {
+			return fmt.Errorf("invalid media_id %q", a.MediaID)
+		}
+	}
+
+	if a.URL != "" {
+		u, err := url.Parse(a.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid url %q", a.URL)
+		}
+	}
+
+	if tghtml.Length(a.Caption) > tghtml.MaxCaptionLength {
+		return fmt.Errorf("caption exceeds %d characters", tghtml.MaxCaptionLength)
+	}
+
+	if a.Filename != "" && a.Type != AttachmentTypeDocument {
+		return errors.New("filename is supported only for documents")
+	}
+
+	return nil
+}
+
+// Attachments - массив вложений для хранения в БД
+type Attachments []Attachment
+
+// Value реализует driver.Valuer для записи в БД
+func (a Attachments) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return json.Marshal(a)
+}
+
+// Scan реализует sql.Scanner для чтения из БД
+func (a *Attachments) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to scan Attachments: expected []byte, got %T", value)
+	}
+
+	return json.Unmarshal(bytes, a)
+}
+
+// Validate проверяет все вложения
+func (a Attachments) Validate() error {
+	if len(a) > MaxAttachments {
+		return fmt.Errorf("attachments: at most %d attachments allowed", MaxAttachments)
+	}
+
+	for i, attachment := range a {
+		if err := attachment.Validate(); err != nil {
+			return fmt.Errorf("attachments[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// MediaGroups раскладывает файлы по медиагруппам Telegram
+// Соседние совместимые файлы объединяются в группы до 10 элементов, порядок вложений сохраняется
+// Группа из одного элемента отправляется отдельным сообщением
+func (a Attachments) MediaGroups() [][]Attachment {
+	var groups [][]Attachment
+
+	for _, attachment := range a {
+		if !attachment.Type.IsMedia() {
+			continue
+		}
+
+		if n := len(groups); n > 0 {
+			last := groups[n-1]
+			if len(last) < MaxMediaGroupSize && last[0].Type.MediaGroupKind() == attachment.Type.MediaGroupKind() {
+				groups[n-1] = append(last, attachment)
+				continue
+			}
+		}
+		groups = append(groups, []Attachment{attachment})
+	}
+
+	return groups
+}
+
+// Standalone возвращает вложения, которые отправляются отдельными сообщениями (точки на карте и контакты)
+func (a Attachments) Standalone() []Attachment {
+	var standalone []Attachment
+	for _, attachment := range a {
+		if !attachment.Type.IsMedia() {
+			standalone = append(standalone, attachment)
+		}
+	}
+	return standalone
+}
@@ -0,0 +1,21 @@
+package domain
+
+// Ограничения Telegram Bot API на профиль бота
+const (
+	MaxBotDescriptionLength      = 512 // setMyDescription
+	MaxBotShortDescriptionLength = 120 // setMyShortDescription
+	MaxBotCommandDescription     = 256 // Описание команды в setMyCommands
+)
+
+// BotCommand команда в меню команд бота
+type BotCommand struct {
+	Command     string // Имя без "/"
+	Description string
+}
+
+// BotProfileTexts тексты профиля бота на одном языке
+type BotProfileTexts struct {
+	Description      string            // Текст в пустом чате с ботом («Что умеет этот бот?»)
+	ShortDescription string            // Текст в профиле бота и при пересылке ссылки на него
+	Commands         map[string]string // Описания команд (имя без "/"); команды без описания не публикуются
+}
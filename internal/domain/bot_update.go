@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrUpdatePartiallyHandled помечает ошибку обработчика обновления, возникшую после ответа пользователю
+// Такое обновление не обрабатывается повторно: повтор отправил бы пользователю те же сообщения ещё раз
+var ErrUpdatePartiallyHandled = errors.New("domain: update handled partially")
+
+// BotUpdateStep неповторяемый шаг обработки обновления
+// Выполненный шаг запоминается, и повторная обработка его пропускает, а остальные действия повторяет
+type BotUpdateStep string
+
+const (
+	BotUpdateStepWelcome BotUpdateStep = "welcome" // Приветствие /start отправлено
+)
+
+// updateIDContextKey ключ update_id обрабатываемого обновления в контексте
+type updateIDContextKey struct{}
+
+// WithUpdateID возвращает контекст обработки обновления из очереди
+func WithUpdateID(ctx context.Context, updateID int64) context.Context {
+	return context.WithValue(ctx, updateIDContextKey{}, updateID)
+}
+
+// UpdateIDFromContext возвращает update_id обновления из очереди
+// Возвращает false, если обработка выполняется не из очереди (шаги не запоминаются)
+func UpdateIDFromContext(ctx context.Context) (int64, bool) {
+	updateID, ok := ctx.Value(updateIDContextKey{}).(int64)
+	return updateID, ok
+}
+
+// BotUpdateStatus статус обработки обновления Telegram
+type BotUpdateStatus string
+
+const (
+	BotUpdateStatusPending BotUpdateStatus = "pending" // Ожидает обработки (в том числе повторной)
+	BotUpdateStatusDone    BotUpdateStatus = "done"    // Обработано
+	BotUpdateStatusFailed  BotUpdateStatus = "failed"  // Исчерпаны попытки обработки
+)
+
+// BotUpdate обновление Telegram, сохранённое для фоновой обработки
+// Webhook подтверждает получение сразу после сохранения, обработка с повторами выполняется worker'ом
+type BotUpdate struct {
+	UpdateID      int64
+	Payload       json.RawMessage // Обновление в формате Telegram Bot API
+	Status        BotUpdateStatus
+	Attempts      int        // Количество выполненных попыток обработки
+	NextAttemptAt time.Time  // Время следующей попытки (для pending)
+	LastError     *string    // Ошибка последней попытки
+	ProcessedAt   *time.Time // Время успешной обработки
+	CreatedAt     time.Time
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// BotUser пользователь, взаимодействовавший с ботом
+// Хранит настройки получателя, которые известны только NotificationService (например, язык интерфейса Telegram)
+type BotUser struct {
+	TgUserID     int64             `db:"tg_user_id"`
+	LanguageCode *string           `db:"language_code"` // Нормализованный код языка из Telegram (ru, en, ...)
+	Attribution  *StartAttribution // Первая deep-link ссылка, по которой пользователь пришёл в бота
+	CreatedAt    time.Time         `db:"created_at"`
+	UpdatedAt    time.Time         `db:"updated_at"`
+}
+
+// Locale возвращает язык пользователя или пустую строку, если он неизвестен
+func (u *BotUser) Locale() string {
+	if u == nil || u.LanguageCode == nil {
+		return ""
+	}
+	return *u.LanguageCode
+}
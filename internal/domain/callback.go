@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+// CallbackAction действие, которое выполняется при нажатии callback-кнопки
+// В callback_data кнопки передаётся "<action>" или "<action>:<arg>"
+type CallbackAction string
+
+const (
+	CallbackActionConfirmBooking CallbackAction = "confirm_booking" // Подтверждение записи клиентом
+	CallbackActionCancelBooking  CallbackAction = "cancel_booking"  // Отмена записи через BookingService
+	CallbackActionSnooze         CallbackAction = "snooze"          // Повтор напоминания позже (arg - минуты)
+)
+
+// IsValid проверяет, зарегистрировано ли действие
+func (a CallbackAction) IsValid() bool {
+	switch a {
+	case CallbackActionConfirmBooking, CallbackActionCancelBooking, CallbackActionSnooze:
+		return true
+	}
+	return false
+}
+
+// RequiresBooking проверяет, нужно ли действию бронирование из metadata уведомления
+func (a CallbackAction) RequiresBooking() bool {
+	return a == CallbackActionConfirmBooking || a == CallbackActionCancelBooking
+}
+
+// CallbackStatus результат обработки callback-запроса
+type CallbackStatus string
+
+const (
+	CallbackStatusSuccess  CallbackStatus = "success"  // Действие выполнено
+	CallbackStatusRejected CallbackStatus = "rejected" // Запрос отклонён (подпись, чужое уведомление, неизвестное действие)
+	CallbackStatusFailed   CallbackStatus = "failed"   // Ошибка при выполнении действия
+)
+
+// CallbackAudit запись журнала действий по callback-кнопкам
+type CallbackAudit struct {
+	ID              int64          `db:"id"`
+	CallbackQueryID string         `db:"callback_query_id"`
+	NotificationID  *int64         `db:"notification_id"` // NULL, если callback_data не прошли проверку
+	TgUserID        int64          `db:"tg_user_id"`
+	Action          *string        `db:"action"`
+	Arg             *string        `db:"arg"`
+	Status          CallbackStatus `db:"status"`
+	ErrorMessage    *string        `db:"error_message"`
+	CreatedAt       time.Time      `db:"created_at"`
+}
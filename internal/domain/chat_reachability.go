@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// UnreachableReason причина, по которой бот не может писать в чат
+type UnreachableReason string
+
+const (
+	UnreachableReasonBlocked     UnreachableReason = "blocked"     // Пользователь заблокировал бота
+	UnreachableReasonKicked      UnreachableReason = "kicked"      // Бота удалили из группы или канала
+	UnreachableReasonDeactivated UnreachableReason = "deactivated" // Аккаунт пользователя удалён
+	UnreachableReasonForbidden   UnreachableReason = "forbidden"   // Другой отказ Telegram с кодом 403
+)
+
+// ReachabilitySource источник сведений о доступности чата
+type ReachabilitySource string
+
+const (
+	ReachabilitySourceSendError    ReachabilitySource = "send_error"     // Ошибка 403 при отправке
+	ReachabilitySourceMyChatMember ReachabilitySource = "my_chat_member" // Обновление статуса бота в чате
+	ReachabilitySourceStart        ReachabilitySource = "start"          // Пользователь снова отправил /start
+)
+
+// SkipReasonChatUnreachable уведомление не отправлено: бот не может писать в чат (заблокирован, удалён из группы)
+const SkipReasonChatUnreachable SkipReason = "chat_unreachable"
+
+// ChatReachability доступность чата для отправки сообщений
+// Запись появляется при первой недоступности и сохраняется после восстановления для аудита
+type ChatReachability struct {
+	ChatID           int64
+	Reachable        bool
+	Reason           *UnreachableReason // Причина недоступности (nil для доступного чата)
+	Source           ReachabilitySource // Откуда получено последнее изменение
+	ErrorMessage     *string            // Описание ошибки Telegram (для source=send_error)
+	UnreachableSince *time.Time         // Когда чат стал недоступен
+	RestoredAt       *time.Time         // Когда доступность восстановлена в последний раз
+	UpdatedAt        time.Time
+}
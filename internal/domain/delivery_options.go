@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// DeliveryOptions параметры доставки уведомления в Telegram
+// Применяются ко всем сообщениям уведомления (текст, файлы, кнопки)
+type DeliveryOptions struct {
+	DisableNotification bool                `json:"disable_notification,omitempty"` // Без звука: например, ночные напоминания
+	ProtectContent      bool                `json:"protect_content,omitempty"`      // Запрет пересылки и сохранения: например, промокоды
+	LinkPreview         *LinkPreviewOptions `json:"link_preview,omitempty"`         // Превью ссылки в текстовом сообщении
+	ReplyToMessageID    *int                `json:"reply_to_message_id,omitempty"`  // Ответ на сообщение чата (первое сообщение уведомления)
+	MessageThreadID     *int                `json:"message_thread_id,omitempty"`    // Тема форума в группе компании
+}
+
+// LinkPreviewOptions параметры превью ссылки в формате link_preview_options Telegram Bot API
+type LinkPreviewOptions struct {
+	IsDisabled       bool   `json:"is_disabled,omitempty"`        // Не показывать превью
+	URL              string `json:"url,omitempty"`                // Ссылка для превью (по умолчанию - первая ссылка в тексте)
+	PreferSmallMedia bool   `json:"prefer_small_media,omitempty"` // Уменьшенное изображение превью
+	PreferLargeMedia bool   `json:"prefer_large_media,omitempty"` // Увеличенное изображение превью
+	ShowAboveText    bool   `json:"show_above_text,omitempty"`    // Превью над текстом
+}
+
+// Validate проверяет параметры доставки
+func (o *DeliveryOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+
+	if o.ReplyToMessageID != nil && *o.ReplyToMessageID <= 0 {
+		return errors.New("delivery_options: reply_to_message_id must be positive")
+	}
+
+	if o.MessageThreadID != nil && *o.MessageThreadID <= 0 {
+		return errors.New("delivery_options: message_thread_id must be positive")
+	}
+
+	if o.LinkPreview != nil {
+		if o.LinkPreview.PreferSmallMedia && o.LinkPreview.PreferLargeMedia {
+			return errors.New("delivery_options: link_preview can't prefer both small and large media")
+		}
+
+		if o.LinkPreview.URL != "" {
+			u, err := url.Parse(o.LinkPreview.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("delivery_options: invalid link_preview url %q", o.LinkPreview.URL)
+			}
+		}
+	}
+
+	return nil
+}
+
+// IsChatSpecific проверяет, ссылаются ли параметры на сообщения или темы конкретного чата
+// Такие параметры нельзя применить к массовой рассылке
+func (o *DeliveryOptions) IsChatSpecific() bool {
+	return o != nil && (o.ReplyToMessageID != nil || o.MessageThreadID != nil)
+}
+
+// Value реализует driver.Valuer для записи в БД
+func (o DeliveryOptions) Value() (driver.Value, error) {
+	return json.Marshal(o)
+}
+
+// Scan реализует sql.Scanner для чтения из БД
+func (o *DeliveryOptions) Scan(value interface{}) error {
+	if value == nil {
+		*o = DeliveryOptions{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to scan DeliveryOptions: expected []byte, got %T", value)
+	}
+
+	return json.Unmarshal(bytes, o)
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// GroupChatType тип группового чата Telegram
+type GroupChatType string
+
+const (
+	GroupChatTypeGroup      GroupChatType = "group"      // Обычная группа
+	GroupChatTypeSupergroup GroupChatType = "supergroup" // Супергруппа (в том числе форум)
+)
+
+// IsGroupChatType проверяет, что тип чата Telegram - группа или супергруппа
+func IsGroupChatType(chatType string) bool {
+	return chatType == string(GroupChatTypeGroup) || chatType == string(GroupChatTypeSupergroup)
+}
+
+// GroupChat групповой чат, в который добавлен бот
+// Привязанный к компании чат получает уведомления для сотрудников (chat_id получателя)
+type GroupChat struct {
+	ChatID           int64
+	Type             GroupChatType
+	Title            string
+	Active           bool   // Бот состоит в чате
+	CompanyID        *int64 // Компания, к которой чат привязан командой /link
+	AddedByTgUserID  *int64 // Кто добавил бота в чат
+	LinkedByTgUserID *int64 // Кто привязал чат к компании
+	LinkedAt         *time.Time
+	MigratedToChatID *int64 // Новый ID чата после преобразования в супергруппу
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
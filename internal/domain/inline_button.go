@@ -0,0 +1,213 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"unicode/utf8"
+
+	"github.com/m04kA/SMC-NotificationService/pkg/callbackdata"
+)
+
+// ButtonKind тип inline-кнопки
+type ButtonKind string
+
+const (
+	ButtonKindURL               ButtonKind = "url"                 // Ссылка (по умолчанию)
+	ButtonKindWebApp            ButtonKind = "web_app"             // Открытие Telegram Mini App
+	ButtonKindCallback          ButtonKind = "callback_data"       // Callback-запрос боту
+	ButtonKindSwitchInlineQuery ButtonKind = "switch_inline_query" // Переход в inline-режим бота в выбранном чате
+	ButtonKindCopyText          ButtonKind = "copy_text"           // Копирование текста в буфер обмена
+)
+
+// Ограничения Telegram Bot API для inline-клавиатуры
+const (
+	MaxButtonsPerRow  = 8
+	MaxButtons        = 100
+	MaxCopyTextLength = 256 // В символах
+)
+
+// IsValid проверяет, поддерживается ли тип кнопки
+func (k ButtonKind) IsValid() bool {
+	switch k {
+	case ButtonKindURL, ButtonKindWebApp, ButtonKindCallback, ButtonKindSwitchInlineQuery, ButtonKindCopyText:
+		return true
+	}
+	return false
+}
+
+// InlineButton представляет inline-кнопку в Telegram
+// Заполняется поле, соответствующее типу кнопки
+type InlineButton struct {
+	Kind              ButtonKind `json:"kind,omitempty"`                // Тип кнопки (пустое значение = url)
+	Text              string     `json:"text"`                          // Текст кнопки
+	URL               string     `json:"url,omitempty"`                 // URL для перехода или адрес Mini App
+	CallbackData      string     `json:"callback_data,omitempty"`       // Действие "<action>[:<arg>]", при отправке подписывается вместе с ID уведомления
+	SwitchInlineQuery string     `json:"switch_inline_query,omitempty"` // Начальный inline-запрос (может быть пустым)
+	CopyText          string     `json:"copy_text,omitempty"`           // Текст для копирования (до 256 символов)
+	Row               int        `json:"row,omitempty"`                 // Номер ряда: кнопки с одинаковым номером в одном ряду, 0 - отдельный ряд
+}
+
+// GetKind возвращает тип кнопки (по умолчанию url)
+func (b InlineButton) GetKind() ButtonKind {
+	if b.Kind == "" {
+		return ButtonKindURL
+	}
+	return b.Kind
+}
+
+// IsWebApp проверяет, открывает ли кнопка Mini App
+func (b InlineButton) IsWebApp() bool {
+	return b.GetKind() == ButtonKindWebApp
+}
+
+// Payload возвращает значение кнопки, соответствующее её типу
+func (b InlineButton) Payload() string {
+	switch b.GetKind() {
+	case ButtonKindCallback:
+		return b.CallbackData
+	case ButtonKindSwitchInlineQuery:
+		return b.SwitchInlineQuery
+	case ButtonKindCopyText:
+		return b.CopyText
+	default:
+		return b.URL
+	}
+}
+
+// PayloadField возвращает имя поля, в котором хранится значение кнопки
+func (b InlineButton) PayloadField() string {
+	switch b.GetKind() {
+	case ButtonKindCallback, ButtonKindSwitchInlineQuery, ButtonKindCopyText:
+		return string(b.GetKind())
+	default:
+		return "url"
+	}
+}
+
+// RequiresPayload проверяет, обязательно ли значение для типа кнопки
+// Пустой switch_inline_query допустим: Telegram подставит только имя бота
+func (b InlineButton) RequiresPayload() bool {
+	return b.GetKind() != ButtonKindSwitchInlineQuery
+}
+
+// Validate проверяет кнопку по ограничениям Telegram Bot API
+func (b InlineButton) Validate() error {
+	kind := b.GetKind()
+	if !kind.IsValid() {
+		return fmt.Errorf("unknown kind %q", b.Kind)
+	}
+
+	if b.Text == "" {
+		return errors.New("text is required")
+	}
+
+	if b.Row < 0 {
+		return errors.New("row must be non-negative")
+	}
+
+	if b.RequiresPayload() && b.Payload() == "" {
+		return fmt.Errorf("%s is required for kind %q", b.PayloadField(), kind)
+	}
+
+	switch kind {
+	case ButtonKindURL:
+		u, err := url.Parse(b.URL)
+		if err != nil || u.Scheme == "" {
+			return fmt.Errorf("invalid url %q", b.URL)
+		}
+	case ButtonKindWebApp:
+		u, err := url.Parse(b.URL)
+		if err != nil || u.Scheme != "https" {
+			return fmt.Errorf("web_app url must be https: %q", b.URL)
+		}
+	case ButtonKindCallback:
+		action, _, err := callbackdata.ParsePayload(b.CallbackData)
+		if err != nil {
+			return fmt.Errorf("callback_data: %v", err)
+		}
+		if !CallbackAction(action).IsValid() {
+			return fmt.Errorf("callback_data: unknown action %q", action)
+		}
+	case ButtonKindCopyText:
+		if utf8.RuneCountInString(b.CopyText) > MaxCopyTextLength {
+			return fmt.Errorf("copy_text exceeds %d characters", MaxCopyTextLength)
+		}
+	}
+
+	return nil
+}
+
+// Validate проверяет кнопки и раскладку по рядам
+func (b InlineButtons) Validate() error {
+	if len(b) > MaxButtons {
+		return fmt.Errorf("inline_buttons: at most %d buttons allowed", MaxButtons)
+	}
+
+	for i, btn := range b {
+		if err := btn.Validate(); err != nil {
+			return fmt.Errorf("inline_buttons[%d]: %w", i, err)
+		}
+	}
+
+	for i, row := range b.Rows() {
+		if len(row) > MaxButtonsPerRow {
+			return fmt.Errorf("inline_buttons: row %d has more than %d buttons", i+1, MaxButtonsPerRow)
+		}
+	}
+
+	return nil
+}
+
+// Rows раскладывает кнопки по рядам клавиатуры
+// Кнопки с одинаковым Row > 0 попадают в один ряд, кнопки без Row - каждая в свой ряд
+// Ряды идут в порядке первого появления кнопок
+func (b InlineButtons) Rows() [][]InlineButton {
+	rows := make([][]InlineButton, 0, len(b))
+	rowIndex := make(map[int]int)
+
+	for _, btn := range b {
+		if btn.Row > 0 {
+			if idx, ok := rowIndex[btn.Row]; ok {
+				rows[idx] = append(rows[idx], btn)
+				continue
+			}
+			rowIndex[btn.Row] = len(rows)
+		}
+		rows = append(rows, []InlineButton{btn})
+	}
+
+	return rows
+}
+
+// HasCallbacks проверяет, есть ли среди кнопок callback-кнопки
+func (b InlineButtons) HasCallbacks() bool {
+	for _, btn := range b {
+		if btn.GetKind() == ButtonKindCallback {
+			return true
+		}
+	}
+	return false
+}
+
+// WithSignedCallbacks возвращает копию кнопок, в которой действия callback-кнопок заменены результатом sign
+// Кнопки копируются, чтобы не изменять кнопки уведомления или шаблона
+func (b InlineButtons) WithSignedCallbacks(sign func(action string) (string, error)) ([]InlineButton, error) {
+	if !b.HasCallbacks() {
+		return b, nil
+	}
+
+	buttons := make([]InlineButton, len(b))
+	for i, btn := range b {
+		if btn.GetKind() == ButtonKindCallback {
+			data, err := sign(btn.CallbackData)
+			if err != nil {
+				return nil, fmt.Errorf("inline_buttons[%d]: %w", i, err)
+			}
+			btn.CallbackData = data
+		}
+		buttons[i] = btn
+	}
+
+	return buttons, nil
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "strings"
+
+// DefaultLocale язык по умолчанию, если у получателя язык неизвестен или для него нет варианта шаблона
+const DefaultLocale = "ru"
+
+// NormalizeLocale приводит language_code Telegram (например, "en-US") к коду языка ("en")
+func NormalizeLocale(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_"); i > 0 {
+		code = code[:i]
+	}
+	return code
+}
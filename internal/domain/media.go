@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// MaxMediaPhotoSize максимальный размер фото, которое бот может загрузить в Telegram
+	MaxMediaPhotoSize = 10 << 20
+
+	// MaxMediaFileSize максимальный размер остальных файлов, которые бот может загрузить в Telegram
+	MaxMediaFileSize = 50 << 20
+)
+
+// Media файл медиатеки, загруженный через API
+// Уведомления ссылаются на него через media_id вложения, содержимое хранится в blob-хранилище под ключом ID
+type Media struct {
+	ID          string         `db:"id"`         // UUID, он же media_id
+	Type        AttachmentType `db:"media_type"` // photo, video, document или audio
+	Filename    string         `db:"filename"`   // Имя, под которым файл отправляется в Telegram
+	ContentType string         `db:"content_type"`
+	Size        int64          `db:"size_bytes"`
+	CreatedAt   time.Time      `db:"created_at"`
+}
+
+// MaxMediaSize возвращает ограничение Telegram на размер загружаемого файла данного типа
+func MaxMediaSize(t AttachmentType) int64 {
+	if t == AttachmentTypePhoto {
+		return MaxMediaPhotoSize
+	}
+	return MaxMediaFileSize
+}
+
+// MediaTypeByContentType определяет тип вложения по MIME-типу файла
+// Фото - только форматы, которые Telegram принимает в sendPhoto; всё остальное отправляется как документ
+func MediaTypeByContentType(contentType string) AttachmentType {
+	switch {
+	case AttachmentTypePhoto.AcceptsContentType(contentType):
+		return AttachmentTypePhoto
+	case AttachmentTypeVideo.AcceptsContentType(contentType):
+		return AttachmentTypeVideo
+	case AttachmentTypeAudio.AcceptsContentType(contentType):
+		return AttachmentTypeAudio
+	default:
+		return AttachmentTypeDocument
+	}
+}
+
+// AcceptsContentType проверяет, можно ли отправить файл с данным MIME-типом вложением этого типа
+func (t AttachmentType) AcceptsContentType(contentType string) bool {
+	contentType, _, _ = strings.Cut(contentType, ";")
+
+	switch t {
+	case AttachmentTypePhoto:
+		return contentType == "image/jpeg" || contentType == "image/png" || contentType == "image/webp"
+	case AttachmentTypeVideo:
+		return contentType == "video/mp4"
+	case AttachmentTypeAudio:
+		return contentType == "audio/mpeg" || contentType == "audio/mp4"
+	case AttachmentTypeDocument:
+		return true
+	}
+	return false
+}
+
+// CanSendAs проверяет, можно ли отправить файл медиатеки вложением данного типа
+// Любой файл можно отправить документом
+func (m *Media) CanSendAs(t AttachmentType) bool {
+	return t == m.Type || t == AttachmentTypeDocument
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/lib/pq"
@@ -21,6 +22,22 @@ const (
 	NotificationTypePromo            NotificationType = "promo"
 )
 
+// IsBooking проверяет, относится ли тип уведомления к бронированиям
+func (t NotificationType) IsBooking() bool {
+	switch t {
+	case NotificationTypeBookingCreated,
+		NotificationTypeBookingConfirmed,
+		NotificationTypeBookingReminder,
+		NotificationTypeBookingCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// MetadataKeyBookingID ключ metadata с ID бронирования
+const MetadataKeyBookingID = "booking_id"
+
 // NotificationStatus представляет статус уведомления
 type NotificationStatus string
 
@@ -30,14 +47,9 @@ const (
 	NotificationStatusSent      NotificationStatus = "sent"      // Отправлено
 	NotificationStatusFailed    NotificationStatus = "failed"    // Ошибка
 	NotificationStatusCancelled NotificationStatus = "cancelled" // Отменено
+	NotificationStatusSkipped   NotificationStatus = "skipped"   // Не отправлено по настройкам получателя
 )
 
-// InlineButton представляет inline-кнопку в Telegram
-type InlineButton struct {
-	Text string `json:"text"` // Текст кнопки
-	URL  string `json:"url"`  // URL для перехода
-}
-
 // InlineButtons - массив inline-кнопок для хранения в БД
 type InlineButtons []InlineButton
 
@@ -66,22 +78,34 @@ func (b *InlineButtons) Scan(value interface{}) error {
 
 // Notification представляет уведомление в системе
 type Notification struct {
-	ID             int64              `db:"id"`
-	TelegramUserID *int64             `db:"telegram_user_id"`
-	ChatID         *int64             `db:"chat_id"`
-	SpanID         *string            `db:"span_id"` // UUID для группировки массовых рассылок
-	MessageText    string             `db:"message_text"`
-	ImageURLs      pq.StringArray     `db:"image_urls"` // Массив URL изображений (до 10)
-	InlineButtons  InlineButtons      `db:"inline_buttons"`
-	Type           NotificationType   `db:"notification_type"`
-	Status         NotificationStatus `db:"status"`
-	ScheduledFor   *time.Time         `db:"scheduled_for"`
-	SentAt         *time.Time         `db:"sent_at"`
-	Metadata       Metadata           `db:"metadata"`
-	ErrorMessage   *string            `db:"error_message"`
-	RetryCount     int                `db:"retry_count"`
-	CreatedAt      time.Time          `db:"created_at"`
-	UpdatedAt      time.Time          `db:"updated_at"`
+	ID                int64              `db:"id"`
+	TelegramUserID    *int64             `db:"telegram_user_id"`
+	ChatID            *int64             `db:"chat_id"`
+	SpanID            *string            `db:"span_id"` // UUID для группировки массовых рассылок
+	MessageText       string             `db:"message_text"`
+	ParseMode         *string            `db:"parse_mode"`         // Режим парсинга текста (NULL - HTML)
+	TemplateName      *string            `db:"template_name"`      // Имя шаблона из реестра (NULL для готового текста)
+	TemplateVersion   *int               `db:"template_version"`   // Версия шаблона, зафиксированная при создании
+	TemplateVariables Metadata           `db:"template_variables"` // Переменные для рендеринга шаблона
+	Locale            *string            `db:"locale"`             // Язык получателя (для шаблона - язык выбранного варианта)
+	ImageURLs         pq.StringArray     `db:"image_urls"`         // Массив URL изображений (до 10)
+	Attachments       Attachments        `db:"attachments"`        // Документы, видео, аудио, точки на карте и контакты
+	InlineButtons     InlineButtons      `db:"inline_buttons"`
+	DeliveryOptions   *DeliveryOptions   `db:"delivery_options"` // Без звука, защита от пересылки, превью ссылок, ответ и тема форума
+	Type              NotificationType   `db:"notification_type"`
+	Status            NotificationStatus `db:"status"`
+	ScheduledFor      *time.Time         `db:"scheduled_for"`
+	SentAt            *time.Time         `db:"sent_at"`
+	SentMessages      SentMessages       `db:"sent_messages"`       // Сообщения Telegram, отправленные по уведомлению
+	MessagesEditedAt  *time.Time         `db:"messages_edited_at"`  // Время последнего редактирования отправленных сообщений
+	MessagesDeletedAt *time.Time         `db:"messages_deleted_at"` // Время удаления отправленных сообщений
+	Metadata          Metadata           `db:"metadata"`
+	ErrorMessage      *string            `db:"error_message"`
+	SkipReason        *SkipReason        `db:"skip_reason"`    // Причина пропуска (для статуса skipped)
+	DeferredUntil     *time.Time         `db:"deferred_until"` // Pending уведомление отложено до конца тихих часов получателя или до повторной отправки
+	RetryCount        int                `db:"retry_count"`
+	CreatedAt         time.Time          `db:"created_at"`
+	UpdatedAt         time.Time          `db:"updated_at"`
 }
 
 // Metadata представляет дополнительные данные уведомления
@@ -110,6 +134,43 @@ func (m *Metadata) Scan(value interface{}) error {
 	return json.Unmarshal(bytes, m)
 }
 
+// GetInt64 возвращает целочисленное значение по ключу
+// JSON-числа приходят как float64, поэтому поддерживаются float64, int64, int и строковое представление
+func (m Metadata) GetInt64(key string) (int64, bool) {
+	value, ok := m[key]
+	if !ok || value == nil {
+		return 0, false
+	}
+
+	switch v := value.(type) {
+	case float64:
+		return int64(v), v == float64(int64(v))
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	case json.Number:
+		n, err := v.Int64()
+		return n, err == nil
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// GetString возвращает строковое значение по ключу
+func (m Metadata) GetString(key string) (string, bool) {
+	value, ok := m[key].(string)
+	return value, ok
+}
+
+// BookingID возвращает ID бронирования из metadata
+func (n *Notification) BookingID() (int64, bool) {
+	return n.Metadata.GetInt64(MetadataKeyBookingID)
+}
+
 // GetChatID возвращает chat_id с приоритетом: chat_id > telegram_user_id
 func (n *Notification) GetChatID() int64 {
 	if n.ChatID != nil {
@@ -141,6 +202,11 @@ func (n *Notification) HasImages() bool {
 	return len(n.ImageURLs) > 0
 }
 
+// HasAttachments проверяет, есть ли у уведомления вложения
+func (n *Notification) HasAttachments() bool {
+	return len(n.Attachments) > 0
+}
+
 // HasButtons проверяет, есть ли у уведомления inline-кнопки
 func (n *Notification) HasButtons() bool {
 	return len(n.InlineButtons) > 0
@@ -150,3 +216,16 @@ func (n *Notification) HasButtons() bool {
 func (n *Notification) IsMediaGroup() bool {
 	return len(n.ImageURLs) > 1
 }
+
+// GetParseMode возвращает режим парсинга текста (по умолчанию HTML)
+func (n *Notification) GetParseMode() string {
+	if n.ParseMode != nil {
+		return *n.ParseMode
+	}
+	return ParseModeHTML
+}
+
+// HasTemplate проверяет, рендерится ли текст уведомления из шаблона
+func (n *Notification) HasTemplate() bool {
+	return n.TemplateName != nil && *n.TemplateName != ""
+}
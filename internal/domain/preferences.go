@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// NotificationChannel канал доставки уведомлений
+type NotificationChannel string
+
+const (
+	NotificationChannelTelegram NotificationChannel = "telegram"
+)
+
+// NotificationChannels каналы доставки в порядке показа в настройках
+var NotificationChannels = []NotificationChannel{NotificationChannelTelegram}
+
+// IsValid проверяет, поддерживается ли канал
+func (c NotificationChannel) IsValid() bool {
+	for _, channel := range NotificationChannels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// OptionalNotificationTypes типы уведомлений, которые пользователь может отключить (в порядке показа в настройках)
+var OptionalNotificationTypes = []NotificationType{NotificationTypePromo, NotificationTypeWelcome}
+
+// MandatoryNotificationTypes типы, которые доставляются независимо от настроек пользователя
+var MandatoryNotificationTypes = []NotificationType{
+	NotificationTypeBookingCreated,
+	NotificationTypeBookingConfirmed,
+	NotificationTypeBookingReminder,
+	NotificationTypeBookingCancelled,
+}
+
+// IsMandatory проверяет, является ли тип обязательным
+// Транзакционные уведомления о бронированиях доставляются независимо от настроек пользователя
+func (t NotificationType) IsMandatory() bool {
+	return t.IsBooking()
+}
+
+// SkipReason причина, по которой уведомление не отправлено (статус skipped)
+type SkipReason string
+
+const (
+	SkipReasonTypeDisabled    SkipReason = "type_disabled"    // Пользователь отключил тип уведомлений
+	SkipReasonChannelDisabled SkipReason = "channel_disabled" // Пользователь отключил канал доставки
+)
+
+// UserPreferences настройки уведомлений пользователя Telegram
+// Хранятся отключённые типы и каналы: новые типы уведомлений по умолчанию включены
+type UserPreferences struct {
+	TgUserID         int64
+	DisabledTypes    []NotificationType
+	DisabledChannels []NotificationChannel
+	TimeZone         string      // Часовой пояс IANA (пусто - UTC)
+	QuietHours       *QuietHours // Тихие часы (nil - не заданы)
+	UpdatedAt        time.Time
+}
+
+// NewUserPreferences возвращает настройки по умолчанию (все уведомления включены)
+func NewUserPreferences(tgUserID int64) *UserPreferences {
+	return &UserPreferences{TgUserID: tgUserID}
+}
+
+// IsTypeEnabled проверяет, включён ли тип уведомлений
+func (p *UserPreferences) IsTypeEnabled(t NotificationType) bool {
+	if t.IsMandatory() {
+		return true
+	}
+	for _, disabled := range p.DisabledTypes {
+		if disabled == t {
+			return false
+		}
+	}
+	return true
+}
+
+// IsChannelEnabled проверяет, включён ли канал доставки
+func (p *UserPreferences) IsChannelEnabled(c NotificationChannel) bool {
+	for _, disabled := range p.DisabledChannels {
+		if disabled == c {
+			return false
+		}
+	}
+	return true
+}
+
+// SetType включает или отключает тип уведомлений
+// Обязательные типы отключить нельзя
+func (p *UserPreferences) SetType(t NotificationType, enabled bool) error {
+	if t.IsMandatory() {
+		if !enabled {
+			return fmt.Errorf("type %q is mandatory and can't be disabled", t)
+		}
+		return nil
+	}
+	if !isOptionalType(t) {
+		return fmt.Errorf("unknown notification type %q", t)
+	}
+
+	p.DisabledTypes = setDisabled(p.DisabledTypes, t, enabled)
+	return nil
+}
+
+// SetChannel включает или отключает канал доставки
+func (p *UserPreferences) SetChannel(c NotificationChannel, enabled bool) error {
+	if !c.IsValid() {
+		return fmt.Errorf("unknown channel %q", c)
+	}
+
+	p.DisabledChannels = setDisabled(p.DisabledChannels, c, enabled)
+	return nil
+}
+
+// SkipReason возвращает причину пропуска уведомления типа t в канале c
+// Обязательные уведомления не пропускаются даже при отключённом канале
+func (p *UserPreferences) SkipReason(t NotificationType, c NotificationChannel) (SkipReason, bool) {
+	if t.IsMandatory() {
+		return "", false
+	}
+	if !p.IsChannelEnabled(c) {
+		return SkipReasonChannelDisabled, true
+	}
+	if !p.IsTypeEnabled(t) {
+		return SkipReasonTypeDisabled, true
+	}
+	return "", false
+}
+
+// isOptionalType проверяет, может ли пользователь отключить тип
+func isOptionalType(t NotificationType) bool {
+	for _, optional := range OptionalNotificationTypes {
+		if t == optional {
+			return true
+		}
+	}
+	return false
+}
+
+// setDisabled добавляет значение в список отключённых или удаляет из него
+func setDisabled[T comparable](disabled []T, value T, enabled bool) []T {
+	result := make([]T, 0, len(disabled)+1)
+	for _, v := range disabled {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	if !enabled {
+		result = append(result, value)
+	}
+	return result
+}
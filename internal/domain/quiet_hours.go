@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/pkg/types"
+)
+
+// IsUrgent проверяет, нужно ли отправлять уведомление немедленно, даже в тихие часы
+// Срочными считаются изменения бронирования: создание, подтверждение и отмена
+// Напоминания, акции и приветствия откладываются до конца тихих часов
+func (t NotificationType) IsUrgent() bool {
+	switch t {
+	case NotificationTypeBookingCreated, NotificationTypeBookingConfirmed, NotificationTypeBookingCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// QuietHours тихие часы пользователя в формате HH:MM по его часовому поясу
+// Окно может переходить через полночь (например, 23:00-08:00)
+type QuietHours struct {
+	Start types.TimeString
+	End   types.TimeString
+}
+
+// Validate проверяет границы тихих часов
+func (q QuietHours) Validate() error {
+	if q.Start.IsZero() || q.End.IsZero() {
+		return fmt.Errorf("quiet hours start and end are required")
+	}
+	if err := q.Start.Validate(); err != nil {
+		return fmt.Errorf("quiet hours start: %w", err)
+	}
+	if err := q.End.Validate(); err != nil {
+		return fmt.Errorf("quiet hours end: %w", err)
+	}
+	if q.Start.Equal(q.End) {
+		return fmt.Errorf("quiet hours start and end must differ")
+	}
+	return nil
+}
+
+// Until возвращает конец текущего окна тихих часов
+// Если момент now (в часовом поясе пользователя) не попадает в окно, возвращает false
+func (q QuietHours) Until(now time.Time) (time.Time, bool) {
+	startHour, startMinute, err := q.Start.Clock()
+	if err != nil {
+		return time.Time{}, false
+	}
+	endHour, endMinute, err := q.End.Clock()
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	start := startHour*60 + startMinute
+	end := endHour*60 + endMinute
+	current := now.Hour()*60 + now.Minute()
+
+	endToday := time.Date(now.Year(), now.Month(), now.Day(), endHour, endMinute, 0, 0, now.Location())
+
+	if start < end {
+		// Окно внутри суток: 13:00-15:00
+		if current >= start && current < end {
+			return endToday, true
+		}
+		return time.Time{}, false
+	}
+
+	// Окно через полночь: 23:00-08:00
+	if current >= start {
+		return endToday.AddDate(0, 0, 1), true
+	}
+	if current < end {
+		return endToday, true
+	}
+	return time.Time{}, false
+}
+
+// Location возвращает часовой пояс пользователя (UTC, если не задан)
+func (p *UserPreferences) Location() *time.Location {
+	if p.TimeZone == "" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(p.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// SetTimeZone устанавливает часовой пояс в формате IANA (например, Europe/Moscow)
+// Пустое значение сбрасывает часовой пояс на UTC
+func (p *UserPreferences) SetTimeZone(timeZone string) error {
+	if timeZone != "" {
+		if _, err := time.LoadLocation(timeZone); err != nil {
+			return fmt.Errorf("unknown time zone %q", timeZone)
+		}
+	}
+
+	p.TimeZone = timeZone
+	return nil
+}
+
+// SetQuietHours устанавливает тихие часы
+// nil отключает тихие часы
+func (p *UserPreferences) SetQuietHours(quietHours *QuietHours) error {
+	if quietHours != nil {
+		if err := quietHours.Validate(); err != nil {
+			return err
+		}
+	}
+
+	p.QuietHours = quietHours
+	return nil
+}
+
+// QuietUntil возвращает время, до которого нужно отложить уведомление типа t
+// Срочные уведомления и уведомления вне тихих часов не откладываются
+func (p *UserPreferences) QuietUntil(t NotificationType, now time.Time) (time.Time, bool) {
+	if t.IsUrgent() || p.QuietHours == nil {
+		return time.Time{}, false
+	}
+
+	until, quiet := p.QuietHours.Until(now.In(p.Location()))
+	if !quiet {
+		return time.Time{}, false
+	}
+	return until.UTC(), true
+}
@@ -0,0 +1,241 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m04kA/SMC-NotificationService/pkg/types"
+)
+
+func TestQuietHours_Until(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		start     types.TimeString
+		end       types.TimeString
+		now       time.Time
+		wantUntil time.Time
+		wantQuiet bool
+	}{
+		{
+			name:      "same day window, inside",
+			start:     "13:00",
+			end:       "15:00",
+			now:       time.Date(2026, 5, 10, 14, 0, 0, 0, time.UTC),
+			wantUntil: time.Date(2026, 5, 10, 15, 0, 0, 0, time.UTC),
+			wantQuiet: true,
+		},
+		{
+			name:  "same day window, before",
+			start: "13:00",
+			end:   "15:00",
+			now:   time.Date(2026, 5, 10, 12, 59, 0, 0, time.UTC),
+		},
+		{
+			name:  "same day window, after",
+			start: "13:00",
+			end:   "15:00",
+			now:   time.Date(2026, 5, 10, 16, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "same day window, start minute is quiet",
+			start:     "13:00",
+			end:       "15:00",
+			now:       time.Date(2026, 5, 10, 13, 0, 0, 0, time.UTC),
+			wantUntil: time.Date(2026, 5, 10, 15, 0, 0, 0, time.UTC),
+			wantQuiet: true,
+		},
+		{
+			name:  "same day window, end minute is not quiet",
+			start: "13:00",
+			end:   "15:00",
+			now:   time.Date(2026, 5, 10, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "same day window, last quiet minute",
+			start:     "13:00",
+			end:       "15:00",
+			now:       time.Date(2026, 5, 10, 14, 59, 59, 0, time.UTC),
+			wantUntil: time.Date(2026, 5, 10, 15, 0, 0, 0, time.UTC),
+			wantQuiet: true,
+		},
+		{
+			name:      "overnight window, before midnight ends next day",
+			start:     "23:00",
+			end:       "08:00",
+			now:       time.Date(2026, 5, 10, 23, 30, 0, 0, time.UTC),
+			wantUntil: time.Date(2026, 5, 11, 8, 0, 0, 0, time.UTC),
+			wantQuiet: true,
+		},
+		{
+			name:      "overnight window, after midnight ends same day",
+			start:     "23:00",
+			end:       "08:00",
+			now:       time.Date(2026, 5, 11, 2, 0, 0, 0, time.UTC),
+			wantUntil: time.Date(2026, 5, 11, 8, 0, 0, 0, time.UTC),
+			wantQuiet: true,
+		},
+		{
+			name:      "overnight window, start minute is quiet",
+			start:     "23:00",
+			end:       "08:00",
+			now:       time.Date(2026, 5, 10, 23, 0, 0, 0, time.UTC),
+			wantUntil: time.Date(2026, 5, 11, 8, 0, 0, 0, time.UTC),
+			wantQuiet: true,
+		},
+		{
+			name:  "overnight window, end minute is not quiet",
+			start: "23:00",
+			end:   "08:00",
+			now:   time.Date(2026, 5, 11, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "overnight window, daytime",
+			start: "23:00",
+			end:   "08:00",
+			now:   time.Date(2026, 5, 11, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "overnight window, end of month",
+			start:     "23:00",
+			end:       "08:00",
+			now:       time.Date(2026, 5, 31, 23, 15, 0, 0, time.UTC),
+			wantUntil: time.Date(2026, 6, 1, 8, 0, 0, 0, time.UTC),
+			wantQuiet: true,
+		},
+		{
+			// Ночь перехода на летнее время: 02:00 CET -> 03:00 CEST, окно короче на час
+			name:      "overnight window across spring DST change",
+			start:     "23:00",
+			end:       "08:00",
+			now:       time.Date(2026, 3, 28, 23, 30, 0, 0, berlin),
+			wantUntil: time.Date(2026, 3, 29, 6, 0, 0, 0, time.UTC),
+			wantQuiet: true,
+		},
+		{
+			// Ночь перехода на зимнее время: 03:00 CEST -> 02:00 CET, окно длиннее на час
+			name:      "overnight window across autumn DST change",
+			start:     "23:00",
+			end:       "08:00",
+			now:       time.Date(2026, 10, 24, 23, 30, 0, 0, berlin),
+			wantUntil: time.Date(2026, 10, 25, 7, 0, 0, 0, time.UTC),
+			wantQuiet: true,
+		},
+		{
+			// Конец окна 02:30 не существует в день перехода и сдвигается на 03:30 CEST
+			name:      "window ending in skipped DST hour",
+			start:     "01:00",
+			end:       "02:30",
+			now:       time.Date(2026, 3, 29, 1, 30, 0, 0, berlin),
+			wantUntil: time.Date(2026, 3, 29, 1, 30, 0, 0, time.UTC),
+			wantQuiet: true,
+		},
+		{
+			name:  "invalid bounds",
+			start: "25:00",
+			end:   "08:00",
+			now:   time.Date(2026, 5, 10, 23, 30, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quietHours := QuietHours{Start: tt.start, End: tt.end}
+
+			until, quiet := quietHours.Until(tt.now)
+
+			assert.Equal(t, tt.wantQuiet, quiet)
+			if !tt.wantQuiet {
+				assert.True(t, until.IsZero())
+				return
+			}
+			assert.True(t, tt.wantUntil.Equal(until), "until: want %s, got %s", tt.wantUntil, until)
+		})
+	}
+}
+
+func TestUserPreferences_QuietUntil(t *testing.T) {
+	quietHours := &QuietHours{Start: "23:00", End: "08:00"}
+
+	tests := []struct {
+		name             string
+		preferences      UserPreferences
+		notificationType NotificationType
+		now              time.Time
+		wantUntil        time.Time
+		wantQuiet        bool
+	}{
+		{
+			name:             "UTC by default",
+			preferences:      UserPreferences{QuietHours: quietHours},
+			notificationType: NotificationTypeBookingReminder,
+			now:              time.Date(2026, 5, 10, 23, 30, 0, 0, time.UTC),
+			wantUntil:        time.Date(2026, 5, 11, 8, 0, 0, 0, time.UTC),
+			wantQuiet:        true,
+		},
+		{
+			// 21:30 UTC = 00:30 MSK: окно пользователя уже началось, конец в 08:00 MSK = 05:00 UTC
+			name:             "user time zone",
+			preferences:      UserPreferences{TimeZone: "Europe/Moscow", QuietHours: quietHours},
+			notificationType: NotificationTypePromo,
+			now:              time.Date(2026, 5, 10, 21, 30, 0, 0, time.UTC),
+			wantUntil:        time.Date(2026, 5, 11, 5, 0, 0, 0, time.UTC),
+			wantQuiet:        true,
+		},
+		{
+			// 21:30 UTC = 23:30 CEST; после перехода на зимнее время 08:00 CET = 07:00 UTC
+			name:             "user time zone across DST change",
+			preferences:      UserPreferences{TimeZone: "Europe/Berlin", QuietHours: quietHours},
+			notificationType: NotificationTypeBookingReminder,
+			now:              time.Date(2026, 10, 24, 21, 30, 0, 0, time.UTC),
+			wantUntil:        time.Date(2026, 10, 25, 7, 0, 0, 0, time.UTC),
+			wantQuiet:        true,
+		},
+		{
+			// 22:30 UTC = 18:30 EDT: в часовом поясе пользователя окно ещё не началось
+			name:             "outside window in user time zone",
+			preferences:      UserPreferences{TimeZone: "America/New_York", QuietHours: quietHours},
+			notificationType: NotificationTypeBookingReminder,
+			now:              time.Date(2026, 5, 10, 22, 30, 0, 0, time.UTC),
+		},
+		{
+			name:             "urgent notification is not deferred",
+			preferences:      UserPreferences{QuietHours: quietHours},
+			notificationType: NotificationTypeBookingCancelled,
+			now:              time.Date(2026, 5, 10, 23, 30, 0, 0, time.UTC),
+		},
+		{
+			name:             "no quiet hours",
+			preferences:      UserPreferences{},
+			notificationType: NotificationTypeBookingReminder,
+			now:              time.Date(2026, 5, 10, 23, 30, 0, 0, time.UTC),
+		},
+		{
+			name:             "unknown time zone falls back to UTC",
+			preferences:      UserPreferences{TimeZone: "Mars/Olympus", QuietHours: quietHours},
+			notificationType: NotificationTypeBookingReminder,
+			now:              time.Date(2026, 5, 10, 23, 30, 0, 0, time.UTC),
+			wantUntil:        time.Date(2026, 5, 11, 8, 0, 0, 0, time.UTC),
+			wantQuiet:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			until, quiet := tt.preferences.QuietUntil(tt.notificationType, tt.now)
+
+			assert.Equal(t, tt.wantQuiet, quiet)
+			if !tt.wantQuiet {
+				assert.True(t, until.IsZero())
+				return
+			}
+			assert.Equal(t, tt.wantUntil, until)
+			assert.Equal(t, time.UTC, until.Location())
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// SentMessageRole роль сообщения Telegram в отправленном уведомлении
+// Определяет, как сообщение можно изменить после отправки
+type SentMessageRole string
+
+const (
+	SentMessageRoleText       SentMessageRole = "text"       // Текстовое сообщение с текстом уведомления (или его частью)
+	SentMessageRoleCaption    SentMessageRole = "caption"    // Файл, подписью которого служит текст уведомления
+	SentMessageRoleFile       SentMessageRole = "file"       // Файл без текста уведомления
+	SentMessageRoleButtons    SentMessageRole = "buttons"    // Отдельное сообщение с кнопками после медиагруппы
+	SentMessageRoleStandalone SentMessageRole = "standalone" // Точка на карте, место или контакт
+)
+
+// SentMessage сообщение Telegram, отправленное в рамках уведомления
+type SentMessage struct {
+	MessageID   int             `json:"message_id"`
+	Role        SentMessageRole `json:"role"`
+	HasKeyboard bool            `json:"has_keyboard,omitempty"` // Сообщение несёт inline-кнопки уведомления: на нём они изменяются
+}
+
+// SentMessages - сообщения уведомления в порядке отправки для хранения в БД
+type SentMessages []SentMessage
+
+// Value реализует driver.Valuer для записи в БД
+func (m SentMessages) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return json.Marshal(m)
+}
+
+// Scan реализует sql.Scanner для чтения из БД
+func (m *SentMessages) Scan(value interface{}) error {
+	if value == nil {
+		*m = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("failed to scan SentMessages: expected []byte, got %T", value)
+	}
+
+	return json.Unmarshal(bytes, m)
+}
+
+// IDs возвращает ID сообщений в порядке отправки
+func (m SentMessages) IDs() []int {
+	ids := make([]int, 0, len(m))
+	for _, msg := range m {
+		ids = append(ids, msg.MessageID)
+	}
+	return ids
+}
+
+// Count возвращает количество сообщений с указанной ролью
+func (m SentMessages) Count(role SentMessageRole) int {
+	count := 0
+	for _, msg := range m {
+		if msg.Role == role {
+			count++
+		}
+	}
+	return count
+}
+
+// TextMessage возвращает индекс первого сообщения с текстом уведомления (текст или подпись), -1 если такого нет
+func (m SentMessages) TextMessage() int {
+	for i, msg := range m {
+		if msg.Role == SentMessageRoleText || msg.Role == SentMessageRoleCaption {
+			return i
+		}
+	}
+	return -1
+}
+
+// KeyboardMessage возвращает индекс сообщения с inline-кнопками, -1 если кнопок нет
+func (m SentMessages) KeyboardMessage() int {
+	for i, msg := range m {
+		if msg.HasKeyboard {
+			return i
+		}
+	}
+	return -1
+}
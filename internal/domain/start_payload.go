@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// StartKind вид deep-link параметра команды /start
+// Значения совпадают с видами pkg/startparam
+type StartKind string
+
+const (
+	StartKindCompany  StartKind = "company" // Приглашение компании
+	StartKindBooking  StartKind = "booking" // Ссылка на бронирование
+	StartKindReferral StartKind = "ref"     // Реферальный код
+	StartKindSource   StartKind = "source"  // Неподписанная метка источника (рекламная кампания, канал)
+)
+
+// IsValid проверяет, что вид параметра известен
+func (k StartKind) IsValid() bool {
+	switch k {
+	case StartKindCompany, StartKindBooking, StartKindReferral, StartKindSource:
+		return true
+	}
+	return false
+}
+
+// StartPayload разобранный параметр deep-link ссылки t.me/<bot>?start=<param>
+type StartPayload struct {
+	Kind  StartKind
+	Value string // ID компании или бронирования, реферальный код или метка источника
+	Param string // Исходный параметр
+}
+
+// StartAttribution источник, по которому пользователь впервые пришёл в бота
+type StartAttribution struct {
+	Kind         StartKind `db:"start_kind"`
+	Value        string    `db:"start_value"`
+	Param        string    `db:"start_param"`
+	AttributedAt time.Time `db:"attributed_at"`
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// SupportDirection направление сообщения в переписке с поддержкой
+type SupportDirection string
+
+const (
+	SupportDirectionInbound  SupportDirection = "inbound"  // Сообщение пользователя боту
+	SupportDirectionOutbound SupportDirection = "outbound" // Ответ оператора пользователю
+)
+
+// SupportMessage сообщение переписки пользователя с поддержкой
+// Сообщения пользователя пересылаются в чат операторов; ответ оператора на пересланное сообщение отправляется пользователю
+type SupportMessage struct {
+	ID                int64
+	TgUserID          int64            // Пользователь (личный чат с ботом)
+	Direction         SupportDirection // inbound или outbound
+	Text              string           // Текст сообщения (для вложений - подпись или пометка о типе)
+	UserMessageID     *int             // ID сообщения в чате пользователя
+	OperatorChatID    *int64           // Чат операторов, в который переслано сообщение
+	OperatorMessageID *int             // ID пересланного сообщения (inbound) или ответа оператора (outbound) в чате операторов
+	OperatorTgUserID  *int64           // Оператор, ответивший пользователю (outbound)
+	CreatedAt         time.Time
+}
+
+// SupportConversation переписка с пользователем: сводка по его сообщениям
+type SupportConversation struct {
+	TgUserID      int64
+	MessageCount  int
+	LastText      string           // Текст последнего сообщения
+	LastDirection SupportDirection // Кто написал последним: inbound - ждёт ответа оператора
+	LastMessageAt time.Time
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// TelegramFile файл, уже загруженный в Telegram
+// file_id позволяет отправлять файл повторно без загрузки и без скачивания Telegram'ом по URL
+type TelegramFile struct {
+	CacheKey  string         `db:"cache_key"` // Ключ: URL файла или хэш содержимого
+	FileID    string         `db:"file_id"`
+	MediaType AttachmentType `db:"media_type"` // file_id действителен только для того же типа отправки
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+}
+
+// TelegramFileURLKey возвращает ключ кэша для файла по URL
+// Имя файла входит в ключ: документ, отправленный под другим именем, - другой файл
+func TelegramFileURLKey(mediaType AttachmentType, url, filename string) string {
+	key := string(mediaType) + ":url:" + url
+	if filename != "" {
+		key += "#" + filename
+	}
+	return key
+}
+
+// TelegramFileMediaKey возвращает ключ кэша для файла медиатеки
+// Содержимое файла медиатеки не меняется, поэтому достаточно его ID
+func TelegramFileMediaKey(mediaType AttachmentType, mediaID string) string {
+	return string(mediaType) + ":media:" + mediaID
+}
+
+// TelegramFileContentKey возвращает ключ кэша для локального файла по хэшу содержимого
+func TelegramFileContentKey(mediaType AttachmentType, content []byte) string {
+	sum := sha256.Sum256(content)
+	return string(mediaType) + ":sha256:" + hex.EncodeToString(sum[:])
+}
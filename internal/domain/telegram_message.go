@@ -1,5 +1,7 @@
 package domain
 
+import "github.com/m04kA/SMC-NotificationService/pkg/tghtml"
+
 // ParseMode константы для режимов парсинга текста в Telegram
 const (
 	ParseModeHTML     = "HTML"     // HTML форматирование (рекомендуется для шаблонов)
@@ -9,22 +11,27 @@ const (
 
 // TelegramMessage представляет сообщение для отправки через Telegram Bot API
 type TelegramMessage struct {
-	ChatID        int64          // ID чата получателя
-	MessageText   string         // Текст сообщения
-	ImageURLs     []string       // URL изображений (для MediaGroup или одиночного фото)
-	InlineButtons []InlineButton // Inline-кнопки
-	ParseMode     string         // Режим парсинга (HTML, Markdown, Plain)
+	ChatID        int64            // ID чата получателя
+	MessageText   string           // Текст сообщения
+	ImageURLs     []string         // URL изображений (для MediaGroup или одиночного фото)
+	Attachments   []Attachment     // Вложения (документы, видео, аудио, точки на карте, контакты)
+	InlineButtons []InlineButton   // Inline-кнопки
+	ParseMode     string           // Режим парсинга (HTML, Markdown, Plain)
+	Options       *DeliveryOptions // Параметры доставки (nil - по умолчанию)
+	Delivered     SentMessages     // Части текста, доставленные прошлой попыткой: отправка продолжается со следующей части
 }
 
 // NewTelegramMessage создает новое сообщение из доменной модели Notification
-// По умолчанию использует HTML для шаблонных сообщений
+// Режим парсинга берётся из уведомления (по умолчанию HTML)
 func NewTelegramMessage(notification *Notification) *TelegramMessage {
 	return &TelegramMessage{
 		ChatID:        notification.GetChatID(),
 		MessageText:   notification.MessageText,
 		ImageURLs:     notification.ImageURLs,
+		Attachments:   notification.Attachments,
 		InlineButtons: notification.InlineButtons,
-		ParseMode:     ParseModeHTML, // HTML по умолчанию для шаблонных сообщений
+		ParseMode:     notification.GetParseMode(),
+		Options:       notification.DeliveryOptions,
 	}
 }
 
@@ -47,6 +54,21 @@ func (m *TelegramMessage) IsMediaGroup() bool {
 	return len(m.ImageURLs) > 1
 }
 
+// HasAttachments проверяет, есть ли вложения помимо изображений
+func (m *TelegramMessage) HasAttachments() bool {
+	return len(m.Attachments) > 0
+}
+
+// AllAttachments возвращает изображения и вложения одним списком
+// Изображения идут первыми и становятся вложениями-фото
+func (m *TelegramMessage) AllAttachments() Attachments {
+	all := make(Attachments, 0, len(m.ImageURLs)+len(m.Attachments))
+	for _, imageURL := range m.ImageURLs {
+		all = append(all, NewPhotoAttachment(imageURL))
+	}
+	return append(all, m.Attachments...)
+}
+
 // HasButtons проверяет, есть ли inline-кнопки
 func (m *TelegramMessage) HasButtons() bool {
 	return len(m.InlineButtons) > 0
@@ -60,8 +82,38 @@ func (m *TelegramMessage) GetFirstImage() string {
 	return ""
 }
 
+// IsHTML проверяет, размечен ли текст HTML
+func (m *TelegramMessage) IsHTML() bool {
+	return m.ParseMode == ParseModeHTML
+}
+
+// AsPlainText возвращает копию сообщения без форматирования
+// Используется для повторной отправки, если Telegram не смог разобрать разметку
+func (m *TelegramMessage) AsPlainText() *TelegramMessage {
+	plain := *m
+	plain.ParseMode = ParseModePlain
+	if m.IsHTML() {
+		plain.MessageText = tghtml.StripTags(m.MessageText)
+
+		plain.Attachments = make([]Attachment, len(m.Attachments))
+		for i, attachment := range m.Attachments {
+			attachment.Caption = tghtml.StripTags(attachment.Caption)
+			plain.Attachments[i] = attachment
+		}
+	}
+	return &plain
+}
+
 // WithParseMode устанавливает режим парсинга и возвращает сообщение (builder pattern)
 func (m *TelegramMessage) WithParseMode(mode string) *TelegramMessage {
 	m.ParseMode = mode
 	return m
 }
+
+// TelegramRequest запрос к Telegram Bot API, сформированный для отправки сообщения
+// Используется для предпросмотра уведомления без отправки
+type TelegramRequest struct {
+	Method string                 `json:"method"`          // Метод Bot API (sendMessage, sendPhoto, sendMediaGroup, ...)
+	Params map[string]interface{} `json:"params"`          // Параметры запроса; JSON-значения (reply_markup, media) раскрыты
+	Files  map[string]string      `json:"files,omitempty"` // Загружаемые файлы: поле формы -> имя файла
+}
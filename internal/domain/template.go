@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// Template представляет версию шаблона уведомления из реестра
+type Template struct {
+	ID            int64            `db:"id"`
+	Name          string           `db:"name"`
+	Version       int              `db:"version"`
+	Locale        string           `db:"locale"` // Язык варианта шаблона (версии нумеруются отдельно для каждого языка)
+	Type          NotificationType `db:"notification_type"`
+	ParseMode     string           `db:"parse_mode"`    // HTML (html/template) или Plain (text/template)
+	Text          string           `db:"text_template"` // Шаблон текста сообщения
+	InlineButtons InlineButtons    `db:"inline_buttons"`
+	Description   *string          `db:"description"`
+	CreatedAt     time.Time        `db:"created_at"`
+	DeletedAt     *time.Time       `db:"deleted_at"`
+}
+
+// IsHTML проверяет, рендерится ли шаблон с HTML-экранированием переменных
+func (t *Template) IsHTML() bool {
+	return t.ParseMode == ParseModeHTML
+}
+
+// IsDeleted проверяет, удалён ли шаблон
+func (t *Template) IsDeleted() bool {
+	return t.DeletedAt != nil
+}
+
+// RenderedMessage результат рендеринга шаблона
+type RenderedMessage struct {
+	Text          string
+	InlineButtons []InlineButton
+	ParseMode     string
+}
@@ -0,0 +1,14 @@
+package localfs
+
+import "errors"
+
+var (
+	// ErrBlobNotFound возвращается, когда объекта нет в хранилище
+	ErrBlobNotFound = errors.New("blobstore: blob not found")
+
+	// ErrInvalidKey возвращается для ключа, который может выйти за пределы каталога хранилища
+	ErrInvalidKey = errors.New("blobstore: invalid key")
+
+	// ErrWrite возвращается при ошибке записи объекта
+	ErrWrite = errors.New("blobstore: failed to write blob")
+)
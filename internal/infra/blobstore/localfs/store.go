@@ -0,0 +1,95 @@
+package localfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Store blob-хранилище в каталоге локальной файловой системы
+// Каждый объект - отдельный файл с именем, равным ключу
+type Store struct {
+	dir string
+}
+
+// NewStore создает хранилище в каталоге dir (каталог создаётся, если его нет)
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("blobstore: create directory %s: %w", dir, err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Put записывает объект и возвращает количество записанных байт
+// Объект сначала пишется во временный файл, чтобы при ошибке не остался обрезанный файл
+func (s *Store) Put(ctx context.Context, key string, content io.Reader) (int64, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrWrite, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrWrite, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrWrite, err)
+	}
+
+	return written, nil
+}
+
+// Open открывает объект на чтение
+func (s *Store) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("blobstore: open %s: %w", key, err)
+	}
+
+	return file, nil
+}
+
+// Delete удаляет объект; отсутствие объекта ошибкой не считается
+func (s *Store) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("blobstore: delete %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// path возвращает путь к файлу объекта
+func (s *Store) path(key string) (string, error) {
+	if key == "" || strings.ContainsAny(key, `/\`) || strings.HasPrefix(key, ".") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return filepath.Join(s.dir, key), nil
+}
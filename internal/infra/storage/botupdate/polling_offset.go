@@ -0,0 +1,59 @@
+package botupdate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/m04kA/SMC-NotificationService/pkg/dbmetrics"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+// GetPollingOffset получает сохранённое смещение long polling бота
+// Возвращает 0, если бот ещё не получал обновления через long polling
+func (r *Repository) GetPollingOffset(ctx context.Context, botID int64) (int64, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Select("update_offset").
+		From("bot_polling_offsets").
+		Where(squirrel.Eq{"bot_id": botID}).
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("%w: GetPollingOffset - build select query: %v", ErrBuildQuery, err)
+	}
+
+	var offset int64
+	err = executor.QueryRowContext(ctx, query, args...).Scan(&offset)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%w: GetPollingOffset - scan offset: %v", ErrScanRow, err)
+	}
+
+	return offset, nil
+}
+
+// SavePollingOffset сохраняет смещение long polling бота
+// Смещение только растёт: запоздавшее сохранение не откатывает его назад
+func (r *Repository) SavePollingOffset(ctx context.Context, botID int64, offset int64) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Insert("bot_polling_offsets").
+		Columns("bot_id", "update_offset").
+		Values(botID, offset).
+		Suffix("ON CONFLICT (bot_id) DO UPDATE SET update_offset = GREATEST(bot_polling_offsets.update_offset, EXCLUDED.update_offset)").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: SavePollingOffset - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := executor.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: SavePollingOffset - execute insert: %v", ErrExecQuery, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,277 @@
+package botupdate
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/dbmetrics"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+// updateColumns список колонок для выборки обновлений
+// Порядок должен совпадать с порядком полей в scanUpdate
+var updateColumns = []string{
+	"update_id",
+	"payload",
+	"status",
+	"attempts",
+	"next_attempt_at",
+	"last_error",
+	"processed_at",
+	"created_at",
+}
+
+// rowScanner общий интерфейс для *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// Repository репозиторий очереди обновлений Telegram
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория обновлений
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// Enqueue сохраняет обновление для обработки
+// Возвращает false, если обновление с таким update_id сохранено после dedupSince (повторная доставка)
+// Запись старше dedupSince относится к прежней последовательности update_id (Telegram начинает её заново
+// после недели без обновлений) и заменяется новым обновлением
+func (r *Repository) Enqueue(ctx context.Context, updateID int64, payload json.RawMessage, at, dedupSince time.Time) (bool, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Insert("bot_updates").
+		Columns("update_id", "payload", "status", "next_attempt_at", "created_at").
+		Values(updateID, []byte(payload), domain.BotUpdateStatusPending, at.UTC(), at.UTC()).
+		Suffix(`ON CONFLICT (update_id) DO UPDATE SET
+			payload = EXCLUDED.payload,
+			status = EXCLUDED.status,
+			attempts = 0,
+			next_attempt_at = EXCLUDED.next_attempt_at,
+			last_error = NULL,
+			processed_at = NULL,
+			completed_steps = '{}',
+			created_at = EXCLUDED.created_at
+			WHERE bot_updates.created_at < ?`, dedupSince.UTC()).
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("%w: Enqueue - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := executor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("%w: Enqueue - execute insert: %v", ErrExecQuery, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%w: Enqueue - get rows affected: %v", ErrExecQuery, err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// IsStepDone проверяет, выполнен ли шаг обработки обновления прошлой попыткой
+func (r *Repository) IsStepDone(ctx context.Context, updateID int64, step domain.BotUpdateStep) (bool, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Select("1").
+		From("bot_updates").
+		Where(squirrel.Eq{"update_id": updateID}).
+		Where(squirrel.Expr("? = ANY(completed_steps)", string(step))).
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("%w: IsStepDone - build select query: %v", ErrBuildQuery, err)
+	}
+
+	var exists int
+	if err := executor.QueryRowContext(ctx, query, args...).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("%w: IsStepDone - execute query: %v", ErrExecQuery, err)
+	}
+
+	return true, nil
+}
+
+// MarkStepDone запоминает выполненный шаг обработки обновления
+// Повторная отметка того же шага ничего не меняет
+func (r *Repository) MarkStepDone(ctx context.Context, updateID int64, step domain.BotUpdateStep) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Update("bot_updates").
+		Set("completed_steps", squirrel.Expr("array_append(completed_steps, ?)", string(step))).
+		Where(squirrel.Eq{"update_id": updateID}).
+		Where(squirrel.Expr("NOT (? = ANY(completed_steps))", string(step))).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: MarkStepDone - build update query: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := executor.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: MarkStepDone - execute update: %v", ErrExecQuery, err)
+	}
+
+	return nil
+}
+
+// DeleteProcessedBefore удаляет обработанные (done и failed) обновления, сохранённые раньше before
+// Возвращает количество удалённых записей
+func (r *Repository) DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Delete("bot_updates").
+		Where(squirrel.NotEq{"status": domain.BotUpdateStatusPending}).
+		Where(squirrel.Lt{"created_at": before.UTC()}).
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("%w: DeleteProcessedBefore - build delete query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := executor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%w: DeleteProcessedBefore - execute delete: %v", ErrExecQuery, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: DeleteProcessedBefore - get rows affected: %v", ErrExecQuery, err)
+	}
+
+	return rowsAffected, nil
+}
+
+// GetDue получает pending обновления, время обработки которых наступило, в порядке update_id
+func (r *Repository) GetDue(ctx context.Context, now time.Time, limit int) ([]*domain.BotUpdate, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Select(updateColumns...).
+		From("bot_updates").
+		Where(squirrel.Eq{"status": domain.BotUpdateStatusPending}).
+		Where(squirrel.LtOrEq{"next_attempt_at": now.UTC()}).
+		OrderBy("update_id ASC").
+		Limit(uint64(limit)).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetDue - build select query: %v", ErrBuildQuery, err)
+	}
+
+	rows, err := executor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetDue - execute query: %v", ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	updates := make([]*domain.BotUpdate, 0)
+	for rows.Next() {
+		update, err := scanUpdate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: GetDue - scan row: %v", ErrScanRow, err)
+		}
+		updates = append(updates, update)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: GetDue - rows error: %v", ErrScanRow, err)
+	}
+
+	return updates, nil
+}
+
+// MarkDone помечает обновление обработанным
+func (r *Repository) MarkDone(ctx context.Context, updateID int64, processedAt time.Time) error {
+	return r.updatePending(ctx, "MarkDone", updateID, map[string]interface{}{
+		"status":       domain.BotUpdateStatusDone,
+		"attempts":     squirrel.Expr("attempts + 1"),
+		"last_error":   nil,
+		"processed_at": processedAt.UTC(),
+	})
+}
+
+// MarkRetry откладывает повторную обработку обновления после ошибки
+func (r *Repository) MarkRetry(ctx context.Context, updateID int64, errorMsg string, nextAttemptAt time.Time) error {
+	return r.updatePending(ctx, "MarkRetry", updateID, map[string]interface{}{
+		"attempts":        squirrel.Expr("attempts + 1"),
+		"last_error":      errorMsg,
+		"next_attempt_at": nextAttemptAt.UTC(),
+	})
+}
+
+// MarkFailed помечает обновление необработанным после исчерпания попыток
+func (r *Repository) MarkFailed(ctx context.Context, updateID int64, errorMsg string) error {
+	return r.updatePending(ctx, "MarkFailed", updateID, map[string]interface{}{
+		"status":     domain.BotUpdateStatusFailed,
+		"attempts":   squirrel.Expr("attempts + 1"),
+		"last_error": errorMsg,
+	})
+}
+
+// updatePending обновляет pending обновление
+// Возвращает ErrUpdateNotFound, если обновление не найдено или уже не pending
+func (r *Repository) updatePending(ctx context.Context, method string, updateID int64, values map[string]interface{}) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Update("bot_updates").
+		SetMap(values).
+		Where(squirrel.Eq{"update_id": updateID, "status": domain.BotUpdateStatusPending}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: %s - build update query: %v", ErrBuildQuery, method, err)
+	}
+
+	result, err := executor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %s - execute update: %v", ErrExecQuery, method, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: %s - get rows affected: %v", ErrExecQuery, method, err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrUpdateNotFound
+	}
+
+	return nil
+}
+
+// scanUpdate сканирует одну строку в доменную модель
+// Порядок полей соответствует updateColumns
+func scanUpdate(row rowScanner) (*domain.BotUpdate, error) {
+	var update domain.BotUpdate
+	var payload []byte
+
+	err := row.Scan(
+		&update.UpdateID,
+		&payload,
+		&update.Status,
+		&update.Attempts,
+		&update.NextAttemptAt,
+		&update.LastError,
+		&update.ProcessedAt,
+		&update.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	update.Payload = payload
+	return &update, nil
+}
@@ -0,0 +1,17 @@
+package botuser
+
+import "errors"
+
+var (
+	// ErrBotUserNotFound возвращается, когда пользователь бота не найден
+	ErrBotUserNotFound = errors.New("repository: bot user not found")
+
+	// ErrBuildQuery возвращается при ошибке построения SQL запроса
+	ErrBuildQuery = errors.New("repository: failed to build SQL query")
+
+	// ErrExecQuery возвращается при ошибке выполнения SQL запроса
+	ErrExecQuery = errors.New("repository: failed to execute SQL query")
+
+	// ErrScanRow возвращается при ошибке сканирования строки результата
+	ErrScanRow = errors.New("repository: failed to scan row")
+)
@@ -0,0 +1,151 @@
+package botuser
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/dbmetrics"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+// botUserColumns список колонок для выборки пользователей бота
+// Порядок должен совпадать с порядком полей в scanBotUser
+var botUserColumns = []string{
+	"tg_user_id",
+	"language_code",
+	"start_kind",
+	"start_value",
+	"start_param",
+	"attributed_at",
+	"created_at",
+	"updated_at",
+}
+
+// rowScanner общий интерфейс для *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// Repository репозиторий для работы с пользователями бота
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория пользователей бота
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// SaveLanguage сохраняет язык пользователя (создаёт запись, если её нет)
+func (r *Repository) SaveLanguage(ctx context.Context, tgUserID int64, languageCode string) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Insert("bot_users").
+		Columns("tg_user_id", "language_code").
+		Values(tgUserID, languageCode).
+		Suffix("ON CONFLICT (tg_user_id) DO UPDATE SET language_code = EXCLUDED.language_code").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: SaveLanguage - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := executor.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: SaveLanguage - execute insert: %v", ErrExecQuery, err)
+	}
+
+	return nil
+}
+
+// SaveAttribution сохраняет первую deep-link ссылку пользователя (создаёт запись, если её нет)
+// Атрибуция только первого касания: если источник уже записан, он не перезаписывается
+// Возвращает true, если атрибуция сохранена этим вызовом
+func (r *Repository) SaveAttribution(ctx context.Context, tgUserID int64, payload *domain.StartPayload, attributedAt time.Time) (bool, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Insert("bot_users").
+		Columns("tg_user_id", "start_kind", "start_value", "start_param", "attributed_at").
+		Values(tgUserID, string(payload.Kind), payload.Value, payload.Param, attributedAt).
+		Suffix("ON CONFLICT (tg_user_id) DO UPDATE SET " +
+			"start_kind = EXCLUDED.start_kind, start_value = EXCLUDED.start_value, " +
+			"start_param = EXCLUDED.start_param, attributed_at = EXCLUDED.attributed_at " +
+			"WHERE bot_users.start_kind IS NULL").
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("%w: SaveAttribution - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := executor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("%w: SaveAttribution - execute insert: %v", ErrExecQuery, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%w: SaveAttribution - get affected rows: %v", ErrExecQuery, err)
+	}
+
+	return affected > 0, nil
+}
+
+// GetByTgUserID получает пользователя бота по Telegram ID
+func (r *Repository) GetByTgUserID(ctx context.Context, tgUserID int64) (*domain.BotUser, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Select(botUserColumns...).
+		From("bot_users").
+		Where(squirrel.Eq{"tg_user_id": tgUserID}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetByTgUserID - build select query: %v", ErrBuildQuery, err)
+	}
+
+	user, err := scanBotUser(executor.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, ErrBotUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetByTgUserID - scan bot user: %v", ErrScanRow, err)
+	}
+
+	return user, nil
+}
+
+// scanBotUser сканирует одну строку в доменную модель
+// Порядок полей соответствует botUserColumns
+func scanBotUser(row rowScanner) (*domain.BotUser, error) {
+	var user domain.BotUser
+	var startKind, startValue, startParam sql.NullString
+	var attributedAt sql.NullTime
+
+	err := row.Scan(
+		&user.TgUserID,
+		&user.LanguageCode,
+		&startKind,
+		&startValue,
+		&startParam,
+		&attributedAt,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if startKind.Valid {
+		user.Attribution = &domain.StartAttribution{
+			Kind:         domain.StartKind(startKind.String),
+			Value:        startValue.String,
+			Param:        startParam.String,
+			AttributedAt: attributedAt.Time,
+		}
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,73 @@
+package callbackaudit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/dbmetrics"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+// Repository репозиторий журнала callback-действий
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория журнала callback-действий
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// Create сохраняет запись журнала и заполняет ID и время создания
+func (r *Repository) Create(ctx context.Context, audit *domain.CallbackAudit) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Insert("callback_audit").
+		Columns("callback_query_id", "notification_id", "tg_user_id", "action", "arg", "status", "error_message").
+		Values(audit.CallbackQueryID, audit.NotificationID, audit.TgUserID, audit.Action, audit.Arg, audit.Status, audit.ErrorMessage).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: Create - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	if err := executor.QueryRowContext(ctx, query, args...).Scan(&audit.ID, &audit.CreatedAt); err != nil {
+		return fmt.Errorf("%w: Create - execute insert: %v", ErrExecQuery, err)
+	}
+
+	return nil
+}
+
+// HasSuccess проверяет, выполнялось ли уже действие по уведомлению
+func (r *Repository) HasSuccess(ctx context.Context, notificationID int64, action string) (bool, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Select("1").
+		From("callback_audit").
+		Where(squirrel.Eq{
+			"notification_id": notificationID,
+			"action":          action,
+			"status":          domain.CallbackStatusSuccess,
+		}).
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("%w: HasSuccess - build select query: %v", ErrBuildQuery, err)
+	}
+
+	var exists int
+	err = executor.QueryRowContext(ctx, query, args...).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("%w: HasSuccess - execute query: %v", ErrExecQuery, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,226 @@
+package groupchat
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/dbmetrics"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+// groupChatColumns список колонок для выборки групповых чатов
+// Порядок должен совпадать с порядком полей в scanGroupChat
+var groupChatColumns = []string{
+	"chat_id",
+	"type",
+	"title",
+	"active",
+	"company_id",
+	"added_by_tg_user_id",
+	"linked_by_tg_user_id",
+	"linked_at",
+	"migrated_to_chat_id",
+	"created_at",
+	"updated_at",
+}
+
+// migrateQuery переносит чат на новый ID одним запросом: старая запись становится неактивной,
+// новая получает тип supergroup и привязку к компании старой (если у новой её ещё нет)
+const migrateQuery = `WITH old AS (
+	UPDATE group_chats
+	SET active = FALSE, migrated_to_chat_id = $2
+	WHERE chat_id = $1
+	RETURNING title, company_id, added_by_tg_user_id, linked_by_tg_user_id, linked_at
+)
+INSERT INTO group_chats (chat_id, type, title, active, company_id, added_by_tg_user_id, linked_by_tg_user_id, linked_at)
+SELECT $2, $3, title, TRUE, company_id, added_by_tg_user_id, linked_by_tg_user_id, linked_at FROM old
+ON CONFLICT (chat_id) DO UPDATE SET
+	type = EXCLUDED.type,
+	active = TRUE,
+	company_id = COALESCE(group_chats.company_id, EXCLUDED.company_id),
+	linked_by_tg_user_id = COALESCE(group_chats.linked_by_tg_user_id, EXCLUDED.linked_by_tg_user_id),
+	linked_at = COALESCE(group_chats.linked_at, EXCLUDED.linked_at)`
+
+// rowScanner общий интерфейс для *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// Repository репозиторий групповых чатов
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория групповых чатов
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// Register регистрирует чат, в который добавлен бот, или обновляет его тип и название
+// Привязка к компании сохраняется: бота могли удалить из чата и добавить снова
+func (r *Repository) Register(ctx context.Context, chat *domain.GroupChat) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Insert("group_chats").
+		Columns("chat_id", "type", "title", "active", "added_by_tg_user_id").
+		Values(chat.ChatID, chat.Type, chat.Title, true, chat.AddedByTgUserID).
+		Suffix(`ON CONFLICT (chat_id) DO UPDATE SET
+			type = EXCLUDED.type,
+			title = EXCLUDED.title,
+			active = TRUE,
+			added_by_tg_user_id = COALESCE(EXCLUDED.added_by_tg_user_id, group_chats.added_by_tg_user_id)`).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: Register - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := executor.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: Register - execute insert: %v", ErrExecQuery, err)
+	}
+
+	return nil
+}
+
+// Deactivate помечает чат неактивным: бота удалили из чата
+// Возвращает false, если чат не зарегистрирован или уже неактивен
+func (r *Repository) Deactivate(ctx context.Context, chatID int64) (bool, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Update("group_chats").
+		Set("active", false).
+		Where(squirrel.Eq{"chat_id": chatID, "active": true}).
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("%w: Deactivate - build update query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := executor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("%w: Deactivate - execute update: %v", ErrExecQuery, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%w: Deactivate - get rows affected: %v", ErrExecQuery, err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Link привязывает чат к компании
+// Чат регистрируется, если бот был добавлен в него до появления реестра
+func (r *Repository) Link(ctx context.Context, chat *domain.GroupChat, companyID int64, linkedBy int64, at time.Time) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Insert("group_chats").
+		Columns("chat_id", "type", "title", "active", "company_id", "linked_by_tg_user_id", "linked_at").
+		Values(chat.ChatID, chat.Type, chat.Title, true, companyID, linkedBy, at).
+		Suffix(`ON CONFLICT (chat_id) DO UPDATE SET
+			type = EXCLUDED.type,
+			title = EXCLUDED.title,
+			active = TRUE,
+			company_id = EXCLUDED.company_id,
+			linked_by_tg_user_id = EXCLUDED.linked_by_tg_user_id,
+			linked_at = EXCLUDED.linked_at`).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: Link - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := executor.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: Link - execute insert: %v", ErrExecQuery, err)
+	}
+
+	return nil
+}
+
+// Migrate переносит чат на новый ID после преобразования группы в супергруппу
+// Возвращает ErrGroupChatNotFound, если старый чат не зарегистрирован
+func (r *Repository) Migrate(ctx context.Context, fromChatID, toChatID int64) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	result, err := executor.ExecContext(ctx, migrateQuery, fromChatID, toChatID, domain.GroupChatTypeSupergroup)
+	if err != nil {
+		return fmt.Errorf("%w: Migrate - execute query: %v", ErrExecQuery, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: Migrate - get rows affected: %v", ErrExecQuery, err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrGroupChatNotFound
+	}
+
+	return nil
+}
+
+// ListByCompany получает активные чаты компании, начиная с недавно привязанных
+func (r *Repository) ListByCompany(ctx context.Context, companyID int64, limit, offset int) ([]*domain.GroupChat, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Select(groupChatColumns...).
+		From("group_chats").
+		Where(squirrel.Eq{"company_id": companyID, "active": true}).
+		OrderBy("linked_at DESC", "chat_id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListByCompany - build select query: %v", ErrBuildQuery, err)
+	}
+
+	rows, err := executor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListByCompany - execute query: %v", ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	chats := make([]*domain.GroupChat, 0)
+	for rows.Next() {
+		chat, err := scanGroupChat(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: ListByCompany - scan row: %v", ErrScanRow, err)
+		}
+		chats = append(chats, chat)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: ListByCompany - rows error: %v", ErrScanRow, err)
+	}
+
+	return chats, nil
+}
+
+// scanGroupChat сканирует одну строку в доменную модель
+// Порядок полей соответствует groupChatColumns
+func scanGroupChat(row rowScanner) (*domain.GroupChat, error) {
+	var chat domain.GroupChat
+
+	err := row.Scan(
+		&chat.ChatID,
+		&chat.Type,
+		&chat.Title,
+		&chat.Active,
+		&chat.CompanyID,
+		&chat.AddedByTgUserID,
+		&chat.LinkedByTgUserID,
+		&chat.LinkedAt,
+		&chat.MigratedToChatID,
+		&chat.CreatedAt,
+		&chat.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chat, nil
+}
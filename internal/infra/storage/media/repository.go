@@ -0,0 +1,86 @@
+package media
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/dbmetrics"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+// mediaColumns список колонок для выборки файлов медиатеки
+// Порядок должен совпадать с порядком полей в GetByID
+var mediaColumns = []string{
+	"id",
+	"media_type",
+	"filename",
+	"content_type",
+	"size_bytes",
+	"created_at",
+}
+
+// Repository репозиторий медиатеки
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория медиатеки
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// Create сохраняет описание загруженного файла и заполняет CreatedAt
+func (r *Repository) Create(ctx context.Context, media *domain.Media) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Insert("media").
+		Columns("id", "media_type", "filename", "content_type", "size_bytes").
+		Values(media.ID, media.Type, media.Filename, media.ContentType, media.Size).
+		Suffix("RETURNING created_at").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: Create - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	if err := executor.QueryRowContext(ctx, query, args...).Scan(&media.CreatedAt); err != nil {
+		return fmt.Errorf("%w: Create - execute insert: %v", ErrExecQuery, err)
+	}
+
+	return nil
+}
+
+// GetByID получает файл медиатеки по ID
+func (r *Repository) GetByID(ctx context.Context, id string) (*domain.Media, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Select(mediaColumns...).
+		From("media").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetByID - build select query: %v", ErrBuildQuery, err)
+	}
+
+	var media domain.Media
+	err = executor.QueryRowContext(ctx, query, args...).Scan(
+		&media.ID,
+		&media.Type,
+		&media.Filename,
+		&media.ContentType,
+		&media.Size,
+		&media.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrMediaNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetByID - scan media: %v", ErrScanRow, err)
+	}
+
+	return &media, nil
+}
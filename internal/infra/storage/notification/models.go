@@ -6,6 +6,7 @@ import "github.com/m04kA/SMC-NotificationService/internal/domain"
 type ListFilter struct {
 	Status         *domain.NotificationStatus
 	Type           *domain.NotificationType
+	SkipReason     *domain.SkipReason
 	TelegramUserID *int64
 	SpanID         *string
 	Limit          int
@@ -12,6 +12,66 @@ import (
 	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
 )
 
+// notificationColumns список колонок для выборки уведомлений
+// Порядок должен совпадать с порядком полей в scanNotification
+var notificationColumns = []string{
+	"id",
+	"telegram_user_id",
+	"chat_id",
+	"span_id",
+	"message_text",
+	"parse_mode",
+	"template_name",
+	"template_version",
+	"template_variables",
+	"locale",
+	"image_urls",
+	"attachments",
+	"inline_buttons",
+	"delivery_options",
+	"notification_type",
+	"status",
+	"scheduled_for",
+	"sent_at",
+	"sent_messages",
+	"messages_edited_at",
+	"messages_deleted_at",
+	"metadata",
+	"error_message",
+	"skip_reason",
+	"deferred_until",
+	"retry_count",
+	"created_at",
+	"updated_at",
+}
+
+// insertColumns список колонок для создания уведомлений
+var insertColumns = []string{
+	"telegram_user_id",
+	"chat_id",
+	"span_id",
+	"message_text",
+	"parse_mode",
+	"template_name",
+	"template_version",
+	"template_variables",
+	"locale",
+	"image_urls",
+	"attachments",
+	"inline_buttons",
+	"delivery_options",
+	"notification_type",
+	"status",
+	"scheduled_for",
+	"metadata",
+	"skip_reason",
+}
+
+// rowScanner общий интерфейс для *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
 // Repository репозиторий для работы с уведомлениями
 type Repository struct {
 	db DBExecutor
@@ -27,30 +87,8 @@ func (r *Repository) Create(ctx context.Context, notification *domain.Notificati
 	executor := dbmetrics.GetExecutor(ctx, r.db)
 
 	query, args, err := psqlbuilder.Insert("notifications").
-		Columns(
-			"telegram_user_id",
-			"chat_id",
-			"span_id",
-			"message_text",
-			"image_urls",
-			"inline_buttons",
-			"notification_type",
-			"status",
-			"scheduled_for",
-			"metadata",
-		).
-		Values(
-			notification.TelegramUserID,
-			notification.ChatID,
-			notification.SpanID,
-			notification.MessageText,
-			pq.Array(notification.ImageURLs),
-			notification.InlineButtons,
-			notification.Type,
-			notification.Status,
-			notification.ScheduledFor,
-			notification.Metadata,
-		).
+		Columns(insertColumns...).
+		Values(insertValues(notification)...).
 		Suffix("RETURNING id, created_at, updated_at").
 		ToSql()
 
@@ -83,33 +121,11 @@ func (r *Repository) CreateBatch(ctx context.Context, notifications []*domain.No
 	executor := dbmetrics.GetExecutor(ctx, r.db)
 
 	builder := psqlbuilder.Insert("notifications").
-		Columns(
-			"telegram_user_id",
-			"chat_id",
-			"span_id",
-			"message_text",
-			"image_urls",
-			"inline_buttons",
-			"notification_type",
-			"status",
-			"scheduled_for",
-			"metadata",
-		)
+		Columns(insertColumns...)
 
 	// Добавляем все уведомления в один batch запрос
 	for _, n := range notifications {
-		builder = builder.Values(
-			n.TelegramUserID,
-			n.ChatID,
-			n.SpanID,
-			n.MessageText,
-			pq.Array(n.ImageURLs),
-			n.InlineButtons,
-			n.Type,
-			n.Status,
-			n.ScheduledFor,
-			n.Metadata,
-		)
+		builder = builder.Values(insertValues(n)...)
 	}
 
 	query, args, err := builder.Suffix("RETURNING id").ToSql()
@@ -143,24 +159,7 @@ func (r *Repository) CreateBatch(ctx context.Context, notifications []*domain.No
 func (r *Repository) GetByID(ctx context.Context, id int64) (*domain.Notification, error) {
 	executor := dbmetrics.GetExecutor(ctx, r.db)
 
-	query, args, err := psqlbuilder.Select(
-		"id",
-		"telegram_user_id",
-		"chat_id",
-		"span_id",
-		"message_text",
-		"image_urls",
-		"inline_buttons",
-		"notification_type",
-		"status",
-		"scheduled_for",
-		"sent_at",
-		"metadata",
-		"error_message",
-		"retry_count",
-		"created_at",
-		"updated_at",
-	).
+	query, args, err := psqlbuilder.Select(notificationColumns...).
 		From("notifications").
 		Where(squirrel.Eq{"id": id}).
 		ToSql()
@@ -169,28 +168,7 @@ func (r *Repository) GetByID(ctx context.Context, id int64) (*domain.Notificatio
 		return nil, fmt.Errorf("%w: GetByID - build select query: %v", ErrBuildQuery, err)
 	}
 
-	var notification domain.Notification
-	var createdAt, updatedAt sql.NullTime
-
-	err = executor.QueryRowContext(ctx, query, args...).Scan(
-		&notification.ID,
-		&notification.TelegramUserID,
-		&notification.ChatID,
-		&notification.SpanID,
-		&notification.MessageText,
-		pq.Array(&notification.ImageURLs),
-		&notification.InlineButtons,
-		&notification.Type,
-		&notification.Status,
-		&notification.ScheduledFor,
-		&notification.SentAt,
-		&notification.Metadata,
-		&notification.ErrorMessage,
-		&notification.RetryCount,
-		&createdAt,
-		&updatedAt,
-	)
-
+	notification, err := scanNotification(executor.QueryRowContext(ctx, query, args...))
 	if err == sql.ErrNoRows {
 		return nil, ErrNotificationNotFound
 	}
@@ -198,34 +176,14 @@ func (r *Repository) GetByID(ctx context.Context, id int64) (*domain.Notificatio
 		return nil, fmt.Errorf("%w: GetByID - scan notification: %v", ErrScanRow, err)
 	}
 
-	notification.CreatedAt = createdAt.Time
-	notification.UpdatedAt = updatedAt.Time
-
-	return &notification, nil
+	return notification, nil
 }
 
 // GetBySpanID получает все уведомления по span_id (массовая рассылка)
 func (r *Repository) GetBySpanID(ctx context.Context, spanID string) ([]*domain.Notification, error) {
 	executor := dbmetrics.GetExecutor(ctx, r.db)
 
-	query, args, err := psqlbuilder.Select(
-		"id",
-		"telegram_user_id",
-		"chat_id",
-		"span_id",
-		"message_text",
-		"image_urls",
-		"inline_buttons",
-		"notification_type",
-		"status",
-		"scheduled_for",
-		"sent_at",
-		"metadata",
-		"error_message",
-		"retry_count",
-		"created_at",
-		"updated_at",
-	).
+	query, args, err := psqlbuilder.Select(notificationColumns...).
 		From("notifications").
 		Where(squirrel.Eq{"span_id": spanID}).
 		OrderBy("created_at ASC").
@@ -248,24 +206,7 @@ func (r *Repository) GetBySpanID(ctx context.Context, spanID string) ([]*domain.
 func (r *Repository) List(ctx context.Context, filter ListFilter) ([]*domain.Notification, error) {
 	executor := dbmetrics.GetExecutor(ctx, r.db)
 
-	selectBuilder := psqlbuilder.Select(
-		"id",
-		"telegram_user_id",
-		"chat_id",
-		"span_id",
-		"message_text",
-		"image_urls",
-		"inline_buttons",
-		"notification_type",
-		"status",
-		"scheduled_for",
-		"sent_at",
-		"metadata",
-		"error_message",
-		"retry_count",
-		"created_at",
-		"updated_at",
-	).
+	selectBuilder := psqlbuilder.Select(notificationColumns...).
 		From("notifications").
 		OrderBy("created_at DESC")
 
@@ -279,6 +220,11 @@ func (r *Repository) List(ctx context.Context, filter ListFilter) ([]*domain.Not
 		selectBuilder = selectBuilder.Where(squirrel.Eq{"notification_type": *filter.Type})
 	}
 
+	// Фильтрация по причине пропуска
+	if filter.SkipReason != nil {
+		selectBuilder = selectBuilder.Where(squirrel.Eq{"skip_reason": *filter.SkipReason})
+	}
+
 	// Фильтрация по telegram_user_id
 	if filter.TelegramUserID != nil {
 		selectBuilder = selectBuilder.Where(squirrel.Eq{"telegram_user_id": *filter.TelegramUserID})
@@ -316,36 +262,12 @@ func (r *Repository) scanNotifications(rows *sql.Rows) ([]*domain.Notification,
 	notifications := make([]*domain.Notification, 0)
 
 	for rows.Next() {
-		var notification domain.Notification
-		var createdAt, updatedAt sql.NullTime
-
-		err := rows.Scan(
-			&notification.ID,
-			&notification.TelegramUserID,
-			&notification.ChatID,
-			&notification.SpanID,
-			&notification.MessageText,
-			pq.Array(&notification.ImageURLs),
-			&notification.InlineButtons,
-			&notification.Type,
-			&notification.Status,
-			&notification.ScheduledFor,
-			&notification.SentAt,
-			&notification.Metadata,
-			&notification.ErrorMessage,
-			&notification.RetryCount,
-			&createdAt,
-			&updatedAt,
-		)
-
+		notification, err := scanNotification(rows)
 		if err != nil {
 			return nil, fmt.Errorf("%w: scanNotifications - scan row: %v", ErrScanRow, err)
 		}
 
-		notification.CreatedAt = createdAt.Time
-		notification.UpdatedAt = updatedAt.Time
-
-		notifications = append(notifications, &notification)
+		notifications = append(notifications, notification)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -354,3 +276,81 @@ func (r *Repository) scanNotifications(rows *sql.Rows) ([]*domain.Notification,
 
 	return notifications, nil
 }
+
+// scanNotification сканирует одну строку в доменную модель
+// Порядок полей соответствует notificationColumns
+func scanNotification(row rowScanner) (*domain.Notification, error) {
+	var notification domain.Notification
+	var createdAt, updatedAt sql.NullTime
+
+	err := row.Scan(
+		&notification.ID,
+		&notification.TelegramUserID,
+		&notification.ChatID,
+		&notification.SpanID,
+		&notification.MessageText,
+		&notification.ParseMode,
+		&notification.TemplateName,
+		&notification.TemplateVersion,
+		&notification.TemplateVariables,
+		&notification.Locale,
+		pq.Array(&notification.ImageURLs),
+		&notification.Attachments,
+		&notification.InlineButtons,
+		&notification.DeliveryOptions,
+		&notification.Type,
+		&notification.Status,
+		&notification.ScheduledFor,
+		&notification.SentAt,
+		&notification.SentMessages,
+		&notification.MessagesEditedAt,
+		&notification.MessagesDeletedAt,
+		&notification.Metadata,
+		&notification.ErrorMessage,
+		&notification.SkipReason,
+		&notification.DeferredUntil,
+		&notification.RetryCount,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	notification.CreatedAt = createdAt.Time
+	notification.UpdatedAt = updatedAt.Time
+
+	return &notification, nil
+}
+
+// insertValues возвращает значения для вставки в порядке insertColumns
+func insertValues(n *domain.Notification) []interface{} {
+	return []interface{}{
+		n.TelegramUserID,
+		n.ChatID,
+		n.SpanID,
+		n.MessageText,
+		n.ParseMode,
+		n.TemplateName,
+		n.TemplateVersion,
+		nullableJSON(n.TemplateVariables),
+		n.Locale,
+		pq.Array(n.ImageURLs),
+		n.Attachments,
+		n.InlineButtons,
+		n.DeliveryOptions,
+		n.Type,
+		n.Status,
+		n.ScheduledFor,
+		n.Metadata,
+		n.SkipReason,
+	}
+}
+
+// nullableJSON возвращает NULL для пустых JSONB значений вместо '{}'
+func nullableJSON(m domain.Metadata) interface{} {
+	if m == nil {
+		return nil
+	}
+	return m
+}
@@ -0,0 +1,79 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/dbmetrics"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+// MarkMessagesEdited сохраняет сообщения уведомления после редактирования
+// Набор сообщений может измениться: лишние части длинного текста удаляются при сокращении
+func (r *Repository) MarkMessagesEdited(ctx context.Context, id int64, messages domain.SentMessages, editedAt time.Time) error {
+	query, args, err := psqlbuilder.Update("notifications").
+		Set("sent_messages", messages).
+		Set("messages_edited_at", editedAt).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: MarkMessagesEdited - build update query: %v", ErrBuildQuery, err)
+	}
+
+	return r.execSentMessagesUpdate(ctx, "MarkMessagesEdited", query, args)
+}
+
+// SaveSentMessages сохраняет сообщения, доставленные до ошибки отправки
+// Статус не меняется: повтор текстового уведомления (MarkForRetry) продолжит с первой недоставленной части,
+// а доставленные сообщения можно изменить или удалить
+func (r *Repository) SaveSentMessages(ctx context.Context, id int64, messages domain.SentMessages) error {
+	query, args, err := psqlbuilder.Update("notifications").
+		Set("sent_messages", messages).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: SaveSentMessages - build update query: %v", ErrBuildQuery, err)
+	}
+
+	return r.execSentMessagesUpdate(ctx, "SaveSentMessages", query, args)
+}
+
+// MarkMessagesDeleted помечает сообщения уведомления удалёнными из чата
+func (r *Repository) MarkMessagesDeleted(ctx context.Context, id int64, deletedAt time.Time) error {
+	query, args, err := psqlbuilder.Update("notifications").
+		Set("messages_deleted_at", deletedAt).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: MarkMessagesDeleted - build update query: %v", ErrBuildQuery, err)
+	}
+
+	return r.execSentMessagesUpdate(ctx, "MarkMessagesDeleted", query, args)
+}
+
+// execSentMessagesUpdate выполняет запрос обновления одного уведомления
+func (r *Repository) execSentMessagesUpdate(ctx context.Context, method, query string, args []interface{}) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	result, err := executor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %s - execute update: %v", ErrExecQuery, method, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: %s - get rows affected: %v", ErrExecQuery, method, err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotificationNotFound
+	}
+
+	return nil
+}
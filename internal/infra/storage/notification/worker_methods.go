@@ -13,29 +13,17 @@ import (
 
 // GetPendingNotifications получает список pending уведомлений для немедленной отправки
 // Используется processor'ом для обработки очереди
+// Уведомления, отложенные до конца тихих часов, возвращаются только после наступления deferred_until
 func (r *Repository) GetPendingNotifications(ctx context.Context, limit int) ([]*domain.Notification, error) {
 	executor := dbmetrics.GetExecutor(ctx, r.db)
 
-	query, args, err := psqlbuilder.Select(
-		"id",
-		"telegram_user_id",
-		"chat_id",
-		"span_id",
-		"message_text",
-		"image_urls",
-		"inline_buttons",
-		"notification_type",
-		"status",
-		"scheduled_for",
-		"sent_at",
-		"metadata",
-		"error_message",
-		"retry_count",
-		"created_at",
-		"updated_at",
-	).
+	query, args, err := psqlbuilder.Select(notificationColumns...).
 		From("notifications").
 		Where(squirrel.Eq{"status": domain.NotificationStatusPending}).
+		Where(squirrel.Or{
+			squirrel.Eq{"deferred_until": nil},
+			squirrel.LtOrEq{"deferred_until": time.Now().UTC()},
+		}).
 		OrderBy("created_at ASC").
 		Limit(uint64(limit)).
 		ToSql()
@@ -58,24 +46,7 @@ func (r *Repository) GetPendingNotifications(ctx context.Context, limit int) ([]
 func (r *Repository) GetScheduledNotifications(ctx context.Context) ([]*domain.Notification, error) {
 	executor := dbmetrics.GetExecutor(ctx, r.db)
 
-	query, args, err := psqlbuilder.Select(
-		"id",
-		"telegram_user_id",
-		"chat_id",
-		"span_id",
-		"message_text",
-		"image_urls",
-		"inline_buttons",
-		"notification_type",
-		"status",
-		"scheduled_for",
-		"sent_at",
-		"metadata",
-		"error_message",
-		"retry_count",
-		"created_at",
-		"updated_at",
-	).
+	query, args, err := psqlbuilder.Select(notificationColumns...).
 		From("notifications").
 		Where(squirrel.Eq{"status": domain.NotificationStatusScheduled}).
 		OrderBy("scheduled_for ASC").
@@ -124,13 +95,14 @@ func (r *Repository) UpdateStatus(ctx context.Context, id int64, status domain.N
 	return nil
 }
 
-// MarkAsSent помечает уведомление как успешно отправленное
-func (r *Repository) MarkAsSent(ctx context.Context, id int64, sentAt time.Time) error {
+// MarkAsSent помечает уведомление как успешно отправленное и сохраняет ID отправленных сообщений
+func (r *Repository) MarkAsSent(ctx context.Context, id int64, sentAt time.Time, messages domain.SentMessages) error {
 	executor := dbmetrics.GetExecutor(ctx, r.db)
 
 	query, args, err := psqlbuilder.Update("notifications").
 		Set("status", domain.NotificationStatusSent).
 		Set("sent_at", sentAt).
+		Set("sent_messages", messages).
 		Set("error_message", nil).
 		Where(squirrel.Eq{"id": id}).
 		ToSql()
@@ -193,6 +165,109 @@ func (r *Repository) MarkAsFailed(ctx context.Context, id int64, errorMsg string
 	return nil
 }
 
+// MarkForRetry возвращает уведомление в очередь после ошибки отправки
+// Увеличивает счётчик попыток; Processor выберет уведомление не раньше nextAttemptAt
+func (r *Repository) MarkForRetry(ctx context.Context, id int64, errorMsg string, nextAttemptAt time.Time) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Update("notifications").
+		Set("status", domain.NotificationStatusPending).
+		Set("error_message", errorMsg).
+		Set("retry_count", squirrel.Expr("retry_count + 1")).
+		Set("deferred_until", nextAttemptAt.UTC()).
+		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Eq{"status": domain.NotificationStatusPending}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: MarkForRetry - build update query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := executor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: MarkForRetry - execute update: %v", ErrExecQuery, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: MarkForRetry - get rows affected: %v", ErrExecQuery, err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotificationNotFound
+	}
+
+	return nil
+}
+
+// MarkAsSkipped помечает уведомление как пропущенное по настройкам получателя
+// Может быть пропущено только pending или scheduled уведомление
+func (r *Repository) MarkAsSkipped(ctx context.Context, id int64, reason domain.SkipReason) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Update("notifications").
+		Set("status", domain.NotificationStatusSkipped).
+		Set("skip_reason", reason).
+		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Or{
+			squirrel.Eq{"status": domain.NotificationStatusPending},
+			squirrel.Eq{"status": domain.NotificationStatusScheduled},
+		}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: MarkAsSkipped - build update query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := executor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: MarkAsSkipped - execute update: %v", ErrExecQuery, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: MarkAsSkipped - get rows affected: %v", ErrExecQuery, err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotificationNotFound
+	}
+
+	return nil
+}
+
+// Defer откладывает pending уведомление до указанного времени (конец тихих часов получателя)
+// Processor не выбирает уведомление до наступления deferred_until
+func (r *Repository) Defer(ctx context.Context, id int64, until time.Time) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Update("notifications").
+		Set("deferred_until", until.UTC()).
+		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Eq{"status": domain.NotificationStatusPending}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: Defer - build update query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := executor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: Defer - execute update: %v", ErrExecQuery, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: Defer - get rows affected: %v", ErrExecQuery, err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotificationNotFound
+	}
+
+	return nil
+}
+
 // Cancel отменяет отложенное уведомление по ID
 // Может быть отменено только pending или scheduled уведомление
 func (r *Repository) Cancel(ctx context.Context, id int64) error {
@@ -0,0 +1,144 @@
+package preferences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/dbmetrics"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+	"github.com/m04kA/SMC-NotificationService/pkg/types"
+)
+
+// preferencesColumns список колонок для выборки настроек
+// Порядок должен совпадать с порядком полей в scanPreferences
+var preferencesColumns = []string{
+	"tg_user_id",
+	"disabled_types",
+	"disabled_channels",
+	"time_zone",
+	"quiet_hours_start",
+	"quiet_hours_end",
+	"updated_at",
+}
+
+// Repository репозиторий настроек уведомлений пользователей
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория настроек уведомлений
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// GetByTgUserID получает настройки пользователя по Telegram ID
+func (r *Repository) GetByTgUserID(ctx context.Context, tgUserID int64) (*domain.UserPreferences, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Select(preferencesColumns...).
+		From("notification_preferences").
+		Where(squirrel.Eq{"tg_user_id": tgUserID}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetByTgUserID - build select query: %v", ErrBuildQuery, err)
+	}
+
+	preferences, err := scanPreferences(executor.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, ErrPreferencesNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetByTgUserID - scan preferences: %v", ErrScanRow, err)
+	}
+
+	return preferences, nil
+}
+
+// Save сохраняет настройки пользователя (создаёт запись, если её нет)
+func (r *Repository) Save(ctx context.Context, preferences *domain.UserPreferences) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	var quietHoursStart, quietHoursEnd types.TimeString
+	if preferences.QuietHours != nil {
+		quietHoursStart = preferences.QuietHours.Start
+		quietHoursEnd = preferences.QuietHours.End
+	}
+
+	query, args, err := psqlbuilder.Insert("notification_preferences").
+		Columns("tg_user_id", "disabled_types", "disabled_channels", "time_zone", "quiet_hours_start", "quiet_hours_end").
+		Values(
+			preferences.TgUserID,
+			pq.Array(toStrings(preferences.DisabledTypes)),
+			pq.Array(toStrings(preferences.DisabledChannels)),
+			sql.NullString{String: preferences.TimeZone, Valid: preferences.TimeZone != ""},
+			quietHoursStart,
+			quietHoursEnd,
+		).
+		Suffix(`ON CONFLICT (tg_user_id) DO UPDATE SET
+			disabled_types = EXCLUDED.disabled_types,
+			disabled_channels = EXCLUDED.disabled_channels,
+			time_zone = EXCLUDED.time_zone,
+			quiet_hours_start = EXCLUDED.quiet_hours_start,
+			quiet_hours_end = EXCLUDED.quiet_hours_end`).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: Save - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := executor.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: Save - execute insert: %v", ErrExecQuery, err)
+	}
+
+	return nil
+}
+
+// scanPreferences сканирует одну строку в доменную модель
+// Порядок полей соответствует preferencesColumns
+func scanPreferences(row *sql.Row) (*domain.UserPreferences, error) {
+	var preferences domain.UserPreferences
+	var disabledTypes, disabledChannels pq.StringArray
+	var timeZone sql.NullString
+	var quietHoursStart, quietHoursEnd types.TimeString
+
+	err := row.Scan(
+		&preferences.TgUserID,
+		&disabledTypes,
+		&disabledChannels,
+		&timeZone,
+		&quietHoursStart,
+		&quietHoursEnd,
+		&preferences.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range disabledTypes {
+		preferences.DisabledTypes = append(preferences.DisabledTypes, domain.NotificationType(t))
+	}
+	for _, c := range disabledChannels {
+		preferences.DisabledChannels = append(preferences.DisabledChannels, domain.NotificationChannel(c))
+	}
+
+	preferences.TimeZone = timeZone.String
+	if !quietHoursStart.IsZero() && !quietHoursEnd.IsZero() {
+		preferences.QuietHours = &domain.QuietHours{Start: quietHoursStart, End: quietHoursEnd}
+	}
+
+	return &preferences, nil
+}
+
+// toStrings преобразует значения строковых типов для записи в TEXT[]
+func toStrings[T ~string](values []T) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = string(v)
+	}
+	return result
+}
@@ -0,0 +1,17 @@
+package reachability
+
+import "errors"
+
+var (
+	// ErrReachabilityNotFound возвращается, когда о доступности чата нет сведений (чат считается доступным)
+	ErrReachabilityNotFound = errors.New("repository: chat reachability not found")
+
+	// ErrBuildQuery возвращается при ошибке построения SQL запроса
+	ErrBuildQuery = errors.New("repository: failed to build SQL query")
+
+	// ErrExecQuery возвращается при ошибке выполнения SQL запроса
+	ErrExecQuery = errors.New("repository: failed to execute SQL query")
+
+	// ErrScanRow возвращается при ошибке сканирования строки результата
+	ErrScanRow = errors.New("repository: failed to scan row")
+)
@@ -0,0 +1,188 @@
+package reachability
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/dbmetrics"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+// reachabilityColumns список колонок для выборки доступности чатов
+// Порядок должен совпадать с порядком полей в scanReachability
+var reachabilityColumns = []string{
+	"chat_id",
+	"reachable",
+	"reason",
+	"source",
+	"error_message",
+	"unreachable_since",
+	"restored_at",
+	"updated_at",
+}
+
+// rowScanner общий интерфейс для *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// Repository репозиторий доступности чатов
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория доступности чатов
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// GetByChatID получает сведения о доступности чата
+// Возвращает ErrReachabilityNotFound, если чат ни разу не был недоступен
+func (r *Repository) GetByChatID(ctx context.Context, chatID int64) (*domain.ChatReachability, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Select(reachabilityColumns...).
+		From("chat_reachability").
+		Where(squirrel.Eq{"chat_id": chatID}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetByChatID - build select query: %v", ErrBuildQuery, err)
+	}
+
+	reachability, err := scanReachability(executor.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, ErrReachabilityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetByChatID - scan reachability: %v", ErrScanRow, err)
+	}
+
+	return reachability, nil
+}
+
+// MarkUnreachable помечает чат недоступным
+// Время начала недоступности сохраняется при повторных ошибках, причина и источник обновляются
+func (r *Repository) MarkUnreachable(ctx context.Context, chatID int64, reason domain.UnreachableReason, source domain.ReachabilitySource, errorMessage *string, at time.Time) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Insert("chat_reachability").
+		Columns("chat_id", "reachable", "reason", "source", "error_message", "unreachable_since").
+		Values(chatID, false, reason, source, errorMessage, at).
+		Suffix(`ON CONFLICT (chat_id) DO UPDATE SET
+			reachable = FALSE,
+			reason = EXCLUDED.reason,
+			source = EXCLUDED.source,
+			error_message = EXCLUDED.error_message,
+			unreachable_since = CASE WHEN chat_reachability.reachable
+				THEN EXCLUDED.unreachable_since
+				ELSE chat_reachability.unreachable_since
+			END`).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: MarkUnreachable - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := executor.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: MarkUnreachable - execute insert: %v", ErrExecQuery, err)
+	}
+
+	return nil
+}
+
+// MarkReachable восстанавливает доступность недоступного чата
+// Возвращает true, если чат был недоступен; для неизвестных и доступных чатов ничего не меняет
+func (r *Repository) MarkReachable(ctx context.Context, chatID int64, source domain.ReachabilitySource, at time.Time) (bool, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Update("chat_reachability").
+		Set("reachable", true).
+		Set("reason", nil).
+		Set("source", source).
+		Set("error_message", nil).
+		Set("unreachable_since", nil).
+		Set("restored_at", at).
+		Where(squirrel.Eq{"chat_id": chatID, "reachable": false}).
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("%w: MarkReachable - build update query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := executor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("%w: MarkReachable - execute update: %v", ErrExecQuery, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%w: MarkReachable - get rows affected: %v", ErrExecQuery, err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ListUnreachable получает недоступные чаты, начиная с недавно ставших недоступными
+func (r *Repository) ListUnreachable(ctx context.Context, limit, offset int) ([]*domain.ChatReachability, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Select(reachabilityColumns...).
+		From("chat_reachability").
+		Where(squirrel.Eq{"reachable": false}).
+		OrderBy("unreachable_since DESC", "chat_id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListUnreachable - build select query: %v", ErrBuildQuery, err)
+	}
+
+	rows, err := executor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListUnreachable - execute query: %v", ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	chats := make([]*domain.ChatReachability, 0)
+	for rows.Next() {
+		reachability, err := scanReachability(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: ListUnreachable - scan row: %v", ErrScanRow, err)
+		}
+		chats = append(chats, reachability)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: ListUnreachable - rows error: %v", ErrScanRow, err)
+	}
+
+	return chats, nil
+}
+
+// scanReachability сканирует одну строку в доменную модель
+// Порядок полей соответствует reachabilityColumns
+func scanReachability(row rowScanner) (*domain.ChatReachability, error) {
+	var reachability domain.ChatReachability
+
+	err := row.Scan(
+		&reachability.ChatID,
+		&reachability.Reachable,
+		&reachability.Reason,
+		&reachability.Source,
+		&reachability.ErrorMessage,
+		&reachability.UnreachableSince,
+		&reachability.RestoredAt,
+		&reachability.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reachability, nil
+}
@@ -0,0 +1,17 @@
+package support
+
+import "errors"
+
+var (
+	// ErrMessageNotFound возвращается, когда сообщение переписки не найдено
+	ErrMessageNotFound = errors.New("repository: support message not found")
+
+	// ErrBuildQuery возвращается при ошибке построения SQL запроса
+	ErrBuildQuery = errors.New("repository: failed to build SQL query")
+
+	// ErrExecQuery возвращается при ошибке выполнения SQL запроса
+	ErrExecQuery = errors.New("repository: failed to execute SQL query")
+
+	// ErrScanRow возвращается при ошибке сканирования строки результата
+	ErrScanRow = errors.New("repository: failed to scan row")
+)
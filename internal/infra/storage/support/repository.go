@@ -0,0 +1,296 @@
+package support
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/dbmetrics"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+// messageColumns список колонок для выборки сообщений
+// Порядок должен совпадать с порядком полей в scanMessage
+var messageColumns = []string{
+	"id",
+	"tg_user_id",
+	"direction",
+	"text",
+	"user_message_id",
+	"operator_chat_id",
+	"operator_message_id",
+	"operator_tg_user_id",
+	"created_at",
+}
+
+// rowScanner общий интерфейс для *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// Repository репозиторий переписки с поддержкой
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория переписки с поддержкой
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// Create сохраняет сообщение переписки
+// Заполняет ID и CreatedAt
+func (r *Repository) Create(ctx context.Context, message *domain.SupportMessage) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Insert("support_messages").
+		Columns("tg_user_id", "direction", "text", "user_message_id", "operator_chat_id", "operator_message_id", "operator_tg_user_id").
+		Values(
+			message.TgUserID,
+			message.Direction,
+			message.Text,
+			message.UserMessageID,
+			message.OperatorChatID,
+			message.OperatorMessageID,
+			message.OperatorTgUserID,
+		).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: Create - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	if err := executor.QueryRowContext(ctx, query, args...).Scan(&message.ID, &message.CreatedAt); err != nil {
+		return fmt.Errorf("%w: Create - execute insert: %v", ErrExecQuery, err)
+	}
+
+	return nil
+}
+
+// CreateInbound сохраняет сообщение пользователя и заполняет ID и CreatedAt
+// Возвращает false, если сообщение с таким user_message_id уже сохранено (повторная обработка обновления)
+func (r *Repository) CreateInbound(ctx context.Context, message *domain.SupportMessage) (bool, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Insert("support_messages").
+		Columns("tg_user_id", "direction", "text", "user_message_id").
+		Values(message.TgUserID, domain.SupportDirectionInbound, message.Text, message.UserMessageID).
+		Suffix("ON CONFLICT (tg_user_id, user_message_id) WHERE direction = 'inbound' DO NOTHING RETURNING id, created_at").
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("%w: CreateInbound - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	err = executor.QueryRowContext(ctx, query, args...).Scan(&message.ID, &message.CreatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("%w: CreateInbound - execute insert: %v", ErrExecQuery, err)
+	}
+
+	return true, nil
+}
+
+// GetInbound получает сохранённое сообщение пользователя по ID сообщения в его чате
+func (r *Repository) GetInbound(ctx context.Context, tgUserID int64, userMessageID int) (*domain.SupportMessage, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Select(messageColumns...).
+		From("support_messages").
+		Where(squirrel.Eq{
+			"tg_user_id":      tgUserID,
+			"user_message_id": userMessageID,
+			"direction":       domain.SupportDirectionInbound,
+		}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetInbound - build select query: %v", ErrBuildQuery, err)
+	}
+
+	message, err := scanMessage(executor.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, ErrMessageNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetInbound - scan message: %v", ErrScanRow, err)
+	}
+
+	return message, nil
+}
+
+// SetOperatorMessage сохраняет сообщение, которым входящее сообщение переслано в чат операторов
+func (r *Repository) SetOperatorMessage(ctx context.Context, id int64, operatorChatID int64, operatorMessageID int) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Update("support_messages").
+		Set("operator_chat_id", operatorChatID).
+		Set("operator_message_id", operatorMessageID).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: SetOperatorMessage - build update query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := executor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: SetOperatorMessage - execute update: %v", ErrExecQuery, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: SetOperatorMessage - get rows affected: %v", ErrExecQuery, err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrMessageNotFound
+	}
+
+	return nil
+}
+
+// GetByOperatorMessage находит сообщение пользователя, пересланное в чат операторов
+func (r *Repository) GetByOperatorMessage(ctx context.Context, operatorChatID int64, operatorMessageID int) (*domain.SupportMessage, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Select(messageColumns...).
+		From("support_messages").
+		Where(squirrel.Eq{
+			"operator_chat_id":    operatorChatID,
+			"operator_message_id": operatorMessageID,
+			"direction":           domain.SupportDirectionInbound,
+		}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetByOperatorMessage - build select query: %v", ErrBuildQuery, err)
+	}
+
+	message, err := scanMessage(executor.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, ErrMessageNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetByOperatorMessage - scan message: %v", ErrScanRow, err)
+	}
+
+	return message, nil
+}
+
+// ListMessages получает сообщения переписки с пользователем, начиная с последних
+func (r *Repository) ListMessages(ctx context.Context, tgUserID int64, limit, offset int) ([]*domain.SupportMessage, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Select(messageColumns...).
+		From("support_messages").
+		Where(squirrel.Eq{"tg_user_id": tgUserID}).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListMessages - build select query: %v", ErrBuildQuery, err)
+	}
+
+	rows, err := executor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListMessages - execute query: %v", ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	messages := make([]*domain.SupportMessage, 0)
+	for rows.Next() {
+		message, err := scanMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: ListMessages - scan row: %v", ErrScanRow, err)
+		}
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: ListMessages - rows error: %v", ErrScanRow, err)
+	}
+
+	return messages, nil
+}
+
+// ListConversations получает переписки, начиная с недавно обновлённых
+func (r *Repository) ListConversations(ctx context.Context, limit, offset int) ([]*domain.SupportConversation, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Select(
+		"tg_user_id",
+		"COUNT(*)",
+		"(ARRAY_AGG(text ORDER BY created_at DESC, id DESC))[1]",
+		"(ARRAY_AGG(direction ORDER BY created_at DESC, id DESC))[1]",
+		"MAX(created_at) AS last_message_at",
+	).
+		From("support_messages").
+		GroupBy("tg_user_id").
+		OrderBy("last_message_at DESC", "tg_user_id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListConversations - build select query: %v", ErrBuildQuery, err)
+	}
+
+	rows, err := executor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListConversations - execute query: %v", ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	conversations := make([]*domain.SupportConversation, 0)
+	for rows.Next() {
+		var conversation domain.SupportConversation
+		err := rows.Scan(
+			&conversation.TgUserID,
+			&conversation.MessageCount,
+			&conversation.LastText,
+			&conversation.LastDirection,
+			&conversation.LastMessageAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%w: ListConversations - scan row: %v", ErrScanRow, err)
+		}
+		conversations = append(conversations, &conversation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: ListConversations - rows error: %v", ErrScanRow, err)
+	}
+
+	return conversations, nil
+}
+
+// scanMessage сканирует одну строку в доменную модель
+// Порядок полей соответствует messageColumns
+func scanMessage(row rowScanner) (*domain.SupportMessage, error) {
+	var message domain.SupportMessage
+
+	err := row.Scan(
+		&message.ID,
+		&message.TgUserID,
+		&message.Direction,
+		&message.Text,
+		&message.UserMessageID,
+		&message.OperatorChatID,
+		&message.OperatorMessageID,
+		&message.OperatorTgUserID,
+		&message.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &message, nil
+}
@@ -0,0 +1,103 @@
+package telegramfile
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/dbmetrics"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+// telegramFileColumns список колонок для выборки файлов
+// Порядок должен совпадать с порядком полей в GetByKey
+var telegramFileColumns = []string{
+	"cache_key",
+	"file_id",
+	"media_type",
+	"created_at",
+	"updated_at",
+}
+
+// Repository репозиторий кэша file_id
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория кэша file_id
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// GetByKey получает файл по ключу кэша
+func (r *Repository) GetByKey(ctx context.Context, cacheKey string) (*domain.TelegramFile, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Select(telegramFileColumns...).
+		From("telegram_files").
+		Where(squirrel.Eq{"cache_key": cacheKey}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetByKey - build select query: %v", ErrBuildQuery, err)
+	}
+
+	var file domain.TelegramFile
+	err = executor.QueryRowContext(ctx, query, args...).Scan(
+		&file.CacheKey,
+		&file.FileID,
+		&file.MediaType,
+		&file.CreatedAt,
+		&file.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrTelegramFileNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetByKey - scan telegram file: %v", ErrScanRow, err)
+	}
+
+	return &file, nil
+}
+
+// Save сохраняет file_id (перезаписывает значение для существующего ключа)
+func (r *Repository) Save(ctx context.Context, file *domain.TelegramFile) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Insert("telegram_files").
+		Columns("cache_key", "file_id", "media_type").
+		Values(file.CacheKey, file.FileID, file.MediaType).
+		Suffix("ON CONFLICT (cache_key) DO UPDATE SET file_id = EXCLUDED.file_id, media_type = EXCLUDED.media_type").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: Save - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := executor.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: Save - execute insert: %v", ErrExecQuery, err)
+	}
+
+	return nil
+}
+
+// Delete удаляет файл из кэша (например, если Telegram отклонил file_id)
+func (r *Repository) Delete(ctx context.Context, cacheKey string) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Delete("telegram_files").
+		Where(squirrel.Eq{"cache_key": cacheKey}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: Delete - build delete query: %v", ErrBuildQuery, err)
+	}
+
+	if _, err := executor.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: Delete - execute delete: %v", ErrExecQuery, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package template
+
+import "errors"
+
+var (
+	// ErrTemplateNotFound возвращается, когда шаблон не найден
+	ErrTemplateNotFound = errors.New("repository: template not found")
+
+	// ErrTemplateAlreadyExists возвращается при попытке создать шаблон с занятым именем
+	ErrTemplateAlreadyExists = errors.New("repository: template already exists")
+
+	// ErrBuildQuery возвращается при ошибке построения SQL запроса
+	ErrBuildQuery = errors.New("repository: failed to build SQL query")
+
+	// ErrExecQuery возвращается при ошибке выполнения SQL запроса
+	ErrExecQuery = errors.New("repository: failed to execute SQL query")
+
+	// ErrScanRow возвращается при ошибке сканирования строки результата
+	ErrScanRow = errors.New("repository: failed to scan row")
+)
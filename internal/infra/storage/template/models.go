@@ -0,0 +1,11 @@
+package template
+
+import "github.com/m04kA/SMC-NotificationService/internal/domain"
+
+// ListFilter параметры для фильтрации списка шаблонов
+type ListFilter struct {
+	Type   *domain.NotificationType
+	Locale *string
+	Limit  int
+	Offset int
+}
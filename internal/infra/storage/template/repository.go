@@ -0,0 +1,298 @@
+package template
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/dbmetrics"
+	"github.com/m04kA/SMC-NotificationService/pkg/psqlbuilder"
+)
+
+// pgUniqueViolation код ошибки PostgreSQL при нарушении уникальности
+const pgUniqueViolation = "23505"
+
+// templateColumns список колонок для выборки шаблонов
+// Порядок должен совпадать с порядком полей в scanTemplate
+var templateColumns = []string{
+	"id",
+	"name",
+	"version",
+	"locale",
+	"notification_type",
+	"parse_mode",
+	"text_template",
+	"inline_buttons",
+	"description",
+	"created_at",
+	"deleted_at",
+}
+
+// rowScanner общий интерфейс для *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// Repository репозиторий для работы с шаблонами уведомлений
+type Repository struct {
+	db DBExecutor
+}
+
+// NewRepository создает новый экземпляр репозитория шаблонов
+func NewRepository(db DBExecutor) *Repository {
+	return &Repository{db: db}
+}
+
+// CreateVersion создаёт новую версию шаблона
+// Номер версии вычисляется как следующий после максимального для данного имени и языка (включая удалённые)
+func (r *Repository) CreateVersion(ctx context.Context, template *domain.Template) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Insert("notification_templates").
+		Columns(
+			"name",
+			"version",
+			"locale",
+			"notification_type",
+			"parse_mode",
+			"text_template",
+			"inline_buttons",
+			"description",
+		).
+		Values(
+			template.Name,
+			squirrel.Expr("(SELECT COALESCE(MAX(version), 0) + 1 FROM notification_templates WHERE name = ? AND locale = ?)", template.Name, template.Locale),
+			template.Locale,
+			template.Type,
+			template.ParseMode,
+			template.Text,
+			template.InlineButtons,
+			template.Description,
+		).
+		Suffix("RETURNING id, version, created_at").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: CreateVersion - build insert query: %v", ErrBuildQuery, err)
+	}
+
+	err = executor.QueryRowContext(ctx, query, args...).Scan(&template.ID, &template.Version, &template.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pgUniqueViolation {
+			return ErrTemplateAlreadyExists
+		}
+		return fmt.Errorf("%w: CreateVersion - execute insert: %v", ErrExecQuery, err)
+	}
+
+	return nil
+}
+
+// GetLatest получает последнюю версию неудалённого шаблона по имени и языку
+func (r *Repository) GetLatest(ctx context.Context, name, locale string) (*domain.Template, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Select(templateColumns...).
+		From("notification_templates").
+		Where(squirrel.Eq{"name": name, "locale": locale}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		OrderBy("version DESC").
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetLatest - build select query: %v", ErrBuildQuery, err)
+	}
+
+	template, err := scanTemplate(executor.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, ErrTemplateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetLatest - scan template: %v", ErrScanRow, err)
+	}
+
+	return template, nil
+}
+
+// GetVersion получает конкретную версию языкового варианта шаблона
+// Удалённые шаблоны тоже возвращаются: на них могут ссылаться уже созданные уведомления
+func (r *Repository) GetVersion(ctx context.Context, name, locale string, version int) (*domain.Template, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	query, args, err := psqlbuilder.Select(templateColumns...).
+		From("notification_templates").
+		Where(squirrel.Eq{"name": name, "locale": locale, "version": version}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetVersion - build select query: %v", ErrBuildQuery, err)
+	}
+
+	template, err := scanTemplate(executor.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, ErrTemplateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: GetVersion - scan template: %v", ErrScanRow, err)
+	}
+
+	return template, nil
+}
+
+// List получает последние версии неудалённых шаблонов (по одной на каждый язык)
+func (r *Repository) List(ctx context.Context, filter ListFilter) ([]*domain.Template, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	selectBuilder := psqlbuilder.Select(templateColumns...).
+		Options("DISTINCT ON (name, locale)").
+		From("notification_templates").
+		Where(squirrel.Eq{"deleted_at": nil}).
+		OrderBy("name ASC", "locale ASC", "version DESC")
+
+	// Фильтрация по типу уведомления
+	if filter.Type != nil {
+		selectBuilder = selectBuilder.Where(squirrel.Eq{"notification_type": *filter.Type})
+	}
+
+	// Фильтрация по языку
+	if filter.Locale != nil {
+		selectBuilder = selectBuilder.Where(squirrel.Eq{"locale": *filter.Locale})
+	}
+
+	// Пагинация
+	if filter.Limit > 0 {
+		selectBuilder = selectBuilder.Limit(uint64(filter.Limit))
+	}
+	if filter.Offset > 0 {
+		selectBuilder = selectBuilder.Offset(uint64(filter.Offset))
+	}
+
+	query, args, err := selectBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%w: List - build select query: %v", ErrBuildQuery, err)
+	}
+
+	rows, err := executor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: List - execute query: %v", ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	return r.scanTemplates(rows)
+}
+
+// ListVersions получает все версии шаблона (по языкам, от новых к старым)
+// Если locale передан - только версии этого языкового варианта
+func (r *Repository) ListVersions(ctx context.Context, name string, locale *string) ([]*domain.Template, error) {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	selectBuilder := psqlbuilder.Select(templateColumns...).
+		From("notification_templates").
+		Where(squirrel.Eq{"name": name}).
+		OrderBy("locale ASC", "version DESC")
+
+	if locale != nil {
+		selectBuilder = selectBuilder.Where(squirrel.Eq{"locale": *locale})
+	}
+
+	query, args, err := selectBuilder.ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListVersions - build select query: %v", ErrBuildQuery, err)
+	}
+
+	rows, err := executor.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListVersions - execute query: %v", ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	return r.scanTemplates(rows)
+}
+
+// Delete помечает все версии шаблона как удалённые (soft delete)
+// Если locale передан - удаляется только этот языковой вариант
+func (r *Repository) Delete(ctx context.Context, name string, locale *string) error {
+	executor := dbmetrics.GetExecutor(ctx, r.db)
+
+	updateBuilder := psqlbuilder.Update("notification_templates").
+		Set("deleted_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"name": name}).
+		Where(squirrel.Eq{"deleted_at": nil})
+
+	if locale != nil {
+		updateBuilder = updateBuilder.Where(squirrel.Eq{"locale": *locale})
+	}
+
+	query, args, err := updateBuilder.ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%w: Delete - build update query: %v", ErrBuildQuery, err)
+	}
+
+	result, err := executor.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: Delete - execute update: %v", ErrExecQuery, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: Delete - get rows affected: %v", ErrExecQuery, err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrTemplateNotFound
+	}
+
+	return nil
+}
+
+// scanTemplates сканирует результаты запроса в слайс шаблонов
+func (r *Repository) scanTemplates(rows *sql.Rows) ([]*domain.Template, error) {
+	templates := make([]*domain.Template, 0)
+
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: scanTemplates - scan row: %v", ErrScanRow, err)
+		}
+
+		templates = append(templates, template)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: scanTemplates - rows error: %v", ErrScanRow, err)
+	}
+
+	return templates, nil
+}
+
+// scanTemplate сканирует одну строку в доменную модель
+// Порядок полей соответствует templateColumns
+func scanTemplate(row rowScanner) (*domain.Template, error) {
+	var template domain.Template
+
+	err := row.Scan(
+		&template.ID,
+		&template.Name,
+		&template.Version,
+		&template.Locale,
+		&template.Type,
+		&template.ParseMode,
+		&template.Text,
+		&template.InlineButtons,
+		&template.Description,
+		&template.CreatedAt,
+		&template.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
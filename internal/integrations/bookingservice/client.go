@@ -0,0 +1,139 @@
+package bookingservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Client клиент для работы с BookingService
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient создает новый экземпляр клиента BookingService
+func NewClient(baseURL string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// GetBooking получает бронирование по ID (operationId: getBooking)
+// userID передаётся в заголовке X-User-ID для проверки доступа на стороне BookingService
+func (c *Client) GetBooking(ctx context.Context, bookingID int64, userID int64) (*Booking, error) {
+	url := fmt.Sprintf("%s/bookings/%d", c.baseURL, bookingID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %v", ErrInternal, err)
+	}
+	req.Header.Set("X-User-ID", strconv.FormatInt(userID, 10))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to execute request: %v", ErrInternal, err)
+	}
+	defer resp.Body.Close()
+
+	// Обработка статус-кодов
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Продолжаем обработку
+	case http.StatusNotFound:
+		return nil, ErrBookingNotFound
+	case http.StatusForbidden:
+		return nil, ErrForbidden
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: unexpected status code %d: %s", ErrInvalidResponse, resp.StatusCode, string(body))
+	}
+
+	// Парсим ответ
+	var booking Booking
+	if err := json.NewDecoder(resp.Body).Decode(&booking); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode response: %v", ErrInvalidResponse, err)
+	}
+
+	return &booking, nil
+}
+
+// GetUserBookings получает бронирования пользователя (operationId: getUserBookings)
+// status - фильтр по статусу, пустая строка - все бронирования
+func (c *Client) GetUserBookings(ctx context.Context, userID int64, status BookingStatus) ([]*Booking, error) {
+	url := fmt.Sprintf("%s/users/%d/bookings", c.baseURL, userID)
+	if status != "" {
+		url += "?status=" + string(status)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %v", ErrInternal, err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to execute request: %v", ErrInternal, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: unexpected status code %d: %s", ErrInvalidResponse, resp.StatusCode, string(body))
+	}
+
+	// Парсим ответ
+	var bookings []*Booking
+	if err := json.NewDecoder(resp.Body).Decode(&bookings); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode response: %v", ErrInvalidResponse, err)
+	}
+
+	return bookings, nil
+}
+
+// CancelBooking отменяет бронирование от имени пользователя (operationId: cancelBooking)
+func (c *Client) CancelBooking(ctx context.Context, bookingID int64, req *CancelBookingRequest) error {
+	url := fmt.Sprintf("%s/bookings/%d/cancel", c.baseURL, bookingID)
+
+	// Кодируем тело запроса
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("%w: failed to marshal request: %v", ErrInternal, err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("%w: failed to create request: %v", ErrInternal, err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("%w: failed to execute request: %v", ErrInternal, err)
+	}
+	defer resp.Body.Close()
+
+	// Обработка статус-кодов
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusBadRequest:
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%w: %s", ErrCannotCancel, string(body))
+	case http.StatusNotFound:
+		return ErrBookingNotFound
+	case http.StatusForbidden:
+		return ErrForbidden
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%w: unexpected status code %d: %s", ErrInvalidResponse, resp.StatusCode, string(body))
+	}
+}
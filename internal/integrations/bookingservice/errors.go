@@ -0,0 +1,20 @@
+package bookingservice
+
+import "errors"
+
+var (
+	// ErrBookingNotFound возвращается, когда бронирование не найдено
+	ErrBookingNotFound = errors.New("booking not found")
+
+	// ErrForbidden возвращается, когда у пользователя нет доступа к бронированию
+	ErrForbidden = errors.New("bookingservice client: access to booking forbidden")
+
+	// ErrCannotCancel возвращается, когда бронирование нельзя отменить (например, уже выполнено)
+	ErrCannotCancel = errors.New("bookingservice client: booking cannot be cancelled")
+
+	// ErrInternal возвращается при внутренних ошибках клиента
+	ErrInternal = errors.New("bookingservice client: internal error")
+
+	// ErrInvalidResponse возвращается при некорректном ответе от сервиса
+	ErrInvalidResponse = errors.New("bookingservice client: invalid response")
+)
@@ -0,0 +1,62 @@
+package bookingservice
+
+import "time"
+
+// BookingStatus статус бронирования в BookingService
+type BookingStatus string
+
+const (
+	BookingStatusPending            BookingStatus = "pending"
+	BookingStatusConfirmed          BookingStatus = "confirmed"
+	BookingStatusInProgress         BookingStatus = "in_progress"
+	BookingStatusCompleted          BookingStatus = "completed"
+	BookingStatusCancelledByUser    BookingStatus = "cancelled_by_user"
+	BookingStatusCancelledByCompany BookingStatus = "cancelled_by_company"
+	BookingStatusNoShow             BookingStatus = "no_show"
+)
+
+// Booking модель бронирования из BookingService (schemas/schema.yaml, Booking)
+type Booking struct {
+	ID                 int64         `json:"id"`
+	UserID             int64         `json:"userId"`
+	CompanyID          int64         `json:"companyId"`
+	AddressID          int64         `json:"addressId"`
+	ServiceID          int64         `json:"serviceId"`
+	CarID              int64         `json:"carId"`
+	BookingDate        string        `json:"bookingDate"` // Формат: YYYY-MM-DD
+	StartTime          string        `json:"startTime"`   // Формат: HH:MM
+	DurationMinutes    int           `json:"durationMinutes"`
+	Status             BookingStatus `json:"status"`
+	ServiceName        string        `json:"serviceName"`
+	ServicePrice       float64       `json:"servicePrice"`
+	CarBrand           *string       `json:"carBrand"`
+	CarModel           *string       `json:"carModel"`
+	CarLicensePlate    *string       `json:"carLicensePlate"`
+	Notes              *string       `json:"notes"`
+	CancellationReason *string       `json:"cancellationReason"`
+	CancelledAt        *time.Time    `json:"cancelledAt"`
+	CreatedAt          time.Time     `json:"createdAt"`
+	UpdatedAt          time.Time     `json:"updatedAt"`
+}
+
+// IsCancelled проверяет, отменено ли бронирование
+func (b *Booking) IsCancelled() bool {
+	return b.Status == BookingStatusCancelledByUser || b.Status == BookingStatusCancelledByCompany
+}
+
+// IsActive проверяет, что бронирование ещё предстоит или выполняется
+func (b *Booking) IsActive() bool {
+	return b.Status == BookingStatusPending || b.Status == BookingStatusConfirmed || b.Status == BookingStatusInProgress
+}
+
+// CancelBookingRequest запрос на отмену бронирования (schemas/schema.yaml, CancelBookingRequest)
+type CancelBookingRequest struct {
+	UserID             int64   `json:"userId"`
+	CancellationReason *string `json:"cancellationReason,omitempty"`
+}
+
+// ErrorResponse модель ошибки от BookingService
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
@@ -107,3 +107,49 @@ func (c *Client) CreateUser(ctx context.Context, req *CreateUserRequest) (*User,
 
 	return &user, nil
 }
+
+// UpdateUser изменяет данные пользователя в UserService
+// Используется, когда пользователь поделился номером телефона через кнопку бота
+func (c *Client) UpdateUser(ctx context.Context, tgUserID int64, req *UpdateUserRequest) (*User, error) {
+	url := fmt.Sprintf("%s/internal/users/%d", c.baseURL, tgUserID)
+
+	// Кодируем тело запроса
+	bodyBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to marshal request: %v", ErrInternal, err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %v", ErrInternal, err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to execute request: %v", ErrInternal, err)
+	}
+	defer resp.Body.Close()
+
+	// Обработка статус-кодов
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Продолжаем обработку
+	case http.StatusNotFound:
+		return nil, ErrUserNotFound
+	case http.StatusBadRequest:
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: bad request: %s", ErrInvalidResponse, string(body))
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: unexpected status code %d: %s", ErrInvalidResponse, resp.StatusCode, string(body))
+	}
+
+	// Парсим ответ
+	var user User
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode response: %v", ErrInvalidResponse, err)
+	}
+
+	return &user, nil
+}
@@ -25,4 +25,22 @@ type CreateUserRequest struct {
 	PhoneNumber *string `json:"phone_number,omitempty"` // Опционально
 	TgLink      *string `json:"tg_link,omitempty"`
 	Role        string  `json:"role"`
+
+	Attribution *Attribution `json:"attribution,omitempty"` // Deep-link ссылка, по которой пользователь пришёл в бота
+}
+
+// UpdateUserRequest запрос на изменение пользователя
+// Передаются только изменяемые поля
+type UpdateUserRequest struct {
+	PhoneNumber *string `json:"phone_number,omitempty"` // Номер телефона в формате E.164
+}
+
+// Attribution источник привлечения пользователя
+// Заполняется одно поле в зависимости от типа
+type Attribution struct {
+	Type         string  `json:"type"` // company, booking, ref, source
+	CompanyID    *int64  `json:"company_id,omitempty"`
+	BookingID    *int64  `json:"booking_id,omitempty"`
+	ReferralCode *string `json:"referral_code,omitempty"`
+	Source       *string `json:"source,omitempty"` // Метка источника (рекламная кампания, канал)
 }
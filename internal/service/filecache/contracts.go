@@ -0,0 +1,20 @@
+package filecache
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// Repository интерфейс репозитория кэша file_id
+type Repository interface {
+	GetByKey(ctx context.Context, cacheKey string) (*domain.TelegramFile, error)
+	Save(ctx context.Context, file *domain.TelegramFile) error
+	Delete(ctx context.Context, cacheKey string) error
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,92 @@
+package filecache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/telegramfile"
+)
+
+// dbTimeout таймаут обращения к БД: кэш не должен задерживать отправку
+const dbTimeout = 3 * time.Second
+
+// Service кэш file_id загруженных в Telegram файлов
+// Значения хранятся в Postgres и дублируются в памяти, чтобы рассылка не обращалась к БД для каждого получателя
+// Ошибки БД только логируются: без кэша файл просто будет загружен заново
+type Service struct {
+	repo   Repository
+	logger Logger
+
+	mu     sync.RWMutex
+	memory map[string]string // cache_key -> file_id
+}
+
+// NewService создает новый экземпляр кэша file_id
+func NewService(repo Repository, logger Logger) *Service {
+	return &Service{
+		repo:   repo,
+		logger: logger,
+		memory: make(map[string]string),
+	}
+}
+
+// Get возвращает file_id по ключу кэша
+func (s *Service) Get(cacheKey string) (string, bool) {
+	s.mu.RLock()
+	fileID, ok := s.memory[cacheKey]
+	s.mu.RUnlock()
+	if ok {
+		return fileID, true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	file, err := s.repo.GetByKey(ctx, cacheKey)
+	if err != nil {
+		if !errors.Is(err, telegramfile.ErrTelegramFileNotFound) {
+			s.logger.Error("File cache: failed to get %s: %v", cacheKey, err)
+		}
+		return "", false
+	}
+
+	s.mu.Lock()
+	s.memory[cacheKey] = file.FileID
+	s.mu.Unlock()
+
+	return file.FileID, true
+}
+
+// Save запоминает file_id, полученный после первой загрузки файла
+func (s *Service) Save(cacheKey, fileID string, mediaType domain.AttachmentType) {
+	s.mu.Lock()
+	s.memory[cacheKey] = fileID
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	file := &domain.TelegramFile{CacheKey: cacheKey, FileID: fileID, MediaType: mediaType}
+	if err := s.repo.Save(ctx, file); err != nil {
+		s.logger.Error("File cache: failed to save %s: %v", cacheKey, err)
+	}
+}
+
+// Forget удаляет file_id, который Telegram больше не принимает
+func (s *Service) Forget(cacheKey string) {
+	s.mu.Lock()
+	delete(s.memory, cacheKey)
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	if err := s.repo.Delete(ctx, cacheKey); err != nil {
+		s.logger.Error("File cache: failed to forget %s: %v", cacheKey, err)
+	} else {
+		s.logger.Warn("File cache: %s was rejected by Telegram and removed", cacheKey)
+	}
+}
@@ -0,0 +1,17 @@
+package groupchat
+
+import (
+	"context"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// GroupChatRepository интерфейс репозитория групповых чатов
+type GroupChatRepository interface {
+	Register(ctx context.Context, chat *domain.GroupChat) error
+	Deactivate(ctx context.Context, chatID int64) (bool, error)
+	Link(ctx context.Context, chat *domain.GroupChat, companyID int64, linkedBy int64, at time.Time) error
+	Migrate(ctx context.Context, fromChatID, toChatID int64) error
+	ListByCompany(ctx context.Context, companyID int64, limit, offset int) ([]*domain.GroupChat, error)
+}
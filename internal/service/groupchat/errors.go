@@ -0,0 +1,11 @@
+package groupchat
+
+import "errors"
+
+var (
+	// ErrNotGroupChat возвращается для чатов, которые не являются группой или супергруппой
+	ErrNotGroupChat = errors.New("service.groupchat: chat is not a group")
+
+	// ErrInternal возвращается при внутренних ошибках сервиса
+	ErrInternal = errors.New("service.groupchat: internal error")
+)
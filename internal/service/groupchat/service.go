@@ -0,0 +1,94 @@
+package groupchat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	groupChatRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/groupchat"
+)
+
+// Service сервис реестра групповых чатов
+// Чат регистрируется, когда бота добавляют в группу, и привязывается к компании командой /link
+type Service struct {
+	repo GroupChatRepository
+}
+
+// NewService создает новый экземпляр сервиса групповых чатов
+func NewService(repo GroupChatRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// Register регистрирует групповой чат, в который добавлен бот
+// addedBy - кто добавил бота (nil, если неизвестно)
+func (s *Service) Register(ctx context.Context, chatID int64, chatType, title string, addedBy *int64) error {
+	if !domain.IsGroupChatType(chatType) {
+		return ErrNotGroupChat
+	}
+
+	chat := &domain.GroupChat{
+		ChatID:          chatID,
+		Type:            domain.GroupChatType(chatType),
+		Title:           title,
+		AddedByTgUserID: addedBy,
+	}
+	if err := s.repo.Register(ctx, chat); err != nil {
+		return fmt.Errorf("%w: Register - repository error: %v", ErrInternal, err)
+	}
+
+	return nil
+}
+
+// Deactivate помечает чат неактивным после удаления бота
+// Возвращает true, если чат был активен
+func (s *Service) Deactivate(ctx context.Context, chatID int64) (bool, error) {
+	deactivated, err := s.repo.Deactivate(ctx, chatID)
+	if err != nil {
+		return false, fmt.Errorf("%w: Deactivate - repository error: %v", ErrInternal, err)
+	}
+
+	return deactivated, nil
+}
+
+// Link привязывает групповой чат к компании
+func (s *Service) Link(ctx context.Context, chatID int64, chatType, title string, companyID, linkedBy int64) error {
+	if !domain.IsGroupChatType(chatType) {
+		return ErrNotGroupChat
+	}
+
+	chat := &domain.GroupChat{
+		ChatID: chatID,
+		Type:   domain.GroupChatType(chatType),
+		Title:  title,
+	}
+	if err := s.repo.Link(ctx, chat, companyID, linkedBy, time.Now()); err != nil {
+		return fmt.Errorf("%w: Link - repository error: %v", ErrInternal, err)
+	}
+
+	return nil
+}
+
+// Migrate переносит чат на новый ID после преобразования группы в супергруппу
+// Возвращает false, если старый чат не был зарегистрирован
+func (s *Service) Migrate(ctx context.Context, fromChatID, toChatID int64) (bool, error) {
+	if err := s.repo.Migrate(ctx, fromChatID, toChatID); err != nil {
+		if errors.Is(err, groupChatRepo.ErrGroupChatNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("%w: Migrate - repository error: %v", ErrInternal, err)
+	}
+
+	return true, nil
+}
+
+// ListByCompany возвращает активные чаты компании
+func (s *Service) ListByCompany(ctx context.Context, companyID int64, limit, offset int) ([]*domain.GroupChat, error) {
+	chats, err := s.repo.ListByCompany(ctx, companyID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListByCompany - repository error: %v", ErrInternal, err)
+	}
+
+	return chats, nil
+}
@@ -0,0 +1,28 @@
+package media
+
+import (
+	"context"
+	"io"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// MediaRepository интерфейс репозитория медиатеки
+type MediaRepository interface {
+	Create(ctx context.Context, media *domain.Media) error
+	GetByID(ctx context.Context, id string) (*domain.Media, error)
+}
+
+// BlobStore интерфейс хранилища содержимого файлов
+// Реализация выбирается при запуске: локальный каталог или внешнее объектное хранилище
+type BlobStore interface {
+	Put(ctx context.Context, key string, content io.Reader) (int64, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
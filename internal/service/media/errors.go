@@ -0,0 +1,20 @@
+package media
+
+import "errors"
+
+var (
+	// ErrMediaNotFound возвращается, когда файл медиатеки не найден
+	ErrMediaNotFound = errors.New("service.media: media not found")
+
+	// ErrInvalidInput возвращается при некорректных входных данных
+	ErrInvalidInput = errors.New("service.media: invalid input data")
+
+	// ErrUnsupportedMediaType возвращается, когда формат файла не подходит для указанного типа вложения
+	ErrUnsupportedMediaType = errors.New("service.media: unsupported media type")
+
+	// ErrFileTooLarge возвращается, когда файл превышает ограничение Telegram для своего типа
+	ErrFileTooLarge = errors.New("service.media: file too large")
+
+	// ErrInternal возвращается при внутренних ошибках сервиса
+	ErrInternal = errors.New("service.media: internal error")
+)
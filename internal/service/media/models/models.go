@@ -0,0 +1,15 @@
+package models
+
+import (
+	"io"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// UploadMediaInput входные данные для загрузки файла в медиатеку
+type UploadMediaInput struct {
+	Filename string
+	Type     domain.AttachmentType // Пустое значение - тип определяется по содержимому
+	Size     int64                 // Размер из запроса; фактический размер проверяется при записи
+	Content  io.Reader
+}
@@ -0,0 +1,164 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	mediaRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/media"
+	"github.com/m04kA/SMC-NotificationService/internal/service/media/models"
+)
+
+// sniffLength количество первых байт файла, по которым определяется MIME-тип
+const sniffLength = 512
+
+// defaultFilename имя файла, если клиент его не передал
+const defaultFilename = "file"
+
+// Service сервис медиатеки
+// Описание файлов хранится в БД, содержимое - в blob-хранилище под ключом media_id
+type Service struct {
+	repo   MediaRepository
+	blobs  BlobStore
+	logger Logger
+}
+
+// NewService создает новый экземпляр сервиса медиатеки
+func NewService(repo MediaRepository, blobs BlobStore, logger Logger) *Service {
+	return &Service{
+		repo:   repo,
+		blobs:  blobs,
+		logger: logger,
+	}
+}
+
+// Upload проверяет файл по ограничениям Telegram и сохраняет его в медиатеку
+// MIME-тип определяется по содержимому, а не по заголовку запроса
+func (s *Service) Upload(ctx context.Context, input *models.UploadMediaInput) (*domain.Media, error) {
+	if input.Type != "" && !input.Type.IsMedia() {
+		return nil, fmt.Errorf("%w: type must be one of photo, video, document, audio", ErrInvalidInput)
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(input.Content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: read file: %v", ErrInvalidInput, err)
+	}
+	head = head[:n]
+	if n == 0 {
+		return nil, fmt.Errorf("%w: file is empty", ErrInvalidInput)
+	}
+
+	filename := sanitizeFilename(input.Filename)
+	contentType := detectContentType(head, filename)
+
+	mediaType := input.Type
+	if mediaType == "" {
+		mediaType = domain.MediaTypeByContentType(contentType)
+	}
+	if !mediaType.AcceptsContentType(contentType) {
+		return nil, fmt.Errorf("%w: %s cannot be sent as %s", ErrUnsupportedMediaType, contentType, mediaType)
+	}
+
+	maxSize := domain.MaxMediaSize(mediaType)
+	if input.Size > maxSize {
+		return nil, fmt.Errorf("%w: %s must not exceed %d MB", ErrFileTooLarge, mediaType, maxSize>>20)
+	}
+
+	media := &domain.Media{
+		ID:          uuid.New().String(),
+		Type:        mediaType,
+		Filename:    filename,
+		ContentType: contentType,
+	}
+
+	// Размер из запроса может не совпадать с фактическим - читаем на байт больше лимита
+	content := io.LimitReader(io.MultiReader(bytes.NewReader(head), input.Content), maxSize+1)
+	size, err := s.blobs.Put(ctx, media.ID, content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: Upload - blob store error: %v", ErrInternal, err)
+	}
+	if size > maxSize {
+		s.deleteBlob(ctx, media.ID)
+		return nil, fmt.Errorf("%w: %s must not exceed %d MB", ErrFileTooLarge, mediaType, maxSize>>20)
+	}
+	media.Size = size
+
+	if err := s.repo.Create(ctx, media); err != nil {
+		s.deleteBlob(ctx, media.ID)
+		return nil, fmt.Errorf("%w: Upload - repository error: %v", ErrInternal, err)
+	}
+
+	return media, nil
+}
+
+// Get возвращает описание файла медиатеки
+func (s *Service) Get(ctx context.Context, id string) (*domain.Media, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrMediaNotFound
+	}
+
+	media, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, mediaRepo.ErrMediaNotFound) {
+			return nil, ErrMediaNotFound
+		}
+		return nil, fmt.Errorf("%w: Get - repository error: %v", ErrInternal, err)
+	}
+
+	return media, nil
+}
+
+// Open возвращает описание файла и его содержимое
+// Вызывающий код обязан закрыть содержимое
+func (s *Service) Open(ctx context.Context, id string) (*domain.Media, io.ReadCloser, error) {
+	media, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.blobs.Open(ctx, media.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: Open - blob store error: %v", ErrInternal, err)
+	}
+
+	return media, content, nil
+}
+
+// deleteBlob удаляет содержимое файла, описание которого не удалось сохранить
+func (s *Service) deleteBlob(ctx context.Context, key string) {
+	if err := s.blobs.Delete(ctx, key); err != nil {
+		s.logger.Warn("Media: failed to delete blob %s: %v", key, err)
+	}
+}
+
+// detectContentType определяет MIME-тип по содержимому
+// Если содержимое не распознано, используется расширение имени файла
+func detectContentType(head []byte, filename string) string {
+	contentType := http.DetectContentType(head)
+	if contentType != "application/octet-stream" {
+		return contentType
+	}
+
+	if byExt := mime.TypeByExtension(filepath.Ext(filename)); byExt != "" {
+		return byExt
+	}
+	return contentType
+}
+
+// sanitizeFilename оставляет от имени файла только базовое имя без пути
+func sanitizeFilename(filename string) string {
+	filename = strings.TrimSpace(filepath.Base(strings.ReplaceAll(filename, `\`, "/")))
+	if filename == "" || filename == "." || filename == "/" {
+		return defaultFilename
+	}
+	return filename
+}
@@ -19,7 +19,29 @@ type NotificationRepository interface {
 	CancelBySpanID(ctx context.Context, spanID string) (int, error)
 }
 
+// BotUserRepository интерфейс репозитория пользователей бота
+type BotUserRepository interface {
+	GetByTgUserID(ctx context.Context, tgUserID int64) (*domain.BotUser, error)
+}
+
+// TemplateService интерфейс реестра шаблонов
+type TemplateService interface {
+	GetLocalized(ctx context.Context, name, locale string) (*domain.Template, error)
+	Render(template *domain.Template, vars domain.Metadata) (*domain.RenderedMessage, error)
+	ResolveVariables(ctx context.Context, notification *domain.Notification) (domain.Metadata, error)
+}
+
 // UserServiceClient интерфейс клиента UserService
 type UserServiceClient interface {
 	GetUser(ctx context.Context, tgUserID int64) (*userservice.User, error)
 }
+
+// PreferencesService интерфейс настроек уведомлений пользователей
+type PreferencesService interface {
+	SkipReason(ctx context.Context, tgUserID int64, t domain.NotificationType, c domain.NotificationChannel) (domain.SkipReason, bool, error)
+}
+
+// MediaService интерфейс медиатеки
+type MediaService interface {
+	Get(ctx context.Context, id string) (*domain.Media, error)
+}
@@ -15,6 +15,12 @@ var (
 	// ErrInvalidRecipient возвращается когда не указан ни telegram_user_id, ни chat_id
 	ErrInvalidRecipient = errors.New("service.notifications: either telegram_user_id or chat_id must be provided")
 
+	// ErrTemplateNotFound возвращается, когда указанный шаблон не найден в реестре
+	ErrTemplateNotFound = errors.New("service.notifications: template not found")
+
+	// ErrBookingNotFound возвращается, когда бронирование из metadata.booking_id не найдено в BookingService
+	ErrBookingNotFound = errors.New("service.notifications: booking not found")
+
 	// ErrCannotCancel возвращается, когда уведомление нельзя отменить
 	ErrCannotCancel = errors.New("service.notifications: notification cannot be cancelled (already sent or failed)")
 
@@ -8,25 +8,37 @@ import (
 
 // CreateNotificationInput входные данные для создания одного уведомления
 type CreateNotificationInput struct {
-	TelegramUserID *int64
-	ChatID         *int64
-	MessageText    string
-	ImageURLs      []string
-	InlineButtons  []domain.InlineButton
-	Type           domain.NotificationType
-	ScheduledFor   *time.Time
-	Metadata       domain.Metadata
+	TelegramUserID    *int64
+	ChatID            *int64
+	MessageText       string
+	ParseMode         *string // HTML (по умолчанию) или пустая строка
+	TemplateName      *string
+	TemplateVariables domain.Metadata
+	Locale            *string // Если не указан - определяется по языку получателя
+	ImageURLs         []string
+	Attachments       []domain.Attachment
+	InlineButtons     []domain.InlineButton
+	DeliveryOptions   *domain.DeliveryOptions
+	Type              domain.NotificationType
+	ScheduledFor      *time.Time
+	Metadata          domain.Metadata
 }
 
 // CreateBatchNotificationInput входные данные для создания массовой рассылки
 type CreateBatchNotificationInput struct {
-	TelegramUserIDs []int64
-	MessageText     string
-	ImageURLs       []string
-	InlineButtons   []domain.InlineButton
-	Type            domain.NotificationType
-	ScheduledFor    *time.Time
-	Metadata        domain.Metadata
+	TelegramUserIDs   []int64
+	MessageText       string
+	ParseMode         *string // HTML (по умолчанию) или пустая строка
+	TemplateName      *string
+	TemplateVariables domain.Metadata
+	Locale            *string // Если не указан - определяется по языку получателя
+	ImageURLs         []string
+	Attachments       []domain.Attachment
+	InlineButtons     []domain.InlineButton
+	DeliveryOptions   *domain.DeliveryOptions
+	Type              domain.NotificationType
+	ScheduledFor      *time.Time
+	Metadata          domain.Metadata
 }
 
 // BatchNotificationResult результат создания массовой рассылки
@@ -35,12 +47,14 @@ type BatchNotificationResult struct {
 	TotalCreated    int
 	NotificationIDs []int64
 	FailedUserIDs   []int64
+	SkippedUserIDs  []int64 // Получатели, отключившие этот тип уведомлений (уведомления созданы со статусом skipped)
 }
 
 // ListNotificationsFilter фильтр для получения списка уведомлений
 type ListNotificationsFilter struct {
 	Status         *domain.NotificationStatus
 	Type           *domain.NotificationType
+	SkipReason     *domain.SkipReason
 	TelegramUserID *int64
 	SpanID         *string
 	Limit          int
@@ -49,57 +63,87 @@ type ListNotificationsFilter struct {
 
 // NotificationOutput выходная модель для одного уведомления
 type NotificationOutput struct {
-	ID             int64
-	TelegramUserID *int64
-	ChatID         *int64
-	SpanID         *string
-	MessageText    string
-	ImageURLs      []string // Преобразован из pq.StringArray
-	InlineButtons  []domain.InlineButton
-	Type           domain.NotificationType
-	Status         domain.NotificationStatus
-	ScheduledFor   *time.Time
-	SentAt         *time.Time
-	Metadata       domain.Metadata
-	ErrorMessage   *string
-	RetryCount     int
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	ID                int64
+	TelegramUserID    *int64
+	ChatID            *int64
+	SpanID            *string
+	MessageText       string
+	ParseMode         *string
+	TemplateName      *string
+	TemplateVersion   *int
+	TemplateVariables domain.Metadata
+	Locale            *string
+	ImageURLs         []string // Преобразован из pq.StringArray
+	Attachments       []domain.Attachment
+	InlineButtons     []domain.InlineButton
+	DeliveryOptions   *domain.DeliveryOptions
+	Type              domain.NotificationType
+	Status            domain.NotificationStatus
+	ScheduledFor      *time.Time
+	SentAt            *time.Time
+	SentMessages      []domain.SentMessage
+	MessagesEditedAt  *time.Time
+	MessagesDeletedAt *time.Time
+	Metadata          domain.Metadata
+	ErrorMessage      *string
+	SkipReason        *domain.SkipReason
+	DeferredUntil     *time.Time
+	RetryCount        int
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 // FromDomainNotification преобразует доменную модель в выходную модель сервиса
 func FromDomainNotification(n *domain.Notification) *NotificationOutput {
 	return &NotificationOutput{
-		ID:             n.ID,
-		TelegramUserID: n.TelegramUserID,
-		ChatID:         n.ChatID,
-		SpanID:         n.SpanID,
-		MessageText:    n.MessageText,
-		ImageURLs:      []string(n.ImageURLs), // Приводим pq.StringArray к []string
-		InlineButtons:  n.InlineButtons,
-		Type:           n.Type,
-		Status:         n.Status,
-		ScheduledFor:   n.ScheduledFor,
-		SentAt:         n.SentAt,
-		Metadata:       n.Metadata,
-		ErrorMessage:   n.ErrorMessage,
-		RetryCount:     n.RetryCount,
-		CreatedAt:      n.CreatedAt,
-		UpdatedAt:      n.UpdatedAt,
+		ID:                n.ID,
+		TelegramUserID:    n.TelegramUserID,
+		ChatID:            n.ChatID,
+		SpanID:            n.SpanID,
+		MessageText:       n.MessageText,
+		ParseMode:         n.ParseMode,
+		TemplateName:      n.TemplateName,
+		TemplateVersion:   n.TemplateVersion,
+		TemplateVariables: n.TemplateVariables,
+		Locale:            n.Locale,
+		ImageURLs:         []string(n.ImageURLs), // Приводим pq.StringArray к []string
+		Attachments:       n.Attachments,
+		InlineButtons:     n.InlineButtons,
+		DeliveryOptions:   n.DeliveryOptions,
+		Type:              n.Type,
+		Status:            n.Status,
+		ScheduledFor:      n.ScheduledFor,
+		SentAt:            n.SentAt,
+		SentMessages:      n.SentMessages,
+		MessagesEditedAt:  n.MessagesEditedAt,
+		MessagesDeletedAt: n.MessagesDeletedAt,
+		Metadata:          n.Metadata,
+		ErrorMessage:      n.ErrorMessage,
+		SkipReason:        n.SkipReason,
+		DeferredUntil:     n.DeferredUntil,
+		RetryCount:        n.RetryCount,
+		CreatedAt:         n.CreatedAt,
+		UpdatedAt:         n.UpdatedAt,
 	}
 }
 
 // ToDomainNotification преобразует CreateNotificationInput в domain.Notification
 func (input *CreateNotificationInput) ToDomainNotification() *domain.Notification {
 	notification := &domain.Notification{
-		TelegramUserID: input.TelegramUserID,
-		ChatID:         input.ChatID,
-		MessageText:    input.MessageText,
-		ImageURLs:      input.ImageURLs,
-		InlineButtons:  input.InlineButtons,
-		Type:           input.Type,
-		ScheduledFor:   input.ScheduledFor,
-		Metadata:       input.Metadata,
+		TelegramUserID:    input.TelegramUserID,
+		ChatID:            input.ChatID,
+		MessageText:       input.MessageText,
+		ParseMode:         input.ParseMode,
+		TemplateName:      input.TemplateName,
+		TemplateVariables: input.TemplateVariables,
+		Locale:            input.Locale,
+		ImageURLs:         input.ImageURLs,
+		Attachments:       input.Attachments,
+		InlineButtons:     input.InlineButtons,
+		DeliveryOptions:   input.DeliveryOptions,
+		Type:              input.Type,
+		ScheduledFor:      input.ScheduledFor,
+		Metadata:          input.Metadata,
 	}
 
 	// Определяем статус
@@ -4,45 +4,53 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	botUserRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/botuser"
 	notificationRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/notification"
 	"github.com/m04kA/SMC-NotificationService/internal/integrations/userservice"
+	mediaSvc "github.com/m04kA/SMC-NotificationService/internal/service/media"
 	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+	"github.com/m04kA/SMC-NotificationService/internal/service/templates"
+	"github.com/m04kA/SMC-NotificationService/pkg/tghtml"
 )
 
 // Service сервис для управления уведомлениями
 type Service struct {
-	notificationRepo NotificationRepository
+	notificationRepo  NotificationRepository
 	userServiceClient UserServiceClient
+	templateService   TemplateService
+	botUserRepo       BotUserRepository
+	mediaService      MediaService
+	preferences       PreferencesService
 }
 
 // NewService создает новый экземпляр сервиса уведомлений
-func NewService(notificationRepo NotificationRepository, userServiceClient UserServiceClient) *Service {
+func NewService(notificationRepo NotificationRepository, userServiceClient UserServiceClient, templateService TemplateService, botUserRepo BotUserRepository, mediaService MediaService, preferences PreferencesService) *Service {
 	return &Service{
 		notificationRepo:  notificationRepo,
 		userServiceClient: userServiceClient,
+		templateService:   templateService,
+		botUserRepo:       botUserRepo,
+		mediaService:      mediaService,
+		preferences:       preferences,
 	}
 }
 
 // Create создает одно уведомление
+// Если получатель отключил этот тип уведомлений, уведомление сохраняется со статусом skipped и причиной
 func (s *Service) Create(ctx context.Context, input *models.CreateNotificationInput) (*domain.Notification, error) {
-	// Валидация получателя
-	if input.TelegramUserID == nil && input.ChatID == nil {
-		return nil, ErrInvalidRecipient
+	notification, err := s.prepare(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("Create - %w", err)
 	}
 
-	// Валидация пользователя в UserService (если указан telegram_user_id)
-	if input.TelegramUserID != nil {
-		if err := s.validateUser(ctx, *input.TelegramUserID); err != nil {
-			return nil, fmt.Errorf("Create - %w", err)
-		}
+	if err := s.applyPreferences(ctx, notification); err != nil {
+		return nil, fmt.Errorf("Create - %w", err)
 	}
 
-	// Преобразуем в доменную модель
-	notification := input.ToDomainNotification()
-
 	// Создаем уведомление в БД
 	id, err := s.notificationRepo.Create(ctx, notification)
 	if err != nil {
@@ -53,18 +61,40 @@ func (s *Service) Create(ctx context.Context, input *models.CreateNotificationIn
 	return notification, nil
 }
 
+// Prepare проверяет уведомление так же, как Create, но не сохраняет его
+// Используется для предпросмотра: возвращённое уведомление не имеет ID
+func (s *Service) Prepare(ctx context.Context, input *models.CreateNotificationInput) (*domain.Notification, error) {
+	notification, err := s.prepare(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("Prepare - %w", err)
+	}
+	return notification, nil
+}
+
 // CreateBatch создает массовую рассылку уведомлений
 func (s *Service) CreateBatch(ctx context.Context, input *models.CreateBatchNotificationInput) (*models.BatchNotificationResult, error) {
 	if len(input.TelegramUserIDs) == 0 {
 		return nil, fmt.Errorf("%w: telegram_user_ids cannot be empty", ErrInvalidInput)
 	}
 
+	// ID сообщений и тем форума у каждого чата свои
+	if input.DeliveryOptions.IsChatSpecific() {
+		return nil, fmt.Errorf("%w: delivery_options: reply_to_message_id and message_thread_id are not supported for batch", ErrInvalidInput)
+	}
+
+	// Шаблон проверяется один раз на каждый язык получателей
+	prototypes := make(map[string]*domain.Notification)
+	if _, err := s.batchPrototype(ctx, input, input.Locale, prototypes); err != nil {
+		return nil, fmt.Errorf("CreateBatch - %w", err)
+	}
+
 	// Генерируем span_id для группировки массовой рассылки
 	spanID := uuid.New().String()
 
 	// Подготавливаем уведомления для всех получателей
 	notifications := make([]*domain.Notification, 0, len(input.TelegramUserIDs))
 	failedUserIDs := make([]int64, 0)
+	skippedUserIDs := make([]int64, 0)
 
 	for _, tgUserID := range input.TelegramUserIDs {
 		// Валидация пользователя (пропускаем невалидных)
@@ -73,15 +103,32 @@ func (s *Service) CreateBatch(ctx context.Context, input *models.CreateBatchNoti
 			continue
 		}
 
+		locale, err := s.resolveLocale(ctx, input.Locale, &tgUserID)
+		if err != nil {
+			return nil, fmt.Errorf("CreateBatch - %w", err)
+		}
+
+		prototype, err := s.batchPrototype(ctx, input, locale, prototypes)
+		if err != nil {
+			return nil, fmt.Errorf("CreateBatch - %w", err)
+		}
+
 		notification := &domain.Notification{
-			TelegramUserID: &tgUserID,
-			SpanID:         &spanID,
-			MessageText:    input.MessageText,
-			ImageURLs:      input.ImageURLs,
-			InlineButtons:  input.InlineButtons,
-			Type:           input.Type,
-			ScheduledFor:   input.ScheduledFor,
-			Metadata:       input.Metadata,
+			TelegramUserID:    &tgUserID,
+			SpanID:            &spanID,
+			MessageText:       prototype.MessageText,
+			ParseMode:         prototype.ParseMode,
+			TemplateName:      prototype.TemplateName,
+			TemplateVersion:   prototype.TemplateVersion,
+			TemplateVariables: input.TemplateVariables,
+			Locale:            prototype.Locale,
+			ImageURLs:         input.ImageURLs,
+			Attachments:       prototype.Attachments, // Подписи уже очищены при проверке прототипа
+			InlineButtons:     input.InlineButtons,
+			DeliveryOptions:   input.DeliveryOptions,
+			Type:              prototype.Type,
+			ScheduledFor:      input.ScheduledFor,
+			Metadata:          input.Metadata,
 		}
 
 		// Определяем статус
@@ -91,6 +138,13 @@ func (s *Service) CreateBatch(ctx context.Context, input *models.CreateBatchNoti
 			notification.Status = domain.NotificationStatusPending
 		}
 
+		if err := s.applyPreferences(ctx, notification); err != nil {
+			return nil, fmt.Errorf("CreateBatch - %w", err)
+		}
+		if notification.Status == domain.NotificationStatusSkipped {
+			skippedUserIDs = append(skippedUserIDs, tgUserID)
+		}
+
 		notifications = append(notifications, notification)
 	}
 
@@ -105,6 +159,7 @@ func (s *Service) CreateBatch(ctx context.Context, input *models.CreateBatchNoti
 		TotalCreated:    len(ids),
 		NotificationIDs: ids,
 		FailedUserIDs:   failedUserIDs,
+		SkippedUserIDs:  skippedUserIDs,
 	}, nil
 }
 
@@ -136,6 +191,7 @@ func (s *Service) List(ctx context.Context, filter models.ListNotificationsFilte
 	repoFilter := notificationRepo.ListFilter{
 		Status:         filter.Status,
 		Type:           filter.Type,
+		SkipReason:     filter.SkipReason,
 		TelegramUserID: filter.TelegramUserID,
 		SpanID:         filter.SpanID,
 		Limit:          filter.Limit,
@@ -192,6 +248,235 @@ func (s *Service) CancelBySpanID(ctx context.Context, spanID string) (int, error
 	return count, nil
 }
 
+// prepare проверяет получателя и содержимое уведомления, определяет язык и фиксирует версию шаблона
+func (s *Service) prepare(ctx context.Context, input *models.CreateNotificationInput) (*domain.Notification, error) {
+	// Валидация получателя
+	if input.TelegramUserID == nil && input.ChatID == nil {
+		return nil, ErrInvalidRecipient
+	}
+
+	// Валидация пользователя в UserService (если указан telegram_user_id)
+	if input.TelegramUserID != nil {
+		if err := s.validateUser(ctx, *input.TelegramUserID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Преобразуем в доменную модель
+	notification := input.ToDomainNotification()
+
+	// Язык не указан явно - берём язык получателя
+	locale, err := s.resolveLocale(ctx, input.Locale, input.TelegramUserID)
+	if err != nil {
+		return nil, err
+	}
+	notification.Locale = locale
+
+	// Проверяем шаблон и фиксируем его версию
+	if err := s.resolveTemplate(ctx, notification); err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+// applyPreferences проверяет настройки получателя и помечает уведомление пропущенным, если он отключил его тип
+// Уведомления в чат (без telegram_user_id) и обязательные уведомления о бронированиях не проверяются
+func (s *Service) applyPreferences(ctx context.Context, notification *domain.Notification) error {
+	if notification.TelegramUserID == nil {
+		return nil
+	}
+
+	reason, skip, err := s.preferences.SkipReason(ctx, *notification.TelegramUserID, notification.Type, domain.NotificationChannelTelegram)
+	if err != nil {
+		return fmt.Errorf("%w: applyPreferences - preferences error: %v", ErrInternal, err)
+	}
+
+	if skip {
+		notification.Status = domain.NotificationStatusSkipped
+		notification.SkipReason = &reason
+	}
+
+	return nil
+}
+
+// validateMedia проверяет, что файлы медиатеки из вложений существуют и могут быть отправлены вложением указанного типа
+func (s *Service) validateMedia(ctx context.Context, attachments domain.Attachments) error {
+	for i, attachment := range attachments {
+		if attachment.MediaID == "" {
+			continue
+		}
+
+		file, err := s.mediaService.Get(ctx, attachment.MediaID)
+		if err != nil {
+			if errors.Is(err, mediaSvc.ErrMediaNotFound) {
+				return fmt.Errorf("%w: attachments[%d]: media %s not found", ErrInvalidInput, i, attachment.MediaID)
+			}
+			return fmt.Errorf("%w: validateMedia - media service error: %v", ErrInternal, err)
+		}
+
+		if !file.CanSendAs(attachment.Type) {
+			return fmt.Errorf("%w: attachments[%d]: media %s is %s and cannot be sent as %s", ErrInvalidInput, i, file.ID, file.Type, attachment.Type)
+		}
+	}
+
+	return nil
+}
+
+// resolveTemplate проверяет содержимое уведомления и фиксирует версию шаблона
+// Пробный рендеринг выполняется сразу, чтобы ошибки в переменных были видны при создании, а не при отправке
+func (s *Service) resolveTemplate(ctx context.Context, notification *domain.Notification) error {
+	if err := notification.InlineButtons.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	if err := notification.Attachments.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	if err := notification.DeliveryOptions.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	if err := s.validateMedia(ctx, notification.Attachments); err != nil {
+		return err
+	}
+
+	// Уведомления о бронированиях без текста используют встроенный шаблон с именем типа
+	if !notification.HasTemplate() && strings.TrimSpace(notification.MessageText) == "" && notification.Type.IsBooking() {
+		if _, ok := notification.BookingID(); ok {
+			name := string(notification.Type)
+			notification.TemplateName = &name
+		}
+	}
+
+	if !notification.HasTemplate() {
+		if strings.TrimSpace(notification.MessageText) == "" {
+			return fmt.Errorf("%w: message_text or template is required", ErrInvalidInput)
+		}
+		return sanitizeText(notification)
+	}
+
+	var locale string
+	if notification.Locale != nil {
+		locale = *notification.Locale
+	}
+
+	// Вариант шаблона на языке получателя (или на языке по умолчанию)
+	template, err := s.templateService.GetLocalized(ctx, *notification.TemplateName, locale)
+	if err != nil {
+		if errors.Is(err, templates.ErrTemplateNotFound) {
+			return ErrTemplateNotFound
+		}
+		return fmt.Errorf("%w: resolveTemplate - template service error: %v", ErrInternal, err)
+	}
+
+	// Тип уведомления берётся из шаблона, если не указан явно
+	if notification.Type == "" {
+		notification.Type = template.Type
+	} else if notification.Type != template.Type {
+		return fmt.Errorf("%w: type %q does not match template type %q", ErrInvalidInput, notification.Type, template.Type)
+	}
+
+	vars, err := s.templateService.ResolveVariables(ctx, notification)
+	if err != nil {
+		if errors.Is(err, templates.ErrBookingNotFound) {
+			return ErrBookingNotFound
+		}
+		return fmt.Errorf("%w: resolveTemplate - template service error: %v", ErrInternal, err)
+	}
+
+	if _, err := s.templateService.Render(template, vars); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	// Фиксируем язык выбранного варианта: вместе с версией он однозначно определяет текст
+	notification.TemplateVersion = &template.Version
+	notification.Locale = &template.Locale
+	return nil
+}
+
+// batchPrototype возвращает проверенный прототип уведомления рассылки для языка
+// Прототипы кэшируются, чтобы шаблон и данные бронирования запрашивались один раз на язык
+func (s *Service) batchPrototype(ctx context.Context, input *models.CreateBatchNotificationInput, locale *string, cache map[string]*domain.Notification) (*domain.Notification, error) {
+	key := ""
+	if locale != nil {
+		key = *locale
+	}
+
+	if prototype, ok := cache[key]; ok {
+		return prototype, nil
+	}
+
+	prototype := &domain.Notification{
+		MessageText:       input.MessageText,
+		ParseMode:         input.ParseMode,
+		TemplateName:      input.TemplateName,
+		TemplateVariables: input.TemplateVariables,
+		Locale:            locale,
+		ImageURLs:         input.ImageURLs,
+		Attachments:       input.Attachments,
+		InlineButtons:     input.InlineButtons,
+		DeliveryOptions:   input.DeliveryOptions,
+		Type:              input.Type,
+		Metadata:          input.Metadata,
+	}
+	if err := s.resolveTemplate(ctx, prototype); err != nil {
+		return nil, err
+	}
+
+	cache[key] = prototype
+	return prototype, nil
+}
+
+// resolveLocale определяет язык уведомления
+// Явно переданный язык имеет приоритет, иначе используется язык получателя, сохранённый при /start
+func (s *Service) resolveLocale(ctx context.Context, locale *string, tgUserID *int64) (*string, error) {
+	if locale != nil {
+		normalized := domain.NormalizeLocale(*locale)
+		if normalized == "" {
+			return nil, fmt.Errorf("%w: locale cannot be empty", ErrInvalidInput)
+		}
+		return &normalized, nil
+	}
+
+	if tgUserID == nil {
+		return nil, nil
+	}
+
+	user, err := s.botUserRepo.GetByTgUserID(ctx, *tgUserID)
+	if err != nil {
+		if errors.Is(err, botUserRepo.ErrBotUserNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: resolveLocale - repository error: %v", ErrInternal, err)
+	}
+
+	if user.Locale() == "" {
+		return nil, nil
+	}
+
+	userLocale := user.Locale()
+	return &userLocale, nil
+}
+
+// sanitizeText проверяет режим парсинга и приводит HTML к подмножеству, которое принимает Telegram
+// Неподдерживаемые теги и одиночные "<", "&" экранируются, поэтому сообщение не будет отклонено при отправке
+func sanitizeText(notification *domain.Notification) error {
+	switch notification.GetParseMode() {
+	case domain.ParseModeHTML:
+		notification.MessageText = tghtml.Sanitize(notification.MessageText)
+		for i := range notification.Attachments {
+			notification.Attachments[i].Caption = tghtml.Sanitize(notification.Attachments[i].Caption)
+		}
+		return nil
+	case domain.ParseModePlain:
+		return nil
+	default:
+		return fmt.Errorf("%w: parse_mode must be %q or empty", ErrInvalidInput, domain.ParseModeHTML)
+	}
+}
+
 // validateUser проверяет существование пользователя в UserService
 func (s *Service) validateUser(ctx context.Context, tgUserID int64) error {
 	_, err := s.userServiceClient.GetUser(ctx, tgUserID)
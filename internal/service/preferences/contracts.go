@@ -0,0 +1,13 @@
+package preferences
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// PreferencesRepository интерфейс репозитория настроек уведомлений
+type PreferencesRepository interface {
+	GetByTgUserID(ctx context.Context, tgUserID int64) (*domain.UserPreferences, error)
+	Save(ctx context.Context, preferences *domain.UserPreferences) error
+}
@@ -0,0 +1,11 @@
+package preferences
+
+import "errors"
+
+var (
+	// ErrInvalidInput возвращается при некорректных изменениях настроек (неизвестный или обязательный тип)
+	ErrInvalidInput = errors.New("service.preferences: invalid input data")
+
+	// ErrInternal возвращается при внутренних ошибках сервиса
+	ErrInternal = errors.New("service.preferences: internal error")
+)
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/types"
+)
+
+// UpdatePreferencesInput изменения настроек уведомлений
+// Незаданные типы, каналы, часовой пояс и тихие часы остаются без изменений
+type UpdatePreferencesInput struct {
+	Types      map[domain.NotificationType]bool
+	Channels   map[domain.NotificationChannel]bool
+	TimeZone   *string          // Пустая строка сбрасывает часовой пояс на UTC
+	QuietHours *QuietHoursInput // Пустые границы отключают тихие часы
+}
+
+// QuietHoursInput границы тихих часов в формате HH:MM
+type QuietHoursInput struct {
+	Start types.TimeString
+	End   types.TimeString
+}
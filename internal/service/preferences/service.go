@@ -0,0 +1,116 @@
+package preferences
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	preferencesRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/preferences"
+	"github.com/m04kA/SMC-NotificationService/internal/service/preferences/models"
+)
+
+// Service сервис настроек уведомлений пользователей
+// Настройки меняются командой /settings и через API, проверяются при создании и отправке уведомлений
+type Service struct {
+	repo PreferencesRepository
+}
+
+// NewService создает новый экземпляр сервиса настроек уведомлений
+func NewService(repo PreferencesRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// Get возвращает настройки пользователя
+// Если пользователь их не менял, возвращаются настройки по умолчанию (все уведомления включены)
+func (s *Service) Get(ctx context.Context, tgUserID int64) (*domain.UserPreferences, error) {
+	preferences, err := s.repo.GetByTgUserID(ctx, tgUserID)
+	if err != nil {
+		if errors.Is(err, preferencesRepo.ErrPreferencesNotFound) {
+			return domain.NewUserPreferences(tgUserID), nil
+		}
+		return nil, fmt.Errorf("%w: Get - repository error: %v", ErrInternal, err)
+	}
+
+	return preferences, nil
+}
+
+// Update изменяет настройки пользователя
+// Отключение обязательного типа (уведомления о бронированиях), неизвестный часовой пояс
+// и некорректные тихие часы отклоняются
+func (s *Service) Update(ctx context.Context, tgUserID int64, input *models.UpdatePreferencesInput) (*domain.UserPreferences, error) {
+	preferences, err := s.Get(ctx, tgUserID)
+	if err != nil {
+		return nil, fmt.Errorf("Update - %w", err)
+	}
+
+	for t, enabled := range input.Types {
+		if err := preferences.SetType(t, enabled); err != nil {
+			return nil, fmt.Errorf("%w: types: %v", ErrInvalidInput, err)
+		}
+	}
+	for c, enabled := range input.Channels {
+		if err := preferences.SetChannel(c, enabled); err != nil {
+			return nil, fmt.Errorf("%w: channels: %v", ErrInvalidInput, err)
+		}
+	}
+	if input.TimeZone != nil {
+		if err := preferences.SetTimeZone(*input.TimeZone); err != nil {
+			return nil, fmt.Errorf("%w: time_zone: %v", ErrInvalidInput, err)
+		}
+	}
+	if input.QuietHours != nil {
+		if err := preferences.SetQuietHours(toDomainQuietHours(input.QuietHours)); err != nil {
+			return nil, fmt.Errorf("%w: quiet_hours: %v", ErrInvalidInput, err)
+		}
+	}
+
+	if err := s.repo.Save(ctx, preferences); err != nil {
+		return nil, fmt.Errorf("%w: Update - repository error: %v", ErrInternal, err)
+	}
+
+	return preferences, nil
+}
+
+// SkipReason проверяет, разрешил ли пользователь уведомления типа t в канале c
+// Возвращает причину пропуска и true, если уведомление отправлять не нужно
+func (s *Service) SkipReason(ctx context.Context, tgUserID int64, t domain.NotificationType, c domain.NotificationChannel) (domain.SkipReason, bool, error) {
+	// Обязательные уведомления не зависят от настроек - не обращаемся к БД
+	if t.IsMandatory() {
+		return "", false, nil
+	}
+
+	preferences, err := s.Get(ctx, tgUserID)
+	if err != nil {
+		return "", false, fmt.Errorf("SkipReason - %w", err)
+	}
+
+	reason, skip := preferences.SkipReason(t, c)
+	return reason, skip, nil
+}
+
+// QuietUntil проверяет, попадает ли момент now в тихие часы пользователя
+// Возвращает время конца тихих часов (UTC) и true, если уведомление типа t нужно отложить
+func (s *Service) QuietUntil(ctx context.Context, tgUserID int64, t domain.NotificationType, now time.Time) (time.Time, bool, error) {
+	// Срочные уведомления отправляются в любое время - не обращаемся к БД
+	if t.IsUrgent() {
+		return time.Time{}, false, nil
+	}
+
+	preferences, err := s.Get(ctx, tgUserID)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("QuietUntil - %w", err)
+	}
+
+	until, quiet := preferences.QuietUntil(t, now)
+	return until, quiet, nil
+}
+
+// toDomainQuietHours преобразует входные границы тихих часов (пустые границы - отключение)
+func toDomainQuietHours(input *models.QuietHoursInput) *domain.QuietHours {
+	if input.Start.IsZero() && input.End.IsZero() {
+		return nil
+	}
+	return &domain.QuietHours{Start: input.Start, End: input.End}
+}
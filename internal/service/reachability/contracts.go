@@ -0,0 +1,16 @@
+package reachability
+
+import (
+	"context"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// ReachabilityRepository интерфейс репозитория доступности чатов
+type ReachabilityRepository interface {
+	GetByChatID(ctx context.Context, chatID int64) (*domain.ChatReachability, error)
+	MarkUnreachable(ctx context.Context, chatID int64, reason domain.UnreachableReason, source domain.ReachabilitySource, errorMessage *string, at time.Time) error
+	MarkReachable(ctx context.Context, chatID int64, source domain.ReachabilitySource, at time.Time) (bool, error)
+	ListUnreachable(ctx context.Context, limit, offset int) ([]*domain.ChatReachability, error)
+}
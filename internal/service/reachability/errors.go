@@ -0,0 +1,8 @@
+package reachability
+
+import "errors"
+
+var (
+	// ErrInternal возвращается при внутренних ошибках сервиса
+	ErrInternal = errors.New("service.reachability: internal error")
+)
@@ -0,0 +1,73 @@
+package reachability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	reachabilityRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/reachability"
+)
+
+// Service сервис доступности чатов
+// Чат становится недоступным после ошибки 403 при отправке или обновления my_chat_member
+// и снова доступным после /start или возвращения бота в чат
+type Service struct {
+	repo ReachabilityRepository
+}
+
+// NewService создает новый экземпляр сервиса доступности чатов
+func NewService(repo ReachabilityRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// IsUnreachable проверяет, помечен ли чат недоступным
+// Чаты без сведений о доступности считаются доступными
+func (s *Service) IsUnreachable(ctx context.Context, chatID int64) (bool, error) {
+	reachability, err := s.repo.GetByChatID(ctx, chatID)
+	if err != nil {
+		if errors.Is(err, reachabilityRepo.ErrReachabilityNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("%w: IsUnreachable - repository error: %v", ErrInternal, err)
+	}
+
+	return !reachability.Reachable, nil
+}
+
+// MarkUnreachable помечает чат недоступным
+// errorMessage - описание ошибки Telegram (пустое для my_chat_member)
+func (s *Service) MarkUnreachable(ctx context.Context, chatID int64, reason domain.UnreachableReason, source domain.ReachabilitySource, errorMessage string) error {
+	var message *string
+	if errorMessage != "" {
+		message = &errorMessage
+	}
+
+	if err := s.repo.MarkUnreachable(ctx, chatID, reason, source, message, time.Now()); err != nil {
+		return fmt.Errorf("%w: MarkUnreachable - repository error: %v", ErrInternal, err)
+	}
+
+	return nil
+}
+
+// Restore восстанавливает доступность чата
+// Возвращает true, если чат был недоступен
+func (s *Service) Restore(ctx context.Context, chatID int64, source domain.ReachabilitySource) (bool, error) {
+	restored, err := s.repo.MarkReachable(ctx, chatID, source, time.Now())
+	if err != nil {
+		return false, fmt.Errorf("%w: Restore - repository error: %v", ErrInternal, err)
+	}
+
+	return restored, nil
+}
+
+// ListUnreachable возвращает недоступные чаты
+func (s *Service) ListUnreachable(ctx context.Context, limit, offset int) ([]*domain.ChatReachability, error) {
+	chats, err := s.repo.ListUnreachable(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListUnreachable - repository error: %v", ErrInternal, err)
+	}
+
+	return chats, nil
+}
@@ -0,0 +1,18 @@
+package support
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// SupportRepository интерфейс репозитория переписки с поддержкой
+type SupportRepository interface {
+	Create(ctx context.Context, message *domain.SupportMessage) error
+	CreateInbound(ctx context.Context, message *domain.SupportMessage) (bool, error)
+	GetInbound(ctx context.Context, tgUserID int64, userMessageID int) (*domain.SupportMessage, error)
+	SetOperatorMessage(ctx context.Context, id int64, operatorChatID int64, operatorMessageID int) error
+	GetByOperatorMessage(ctx context.Context, operatorChatID int64, operatorMessageID int) (*domain.SupportMessage, error)
+	ListMessages(ctx context.Context, tgUserID int64, limit, offset int) ([]*domain.SupportMessage, error)
+	ListConversations(ctx context.Context, limit, offset int) ([]*domain.SupportConversation, error)
+}
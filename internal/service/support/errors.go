@@ -0,0 +1,11 @@
+package support
+
+import "errors"
+
+var (
+	// ErrMessageNotFound возвращается, если сообщение чата операторов не относится к переписке с пользователем
+	ErrMessageNotFound = errors.New("service.support: message not found")
+
+	// ErrInternal возвращается при внутренних ошибках сервиса
+	ErrInternal = errors.New("service.support: internal error")
+)
@@ -0,0 +1,101 @@
+package support
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	supportRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/support"
+)
+
+// Service сервис переписки пользователей с поддержкой
+type Service struct {
+	repo SupportRepository
+}
+
+// NewService создает новый экземпляр сервиса поддержки
+func NewService(repo SupportRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// SaveInbound сохраняет сообщение пользователя боту
+// Повторно обработанное сообщение не сохраняется второй раз: возвращается сохранённая ранее запись
+// (с OperatorMessageID, если сообщение уже переслано операторам)
+func (s *Service) SaveInbound(ctx context.Context, tgUserID int64, userMessageID int, text string) (*domain.SupportMessage, error) {
+	message := &domain.SupportMessage{
+		TgUserID:      tgUserID,
+		Direction:     domain.SupportDirectionInbound,
+		Text:          text,
+		UserMessageID: &userMessageID,
+	}
+
+	created, err := s.repo.CreateInbound(ctx, message)
+	if err != nil {
+		return nil, fmt.Errorf("%w: SaveInbound - repository error: %v", ErrInternal, err)
+	}
+	if created {
+		return message, nil
+	}
+
+	existing, err := s.repo.GetInbound(ctx, tgUserID, userMessageID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: SaveInbound - get saved message: %v", ErrInternal, err)
+	}
+
+	return existing, nil
+}
+
+// SetForwarded запоминает сообщение, которым входящее сообщение переслано в чат операторов
+// Ответ оператора на это сообщение будет отправлен пользователю
+func (s *Service) SetForwarded(ctx context.Context, id int64, operatorChatID int64, operatorMessageID int) error {
+	if err := s.repo.SetOperatorMessage(ctx, id, operatorChatID, operatorMessageID); err != nil {
+		return fmt.Errorf("%w: SetForwarded - repository error: %v", ErrInternal, err)
+	}
+
+	return nil
+}
+
+// FindForwarded находит сообщение пользователя по пересланному в чат операторов сообщению
+func (s *Service) FindForwarded(ctx context.Context, operatorChatID int64, operatorMessageID int) (*domain.SupportMessage, error) {
+	message, err := s.repo.GetByOperatorMessage(ctx, operatorChatID, operatorMessageID)
+	if err != nil {
+		if errors.Is(err, supportRepo.ErrMessageNotFound) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, fmt.Errorf("%w: FindForwarded - repository error: %v", ErrInternal, err)
+	}
+
+	return message, nil
+}
+
+// SaveReply сохраняет ответ оператора, отправленный пользователю
+func (s *Service) SaveReply(ctx context.Context, reply *domain.SupportMessage) error {
+	reply.Direction = domain.SupportDirectionOutbound
+
+	if err := s.repo.Create(ctx, reply); err != nil {
+		return fmt.Errorf("%w: SaveReply - repository error: %v", ErrInternal, err)
+	}
+
+	return nil
+}
+
+// ListConversations возвращает переписки, начиная с недавно обновлённых
+func (s *Service) ListConversations(ctx context.Context, limit, offset int) ([]*domain.SupportConversation, error) {
+	conversations, err := s.repo.ListConversations(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListConversations - repository error: %v", ErrInternal, err)
+	}
+
+	return conversations, nil
+}
+
+// ListMessages возвращает сообщения переписки с пользователем, начиная с последних
+func (s *Service) ListMessages(ctx context.Context, tgUserID int64, limit, offset int) ([]*domain.SupportMessage, error) {
+	messages, err := s.repo.ListMessages(ctx, tgUserID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListMessages - repository error: %v", ErrInternal, err)
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,407 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// sendWithAttachments отправляет уведомление с вложениями
+// Файлы отправляются медиагруппами (или по одному), текст - подписью к первому файлу, если помещается
+// Точки на карте и контакты отправляются отдельными сообщениями в конце
+func (s *Service) sendWithAttachments(msg *domain.TelegramMessage) (domain.SentMessages, error) {
+	all := msg.AllAttachments()
+	groups := all.MediaGroups()
+
+	var sent domain.SentMessages
+	var err error
+	if len(groups) == 0 {
+		sent, err = s.sendTextMessage(msg, nil)
+	} else {
+		sent, err = s.sendFiles(msg, groups)
+	}
+	if err != nil {
+		return sent, err
+	}
+
+	for _, attachment := range all.Standalone() {
+		messageID, err := s.sendStandalone(msg.ChatID, attachment)
+		if err != nil {
+			return sent, err
+		}
+		sent = append(sent, domain.SentMessage{MessageID: messageID, Role: domain.SentMessageRoleStandalone})
+	}
+
+	return sent, nil
+}
+
+// sendFiles отправляет файлы по медиагруппам
+// Кнопки прикрепляются к единственному файлу, иначе отправляются отдельным сообщением (или с длинным текстом)
+func (s *Service) sendFiles(msg *domain.TelegramMessage, groups [][]domain.Attachment) (domain.SentMessages, error) {
+	captionFits := fitsCaption(msg)
+	singleFile := len(groups) == 1 && len(groups[0]) == 1
+
+	var sent domain.SentMessages
+	for i, group := range groups {
+		withText := i == 0 && captionFits
+
+		if len(group) == 1 {
+			fileMsg, err := s.sendFile(msg, group[0], withText, withText && singleFile)
+			if err != nil {
+				return sent, err
+			}
+			sent = append(sent, fileMsg)
+			continue
+		}
+
+		groupMsgs, err := s.sendFileGroup(msg, group, withText)
+		if err != nil {
+			return sent, err
+		}
+		sent = append(sent, groupMsgs...)
+	}
+
+	// Длинный текст отправляем отдельными сообщениями, кнопки - у последнего
+	if !captionFits {
+		return s.sendTextMessage(msg, sent)
+	}
+
+	if singleFile {
+		return sent, nil
+	}
+
+	return s.sendButtons(msg, sent)
+}
+
+// sendFile отправляет один файл
+// withText - подписью служит текст уведомления, withButtons - к файлу прикрепляются inline-кнопки
+func (s *Service) sendFile(msg *domain.TelegramMessage, attachment domain.Attachment, withText, withButtons bool) (domain.SentMessage, error) {
+	caption := attachment.Caption
+	if withText {
+		caption = msg.MessageText
+	}
+
+	var markup interface{}
+	withButtons = withButtons && msg.HasButtons()
+	if withButtons {
+		markup = s.buildInlineKeyboard(msg.InlineButtons)
+	}
+
+	sentinel := ErrSendAttachment
+	if attachment.Type == domain.AttachmentTypePhoto {
+		sentinel = ErrSendPhoto
+	}
+
+	send := func(useCache bool) (tgbotapi.Message, *preparedFile, error) {
+		file, err := s.prepareFile(attachment, useCache)
+		if err != nil {
+			return tgbotapi.Message{}, nil, err
+		}
+		defer file.close()
+
+		var chattable tgbotapi.Chattable
+		switch attachment.Type {
+		case domain.AttachmentTypePhoto:
+			cfg := tgbotapi.NewPhoto(msg.ChatID, file.data)
+			cfg.Caption, cfg.ParseMode, cfg.ReplyMarkup = caption, msg.ParseMode, markup
+			chattable = cfg
+		case domain.AttachmentTypeVideo:
+			cfg := tgbotapi.NewVideo(msg.ChatID, file.data)
+			cfg.Caption, cfg.ParseMode, cfg.ReplyMarkup = caption, msg.ParseMode, markup
+			chattable = cfg
+		case domain.AttachmentTypeDocument:
+			cfg := tgbotapi.NewDocument(msg.ChatID, file.data)
+			cfg.Caption, cfg.ParseMode, cfg.ReplyMarkup = caption, msg.ParseMode, markup
+			chattable = cfg
+		case domain.AttachmentTypeAudio:
+			cfg := tgbotapi.NewAudio(msg.ChatID, file.data)
+			cfg.Caption, cfg.ParseMode, cfg.ReplyMarkup = caption, msg.ParseMode, markup
+			chattable = cfg
+		default:
+			return tgbotapi.Message{}, nil, fmt.Errorf("%w: %s is not a file", ErrSendAttachment, attachment.Type)
+		}
+
+		sent, err := s.send(chattable)
+		return sent, file, err
+	}
+
+	sent, file, err := send(true)
+	if err != nil && file != nil && file.cached && isStaleFileID(err) {
+		// Сохранённый file_id больше не действителен - загружаем файл заново
+		s.files.Forget(file.key)
+		sent, file, err = send(false)
+	}
+	if err != nil {
+		if file == nil {
+			return domain.SentMessage{}, err
+		}
+		return domain.SentMessage{}, wrapSendError(sentinel, err)
+	}
+
+	s.rememberFile(file, sent)
+
+	role := domain.SentMessageRoleFile
+	if withText {
+		role = domain.SentMessageRoleCaption
+	}
+	return domain.SentMessage{MessageID: sent.MessageID, Role: role, HasKeyboard: withButtons}, nil
+}
+
+// sendFileGroup отправляет совместимые файлы одной медиагруппой
+func (s *Service) sendFileGroup(msg *domain.TelegramMessage, group []domain.Attachment, withText bool) (domain.SentMessages, error) {
+	send := func(useCache bool) ([]tgbotapi.Message, []*preparedFile, error) {
+		media := make([]interface{}, 0, len(group))
+		files := make([]*preparedFile, 0, len(group))
+		defer func() {
+			for _, file := range files {
+				file.close()
+			}
+		}()
+
+		for i, attachment := range group {
+			file, err := s.prepareFile(attachment, useCache)
+			if err != nil {
+				return nil, nil, err
+			}
+			files = append(files, file)
+
+			caption := attachment.Caption
+			if i == 0 && withText {
+				caption = msg.MessageText
+			}
+			media = append(media, inputMedia(attachment.Type, file.data, caption, msg.ParseMode))
+		}
+
+		sent, err := s.requestMediaGroup(tgbotapi.NewMediaGroup(msg.ChatID, media))
+		return sent, files, err
+	}
+
+	sent, files, err := send(true)
+	if err != nil && hasCachedFile(files) && isStaleFileID(err) {
+		// Telegram не сообщает, какой из file_id недействителен - загружаем заново все файлы группы
+		for _, file := range files {
+			if file.cached {
+				s.files.Forget(file.key)
+			}
+		}
+		sent, files, err = send(false)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(domain.SentMessages, 0, len(sent))
+	for i, m := range sent {
+		if i < len(files) {
+			s.rememberFile(files[i], m)
+		}
+
+		role := domain.SentMessageRoleFile
+		if i == 0 && withText {
+			role = domain.SentMessageRoleCaption
+		}
+		result = append(result, domain.SentMessage{MessageID: m.MessageID, Role: role})
+	}
+
+	return result, nil
+}
+
+// inputMedia создает элемент медиагруппы
+func inputMedia(attachmentType domain.AttachmentType, file tgbotapi.RequestFileData, caption, parseMode string) interface{} {
+	switch attachmentType {
+	case domain.AttachmentTypeVideo:
+		item := tgbotapi.NewInputMediaVideo(file)
+		item.Caption, item.ParseMode = caption, parseMode
+		return item
+	case domain.AttachmentTypeDocument:
+		item := tgbotapi.NewInputMediaDocument(file)
+		item.Caption, item.ParseMode = caption, parseMode
+		return item
+	case domain.AttachmentTypeAudio:
+		item := tgbotapi.NewInputMediaAudio(file)
+		item.Caption, item.ParseMode = caption, parseMode
+		return item
+	default:
+		item := tgbotapi.NewInputMediaPhoto(file)
+		item.Caption, item.ParseMode = caption, parseMode
+		return item
+	}
+}
+
+// sendStandalone отправляет точку на карте, место или контакт
+func (s *Service) sendStandalone(chatID int64, attachment domain.Attachment) (int, error) {
+	var chattable tgbotapi.Chattable
+	switch attachment.Type {
+	case domain.AttachmentTypeLocation:
+		chattable = tgbotapi.NewLocation(chatID, *attachment.Latitude, *attachment.Longitude)
+	case domain.AttachmentTypeVenue:
+		chattable = tgbotapi.NewVenue(chatID, attachment.Title, attachment.Address, *attachment.Latitude, *attachment.Longitude)
+	case domain.AttachmentTypeContact:
+		contact := tgbotapi.NewContact(chatID, attachment.PhoneNumber, attachment.FirstName)
+		contact.LastName = attachment.LastName
+		chattable = contact
+	default:
+		return 0, fmt.Errorf("%w: unsupported attachment type %s", ErrSendAttachment, attachment.Type)
+	}
+
+	sent, err := s.send(chattable)
+	if err != nil {
+		return 0, wrapSendError(ErrSendAttachment, err)
+	}
+
+	return sent.MessageID, nil
+}
+
+// preparedFile файл, подготовленный к отправке
+type preparedFile struct {
+	data   tgbotapi.RequestFileData
+	key    string                // Ключ кэша file_id; пустой, если файл не кэшируется
+	cached bool                  // Файл отправляется по file_id из кэша
+	kind   domain.AttachmentType // Тип отправки, для которого сохраняется file_id
+	close  func()
+}
+
+// prepareFile возвращает источник файла для Telegram
+// Если файл уже загружался, используется сохранённый file_id; useCache=false принудительно загружает файл заново.
+// Файл медиатеки загружается из blob-хранилища. Документ с filename загружается сервисом
+// и передаётся в Telegram под указанным именем, в остальных случаях Telegram сам скачивает файл по URL
+func (s *Service) prepareFile(attachment domain.Attachment, useCache bool) (*preparedFile, error) {
+	file := &preparedFile{kind: attachment.Type, close: func() {}}
+
+	// file_id передан клиентом - кэшировать нечего
+	if attachment.FileID != "" {
+		file.data = tgbotapi.FileID(attachment.FileID)
+		return file, nil
+	}
+
+	if attachment.MediaID != "" {
+		file.key = domain.TelegramFileMediaKey(attachment.Type, attachment.MediaID)
+		if attachment.Filename != "" {
+			file.key += "#" + attachment.Filename
+		}
+	} else {
+		file.key = domain.TelegramFileURLKey(attachment.Type, attachment.URL, attachment.Filename)
+	}
+
+	if useCache {
+		if fileID, ok := s.files.Get(file.key); ok {
+			file.data = tgbotapi.FileID(fileID)
+			file.cached = true
+			return file, nil
+		}
+	}
+
+	if attachment.MediaID != "" {
+		return s.prepareMedia(file, attachment)
+	}
+
+	if attachment.Filename == "" {
+		file.data = tgbotapi.FileURL(attachment.URL)
+		return file, nil
+	}
+
+	if s.dryRun {
+		file.data = tgbotapi.FileReader{Name: attachment.Filename, Reader: strings.NewReader("")}
+		return file, nil
+	}
+
+	resp, err := s.httpClient.Get(attachment.URL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: download %s: %v", ErrSendAttachment, attachment.URL, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: download %s: unexpected status %s", ErrSendAttachment, attachment.URL, resp.Status)
+	}
+
+	file.data = tgbotapi.FileReader{Name: attachment.Filename, Reader: resp.Body}
+	file.close = func() { resp.Body.Close() }
+	return file, nil
+}
+
+// prepareMedia открывает файл медиатеки для загрузки в Telegram
+// Файл передаётся под именем из медиатеки, если во вложении не указано другое
+func (s *Service) prepareMedia(file *preparedFile, attachment domain.Attachment) (*preparedFile, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), downloadTimeout)
+	media, content, err := s.media.Open(ctx, attachment.MediaID)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("%w: open media %s: %v", ErrSendAttachment, attachment.MediaID, err)
+	}
+
+	name := media.Filename
+	if attachment.Filename != "" {
+		name = attachment.Filename
+	}
+
+	if s.dryRun {
+		content.Close()
+		cancel()
+		file.data = tgbotapi.FileReader{Name: name, Reader: strings.NewReader("")}
+		return file, nil
+	}
+
+	file.data = tgbotapi.FileReader{Name: name, Reader: content}
+	file.close = func() {
+		content.Close()
+		cancel()
+	}
+	return file, nil
+}
+
+// rememberFile сохраняет file_id загруженного файла из отправленного сообщения
+func (s *Service) rememberFile(file *preparedFile, sent tgbotapi.Message) {
+	if file.key == "" || file.cached {
+		return
+	}
+
+	if fileID := sentFileID(sent, file.kind); fileID != "" {
+		s.files.Save(file.key, fileID, file.kind)
+	}
+}
+
+// sentFileID извлекает file_id файла из отправленного сообщения
+// Для фото берётся самый большой размер - он соответствует оригиналу
+func sentFileID(sent tgbotapi.Message, kind domain.AttachmentType) string {
+	switch kind {
+	case domain.AttachmentTypePhoto:
+		if len(sent.Photo) > 0 {
+			return sent.Photo[len(sent.Photo)-1].FileID
+		}
+	case domain.AttachmentTypeVideo:
+		if sent.Video != nil {
+			return sent.Video.FileID
+		}
+	case domain.AttachmentTypeDocument:
+		if sent.Document != nil {
+			return sent.Document.FileID
+		}
+	case domain.AttachmentTypeAudio:
+		if sent.Audio != nil {
+			return sent.Audio.FileID
+		}
+	}
+	return ""
+}
+
+// hasCachedFile проверяет, отправлялся ли хотя бы один файл по file_id из кэша
+func hasCachedFile(files []*preparedFile) bool {
+	for _, file := range files {
+		if file.cached {
+			return true
+		}
+	}
+	return false
+}
+
+// isStaleFileID проверяет, что Telegram отклонил file_id (файл удалён или бот сменил токен)
+func isStaleFileID(err error) bool {
+	text := err.Error()
+	return strings.Contains(text, "wrong file identifier") ||
+		strings.Contains(text, "wrong remote file identifier") ||
+		strings.Contains(text, "FILE_REFERENCE")
+}
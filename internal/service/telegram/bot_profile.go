@@ -0,0 +1,84 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// menuButtonWebApp кнопка меню, открывающая мини-приложение (MenuButtonWebApp)
+type menuButtonWebApp struct {
+	Type   string              `json:"type"`
+	Text   string              `json:"text"`
+	WebApp tgbotapi.WebAppInfo `json:"web_app"`
+}
+
+// SetMyCommands публикует список команд в меню бота
+// languageCode - язык пользователей, для которых действует список (пусто - для всех, у кого нет своего варианта)
+// Пустой список удаляет команды для этого языка
+func (s *Service) SetMyCommands(commands []domain.BotCommand, languageCode string) error {
+	scope := tgbotapi.NewBotCommandScopeDefault()
+
+	if len(commands) == 0 {
+		if _, err := s.bot.Request(tgbotapi.NewDeleteMyCommandsWithScopeAndLanguage(scope, languageCode)); err != nil {
+			return fmt.Errorf("%w: delete commands (language %q): %v", ErrSetCommands, languageCode, err)
+		}
+		return nil
+	}
+
+	botCommands := make([]tgbotapi.BotCommand, 0, len(commands))
+	for _, command := range commands {
+		botCommands = append(botCommands, tgbotapi.BotCommand{Command: command.Command, Description: command.Description})
+	}
+
+	if _, err := s.bot.Request(tgbotapi.NewSetMyCommandsWithScopeAndLanguage(scope, languageCode, botCommands...)); err != nil {
+		return fmt.Errorf("%w: language %q: %v", ErrSetCommands, languageCode, err)
+	}
+
+	return nil
+}
+
+// SetMyDescription устанавливает описание и краткое описание бота
+// tgbotapi не поддерживает setMyDescription и setMyShortDescription, поэтому запросы формируются вручную
+func (s *Service) SetMyDescription(description, shortDescription, languageCode string) error {
+	params := tgbotapi.Params{}
+	params["description"] = description
+	params.AddNonEmpty("language_code", languageCode)
+
+	if _, err := s.bot.MakeRequest("setMyDescription", params); err != nil {
+		return fmt.Errorf("%w: description (language %q): %v", ErrSetDescription, languageCode, err)
+	}
+
+	params = tgbotapi.Params{}
+	params["short_description"] = shortDescription
+	params.AddNonEmpty("language_code", languageCode)
+
+	if _, err := s.bot.MakeRequest("setMyShortDescription", params); err != nil {
+		return fmt.Errorf("%w: short description (language %q): %v", ErrSetDescription, languageCode, err)
+	}
+
+	return nil
+}
+
+// SetMenuButtonWebApp устанавливает кнопку меню всех личных чатов, открывающую мини-приложение
+func (s *Service) SetMenuButtonWebApp(text, webAppURL string) error {
+	button, err := json.Marshal(menuButtonWebApp{
+		Type:   "web_app",
+		Text:   text,
+		WebApp: tgbotapi.WebAppInfo{URL: webAppURL},
+	})
+	if err != nil {
+		return fmt.Errorf("%w: marshal menu button: %v", ErrSetMenuButton, err)
+	}
+
+	params := tgbotapi.Params{}
+	params["menu_button"] = string(button)
+
+	if _, err := s.bot.MakeRequest("setChatMenuButton", params); err != nil {
+		return fmt.Errorf("%w: %v", ErrSetMenuButton, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// IsChatAdmin проверяет, что пользователь - создатель или администратор группы (getChatMember)
+func (s *Service) IsChatAdmin(chatID, userID int64) (bool, error) {
+	if chatID == 0 {
+		return false, ErrInvalidChatID
+	}
+
+	resp, err := s.bot.Request(tgbotapi.GetChatMemberConfig{
+		ChatConfigWithUser: tgbotapi.ChatConfigWithUser{ChatID: chatID, UserID: userID},
+	})
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrGetChatMember, err)
+	}
+
+	var member tgbotapi.ChatMember
+	if err := json.Unmarshal(resp.Result, &member); err != nil {
+		return false, fmt.Errorf("%w: decode chat member: %v", ErrGetChatMember, err)
+	}
+
+	return member.IsCreator() || member.IsAdministrator(), nil
+}
@@ -0,0 +1,42 @@
+package telegram
+
+import (
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// SendContactRequest отправляет сообщение с клавиатурой из одной кнопки «поделиться номером телефона» (request_contact)
+// Клавиатура скрывается после нажатия; Telegram пришлёт контакт пользователя обычным сообщением
+func (s *Service) SendContactRequest(chatID int64, text, buttonText string) error {
+	if chatID == 0 {
+		return ErrInvalidChatID
+	}
+
+	keyboard := tgbotapi.NewOneTimeReplyKeyboard(
+		tgbotapi.NewKeyboardButtonRow(tgbotapi.NewKeyboardButtonContact(buttonText)),
+	)
+
+	message := tgbotapi.NewMessage(chatID, text)
+	message.ReplyMarkup = keyboard
+
+	if _, err := s.bot.Send(message); err != nil {
+		return wrapSendError(ErrSendMessage, err)
+	}
+
+	return nil
+}
+
+// SendRemoveKeyboard отправляет сообщение и убирает клавиатуру запроса контакта
+func (s *Service) SendRemoveKeyboard(chatID int64, text string) error {
+	if chatID == 0 {
+		return ErrInvalidChatID
+	}
+
+	message := tgbotapi.NewMessage(chatID, text)
+	message.ReplyMarkup = tgbotapi.NewRemoveKeyboard(true)
+
+	if _, err := s.bot.Send(message); err != nil {
+		return wrapSendError(ErrSendMessage, err)
+	}
+
+	return nil
+}
@@ -1,6 +1,12 @@
 package telegram
 
-import tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+import (
+	"context"
+	"io"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
 
 // BotAPI интерфейс для Telegram Bot API
 // Абстракция над tgbotapi.BotAPI для упрощения тестирования
@@ -11,6 +17,32 @@ type BotAPI interface {
 	// Request выполняет кастомный запрос к Telegram API
 	Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error)
 
+	// MakeRequest выполняет запрос к методу Telegram API с произвольными параметрами
+	// Нужен для методов, параметры которых tgbotapi не поддерживает (например, клавиатура с copy_text)
+	MakeRequest(endpoint string, params tgbotapi.Params) (*tgbotapi.APIResponse, error)
+
+	// UploadFiles выполняет запрос к методу Telegram API с загрузкой файлов
+	UploadFiles(endpoint string, params tgbotapi.Params, files []tgbotapi.RequestFile) (*tgbotapi.APIResponse, error)
+
 	// GetUpdatesChan возвращает канал для получения обновлений (long polling)
 	GetUpdatesChan(config tgbotapi.UpdateConfig) tgbotapi.UpdatesChannel
 }
+
+// FileCache кэш file_id загруженных в Telegram файлов
+// Позволяет не загружать повторно один и тот же файл при каждой отправке
+type FileCache interface {
+	// Get возвращает file_id по ключу кэша
+	Get(key string) (string, bool)
+
+	// Save запоминает file_id, полученный после первой загрузки файла
+	Save(key, fileID string, mediaType domain.AttachmentType)
+
+	// Forget удаляет file_id, который Telegram больше не принимает
+	Forget(key string)
+}
+
+// MediaStore медиатека: файлы, загруженные через API
+type MediaStore interface {
+	// Open возвращает описание файла и его содержимое
+	Open(ctx context.Context, id string) (*domain.Media, io.ReadCloser, error)
+}
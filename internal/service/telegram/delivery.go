@@ -0,0 +1,357 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// delivery параметры доставки сообщений одного уведомления
+// Без звука, защита от пересылки и ответ задаются полями конфигураций tgbotapi. message_thread_id и
+// link_preview_options tgbotapi не поддерживает: такие сообщения отправляются через MakeRequest (UploadFiles
+// для загружаемых файлов) с параметрами, собранными из конфигурации, - так же, как SetWebhook и SetMyDescription
+type delivery struct {
+	options *domain.DeliveryOptions
+	replied bool // Ответ на сообщение добавляется только к первому доставленному сообщению уведомления
+}
+
+// withDeliveryOptions возвращает сервис, сообщения которого отправляются с параметрами доставки
+func (s *Service) withDeliveryOptions(options *domain.DeliveryOptions) *Service {
+	if options == nil {
+		return s
+	}
+
+	return &Service{
+		bot:        s.bot,
+		files:      s.files,
+		media:      s.media,
+		welcome:    s.welcome,
+		httpClient: s.httpClient,
+		dryRun:     s.dryRun,
+		delivery:   &delivery{options: options},
+	}
+}
+
+// send отправляет сообщение с параметрами доставки уведомления
+func (s *Service) send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	if s.delivery == nil {
+		return s.bot.Send(c)
+	}
+
+	c = s.delivery.apply(c)
+
+	var (
+		message tgbotapi.Message
+		err     error
+	)
+	if s.delivery.needsParams(c) {
+		message, err = s.sendWithParams(c)
+	} else {
+		message, err = s.bot.Send(c)
+	}
+	if err != nil {
+		return message, err
+	}
+
+	s.delivery.replied = true
+	return message, nil
+}
+
+// sendMediaGroup отправляет медиагруппу с параметрами доставки уведомления
+func (s *Service) sendMediaGroup(config tgbotapi.MediaGroupConfig) (*tgbotapi.APIResponse, error) {
+	if s.delivery == nil {
+		return s.bot.Request(config)
+	}
+
+	config = s.delivery.apply(config).(tgbotapi.MediaGroupConfig)
+
+	var (
+		resp *tgbotapi.APIResponse
+		err  error
+	)
+	if s.delivery.needsParams(config) {
+		method, params, files, buildErr := s.delivery.params(config)
+		if buildErr != nil {
+			return nil, fmt.Errorf("delivery options: %w", buildErr)
+		}
+		resp, err = s.request(method, params, files)
+	} else {
+		resp, err = s.bot.Request(config)
+	}
+	if err != nil {
+		return resp, err
+	}
+
+	s.delivery.replied = true
+	return resp, nil
+}
+
+// sendWithParams отправляет сообщение запросом с параметрами, которые tgbotapi не поддерживает
+func (s *Service) sendWithParams(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	method, params, files, err := s.delivery.params(c)
+	if err != nil {
+		return tgbotapi.Message{}, fmt.Errorf("delivery options: %w", err)
+	}
+
+	resp, err := s.request(method, params, files)
+	if err != nil {
+		return tgbotapi.Message{}, err
+	}
+
+	var message tgbotapi.Message
+	if err := json.Unmarshal(resp.Result, &message); err != nil {
+		return tgbotapi.Message{}, fmt.Errorf("decode sent message: %w", err)
+	}
+
+	return message, nil
+}
+
+// request выполняет запрос к методу Bot API; файлы, которые нужно загрузить, отправляются multipart-запросом
+func (s *Service) request(method string, params tgbotapi.Params, files []tgbotapi.RequestFile) (*tgbotapi.APIResponse, error) {
+	if len(files) > 0 {
+		return s.bot.UploadFiles(method, params, files)
+	}
+	return s.bot.MakeRequest(method, params)
+}
+
+// apply задаёт параметры доставки, которые поддерживают конфигурации tgbotapi
+func (d *delivery) apply(c tgbotapi.Chattable) tgbotapi.Chattable {
+	switch config := c.(type) {
+	case tgbotapi.MessageConfig:
+		d.applyBaseChat(&config.BaseChat)
+		return config
+	case tgbotapi.PhotoConfig:
+		d.applyBaseChat(&config.BaseChat)
+		return config
+	case tgbotapi.VideoConfig:
+		d.applyBaseChat(&config.BaseChat)
+		return config
+	case tgbotapi.DocumentConfig:
+		d.applyBaseChat(&config.BaseChat)
+		return config
+	case tgbotapi.AudioConfig:
+		d.applyBaseChat(&config.BaseChat)
+		return config
+	case tgbotapi.LocationConfig:
+		d.applyBaseChat(&config.BaseChat)
+		return config
+	case tgbotapi.VenueConfig:
+		d.applyBaseChat(&config.BaseChat)
+		return config
+	case tgbotapi.ContactConfig:
+		d.applyBaseChat(&config.BaseChat)
+		return config
+	case tgbotapi.MediaGroupConfig:
+		config.DisableNotification = d.options.DisableNotification
+		config.ReplyToMessageID = d.replyTo()
+		return config
+	default:
+		return c
+	}
+}
+
+// applyBaseChat задаёт параметры доставки общей части конфигурации
+// Если сообщение, на которое отвечает уведомление, удалено, уведомление всё равно доставляется
+func (d *delivery) applyBaseChat(chat *tgbotapi.BaseChat) {
+	chat.DisableNotification = d.options.DisableNotification
+	chat.ProtectContent = d.options.ProtectContent
+	if replyTo := d.replyTo(); replyTo != 0 {
+		chat.ReplyToMessageID = replyTo
+		chat.AllowSendingWithoutReply = true
+	}
+}
+
+// replyTo возвращает ID сообщения, на которое нужно ответить, или 0, если ответ уже отправлен
+func (d *delivery) replyTo() int {
+	if d.options.ReplyToMessageID == nil || d.replied {
+		return 0
+	}
+	return *d.options.ReplyToMessageID
+}
+
+// needsParams проверяет, нужны ли сообщению параметры, которых нет в конфигурации tgbotapi
+func (d *delivery) needsParams(c tgbotapi.Chattable) bool {
+	if d.options.MessageThreadID != nil {
+		return true
+	}
+
+	switch config := c.(type) {
+	case tgbotapi.MessageConfig:
+		return d.options.LinkPreview != nil
+	case tgbotapi.MediaGroupConfig:
+		// У медиагруппы в tgbotapi нет protect_content и allow_sending_without_reply
+		return d.options.ProtectContent || config.ReplyToMessageID != 0
+	default:
+		return false
+	}
+}
+
+// params собирает параметры запроса из конфигурации и дополняет их параметрами доставки
+// Возвращает метод Bot API, параметры и файлы, которые нужно загрузить
+func (d *delivery) params(c tgbotapi.Chattable) (string, tgbotapi.Params, []tgbotapi.RequestFile, error) {
+	var (
+		method string
+		params tgbotapi.Params
+		files  []tgbotapi.RequestFile
+		err    error
+	)
+
+	switch config := c.(type) {
+	case tgbotapi.MessageConfig:
+		method = "sendMessage"
+		params, err = baseChatParams(config.BaseChat)
+		params["text"] = config.Text
+		params.AddNonEmpty("parse_mode", config.ParseMode)
+		if err == nil && d.options.LinkPreview != nil {
+			err = params.AddInterface("link_preview_options", d.options.LinkPreview)
+		}
+	case tgbotapi.PhotoConfig:
+		method = "sendPhoto"
+		params, files, err = fileParams(config.BaseFile, "photo", config.Caption, config.ParseMode)
+	case tgbotapi.VideoConfig:
+		method = "sendVideo"
+		params, files, err = fileParams(config.BaseFile, "video", config.Caption, config.ParseMode)
+	case tgbotapi.DocumentConfig:
+		method = "sendDocument"
+		params, files, err = fileParams(config.BaseFile, "document", config.Caption, config.ParseMode)
+	case tgbotapi.AudioConfig:
+		method = "sendAudio"
+		params, files, err = fileParams(config.BaseFile, "audio", config.Caption, config.ParseMode)
+	case tgbotapi.LocationConfig:
+		method = "sendLocation"
+		params, err = baseChatParams(config.BaseChat)
+		addCoordinates(params, config.Latitude, config.Longitude)
+	case tgbotapi.VenueConfig:
+		method = "sendVenue"
+		params, err = baseChatParams(config.BaseChat)
+		addCoordinates(params, config.Latitude, config.Longitude)
+		params["title"] = config.Title
+		params["address"] = config.Address
+	case tgbotapi.ContactConfig:
+		method = "sendContact"
+		params, err = baseChatParams(config.BaseChat)
+		params["phone_number"] = config.PhoneNumber
+		params["first_name"] = config.FirstName
+		params.AddNonEmpty("last_name", config.LastName)
+	case tgbotapi.MediaGroupConfig:
+		method = "sendMediaGroup"
+		params, files, err = d.mediaGroupParams(config)
+	default:
+		return "", nil, nil, fmt.Errorf("unsupported message type %T", c)
+	}
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	if d.options.MessageThreadID != nil {
+		params.AddNonZero("message_thread_id", *d.options.MessageThreadID)
+	}
+
+	return method, params, files, nil
+}
+
+// mediaGroupParams собирает параметры медиагруппы
+// Загружаемые файлы передаются частями file-N, в media на них ссылается attach://file-N
+func (d *delivery) mediaGroupParams(config tgbotapi.MediaGroupConfig) (tgbotapi.Params, []tgbotapi.RequestFile, error) {
+	params := make(tgbotapi.Params)
+	params.AddNonZero64("chat_id", config.ChatID)
+	params.AddBool("disable_notification", config.DisableNotification)
+	params.AddBool("protect_content", d.options.ProtectContent)
+	if config.ReplyToMessageID != 0 {
+		params.AddNonZero("reply_to_message_id", config.ReplyToMessageID)
+		params.AddBool("allow_sending_without_reply", true)
+	}
+
+	media := make([]interface{}, 0, len(config.Media))
+	var files []tgbotapi.RequestFile
+	for i, item := range config.Media {
+		name := fmt.Sprintf("file-%d", i)
+		attach := tgbotapi.FileID("attach://" + name)
+
+		var file tgbotapi.RequestFileData
+		switch m := item.(type) {
+		case tgbotapi.InputMediaPhoto:
+			file = m.Media
+			if file.NeedsUpload() {
+				m.Media = attach
+			}
+			item = m
+		case tgbotapi.InputMediaVideo:
+			file = m.Media
+			if file.NeedsUpload() {
+				m.Media = attach
+			}
+			item = m
+		case tgbotapi.InputMediaDocument:
+			file = m.Media
+			if file.NeedsUpload() {
+				m.Media = attach
+			}
+			item = m
+		case tgbotapi.InputMediaAudio:
+			file = m.Media
+			if file.NeedsUpload() {
+				m.Media = attach
+			}
+			item = m
+		default:
+			return nil, nil, fmt.Errorf("unsupported media group item %T", item)
+		}
+
+		if file.NeedsUpload() {
+			files = append(files, tgbotapi.RequestFile{Name: name, Data: file})
+		}
+		media = append(media, item)
+	}
+
+	if err := params.AddInterface("media", media); err != nil {
+		return nil, nil, err
+	}
+
+	return params, files, nil
+}
+
+// baseChatParams собирает общие параметры отправки из конфигурации tgbotapi
+func baseChatParams(chat tgbotapi.BaseChat) (tgbotapi.Params, error) {
+	params := make(tgbotapi.Params)
+
+	params.AddNonZero64("chat_id", chat.ChatID)
+	params.AddBool("disable_notification", chat.DisableNotification)
+	params.AddBool("protect_content", chat.ProtectContent)
+	params.AddNonZero("reply_to_message_id", chat.ReplyToMessageID)
+	params.AddBool("allow_sending_without_reply", chat.AllowSendingWithoutReply)
+
+	if err := params.AddInterface("reply_markup", chat.ReplyMarkup); err != nil {
+		return nil, err
+	}
+
+	return params, nil
+}
+
+// fileParams собирает параметры отправки файла с подписью
+// Файл, который нужно загрузить, возвращается отдельно; file_id и URL передаются параметром
+func fileParams(file tgbotapi.BaseFile, field, caption, parseMode string) (tgbotapi.Params, []tgbotapi.RequestFile, error) {
+	params, err := baseChatParams(file.BaseChat)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params.AddNonEmpty("caption", caption)
+	params.AddNonEmpty("parse_mode", parseMode)
+
+	if file.File.NeedsUpload() {
+		return params, []tgbotapi.RequestFile{{Name: field, Data: file.File}}, nil
+	}
+
+	params[field] = file.File.SendData()
+	return params, nil, nil
+}
+
+// addCoordinates добавляет координаты точки на карте (нулевые координаты допустимы)
+func addCoordinates(params tgbotapi.Params, latitude, longitude float64) {
+	params["latitude"] = strconv.FormatFloat(latitude, 'f', -1, 64)
+	params["longitude"] = strconv.FormatFloat(longitude, 'f', -1, 64)
+}
@@ -0,0 +1,98 @@
+package telegram
+
+import (
+	"encoding/json"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+func TestSendMessage_DeliveryOptions(t *testing.T) {
+	replyTo := 7
+	threadID := 12
+
+	tests := []struct {
+		name       string
+		options    *domain.DeliveryOptions
+		wantSent   bool
+		wantMethod string
+		wantParams tgbotapi.Params
+	}{
+		{
+			name:     "native fields",
+			options:  &domain.DeliveryOptions{DisableNotification: true, ProtectContent: true, ReplyToMessageID: &replyTo},
+			wantSent: true,
+		},
+		{
+			name:       "thread",
+			options:    &domain.DeliveryOptions{ReplyToMessageID: &replyTo, MessageThreadID: &threadID},
+			wantMethod: "sendMessage",
+			wantParams: tgbotapi.Params{
+				"chat_id":                     "42",
+				"text":                        "hello",
+				"reply_to_message_id":         "7",
+				"allow_sending_without_reply": "true",
+				"message_thread_id":           "12",
+			},
+		},
+		{
+			name:       "link preview",
+			options:    &domain.DeliveryOptions{LinkPreview: &domain.LinkPreviewOptions{IsDisabled: true}},
+			wantMethod: "sendMessage",
+			wantParams: tgbotapi.Params{
+				"chat_id":              "42",
+				"text":                 "hello",
+				"link_preview_options": `{"is_disabled":true}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bot := &recordingBot{}
+			service := NewService(bot, nil, nil, nil)
+
+			sent, err := service.SendMessage(&domain.TelegramMessage{ChatID: 42, MessageText: "hello", Options: tt.options})
+			require.NoError(t, err)
+			require.Len(t, sent, 1)
+
+			if tt.wantSent {
+				require.Len(t, bot.sent, 1)
+				msg := bot.sent[0].(tgbotapi.MessageConfig)
+				assert.True(t, msg.DisableNotification)
+				assert.True(t, msg.ProtectContent)
+				assert.Equal(t, replyTo, msg.ReplyToMessageID)
+				assert.True(t, msg.AllowSendingWithoutReply)
+				assert.Empty(t, bot.method)
+				return
+			}
+
+			assert.Empty(t, bot.sent)
+			assert.Equal(t, tt.wantMethod, bot.method)
+			assert.Equal(t, tt.wantParams, bot.params)
+		})
+	}
+}
+
+// recordingBot записывает отправленные конфигурации и запросы MakeRequest
+type recordingBot struct {
+	BotAPI
+	sent   []tgbotapi.Chattable
+	method string
+	params tgbotapi.Params
+}
+
+func (b *recordingBot) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	b.sent = append(b.sent, c)
+	return tgbotapi.Message{MessageID: 1}, nil
+}
+
+func (b *recordingBot) MakeRequest(endpoint string, params tgbotapi.Params) (*tgbotapi.APIResponse, error) {
+	b.method, b.params = endpoint, params
+	result, _ := json.Marshal(tgbotapi.Message{MessageID: 1})
+	return &tgbotapi.APIResponse{Ok: true, Result: result}, nil
+}
@@ -0,0 +1,191 @@
+package telegram
+
+import (
+	"fmt"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/tghtml"
+)
+
+// maxDeleteMessages максимальное количество сообщений в одном запросе deleteMessages
+const maxDeleteMessages = 100
+
+// EditSentMessages изменяет уже отправленные сообщения уведомления: текст (или подпись) и inline-кнопки
+// msg - новое содержимое уведомления; изменения применяются на месте, новые сообщения не отправляются.
+// Длинный текст раскладывается по уже отправленным частям, лишние части удаляются.
+// Возвращает сообщения уведомления после изменения
+func (s *Service) EditSentMessages(msg *domain.TelegramMessage, sent domain.SentMessages) (domain.SentMessages, error) {
+	if msg.ChatID == 0 {
+		return nil, ErrInvalidChatID
+	}
+
+	if msg.MessageText == "" {
+		return nil, ErrEmptyMessage
+	}
+
+	target := sent.TextMessage()
+	if target < 0 {
+		return nil, fmt.Errorf("%w: notification has no message with text", ErrNotEditable)
+	}
+
+	if sent[target].Role == domain.SentMessageRoleCaption {
+		return s.editCaption(msg, sent, target)
+	}
+
+	return s.editText(msg, sent, target)
+}
+
+// editCaption изменяет подпись файла и кнопки уведомления
+// Кнопки могут быть прикреплены к самому файлу или к отдельному сообщению после медиагруппы
+func (s *Service) editCaption(msg *domain.TelegramMessage, sent domain.SentMessages, target int) (domain.SentMessages, error) {
+	if !fitsCaption(msg) {
+		return nil, fmt.Errorf("%w: caption is limited to %d characters", ErrTextTooLong, tghtml.MaxCaptionLength)
+	}
+
+	keyboard := sent.KeyboardMessage()
+	if keyboard < 0 && msg.HasButtons() {
+		return nil, fmt.Errorf("%w: buttons can't be added to a message sent without them", ErrNotEditable)
+	}
+
+	params := s.editParams(msg.ChatID, sent[target].MessageID, keyboard == target, msg.InlineButtons)
+	params.AddNonEmpty("caption", msg.MessageText)
+	params.AddNonEmpty("parse_mode", msg.ParseMode)
+	if err := s.editRequest("editMessageCaption", params); err != nil {
+		return nil, err
+	}
+
+	if keyboard >= 0 && keyboard != target {
+		if err := s.editReplyMarkup(msg, sent[keyboard].MessageID); err != nil {
+			return nil, err
+		}
+	}
+
+	return sent, nil
+}
+
+// editText изменяет текстовые сообщения уведомления
+// Части нового текста записываются в уже отправленные текстовые сообщения по порядку,
+// кнопки прикрепляются к последней части, оставшиеся части удаляются
+func (s *Service) editText(msg *domain.TelegramMessage, sent domain.SentMessages, target int) (domain.SentMessages, error) {
+	// Части текста отправляются подряд
+	end := target
+	for end < len(sent) && sent[end].Role == domain.SentMessageRoleText {
+		end++
+	}
+
+	parts := tghtml.Split(msg.MessageText, tghtml.MaxMessageLength, msg.IsHTML())
+	if len(parts) > end-target {
+		return nil, fmt.Errorf("%w: text needs %d messages, only %d were sent", ErrTextTooLong, len(parts), end-target)
+	}
+
+	// Кнопки, отправленные отдельным сообщением, остаются на нём; иначе переезжают на последнюю часть
+	keyboard := sent.KeyboardMessage()
+	separateKeyboard := keyboard >= 0 && sent[keyboard].Role != domain.SentMessageRoleText
+	last := target + len(parts) - 1
+
+	for i, part := range parts {
+		index := target + i
+		withKeyboard := index == last && !separateKeyboard && (keyboard >= 0 || msg.HasButtons())
+
+		params := s.editParams(msg.ChatID, sent[index].MessageID, withKeyboard, msg.InlineButtons)
+		params.AddNonEmpty("text", part)
+		params.AddNonEmpty("parse_mode", msg.ParseMode)
+		if msg.Options != nil {
+			// Превью ссылок сохраняется таким, каким было при отправке
+			if err := params.AddInterface("link_preview_options", msg.Options.LinkPreview); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrEditMessage, err)
+			}
+		}
+		if err := s.editRequest("editMessageText", params); err != nil {
+			return nil, err
+		}
+	}
+
+	if separateKeyboard {
+		if err := s.editReplyMarkup(msg, sent[keyboard].MessageID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Удаляем части, которые больше не нужны
+	extra := sent[last+1 : end]
+	if len(extra) > 0 {
+		if err := s.DeleteSentMessages(msg.ChatID, extra); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make(domain.SentMessages, 0, len(sent)-len(extra))
+	for i, m := range sent {
+		if i > last && i < end {
+			continue
+		}
+		if m.Role == domain.SentMessageRoleText {
+			m.HasKeyboard = i == last && !separateKeyboard && msg.HasButtons()
+		}
+		result = append(result, m)
+	}
+
+	return result, nil
+}
+
+// editReplyMarkup заменяет inline-кнопки сообщения; пустой список кнопок убирает клавиатуру
+func (s *Service) editReplyMarkup(msg *domain.TelegramMessage, messageID int) error {
+	return s.editRequest("editMessageReplyMarkup", s.editParams(msg.ChatID, messageID, true, msg.InlineButtons))
+}
+
+// editParams формирует общие параметры методов редактирования
+func (s *Service) editParams(chatID int64, messageID int, withKeyboard bool, buttons domain.InlineButtons) tgbotapi.Params {
+	params := tgbotapi.Params{}
+	params.AddNonZero64("chat_id", chatID)
+	params.AddNonZero("message_id", messageID)
+
+	if withKeyboard {
+		// Ошибка сериализации клавиатуры невозможна: структура состоит из строк
+		_ = params.AddInterface("reply_markup", s.buildInlineKeyboard(buttons))
+	}
+
+	return params
+}
+
+// editRequest выполняет запрос редактирования
+// Отсутствие изменений (тот же текст и кнопки) не считается ошибкой
+func (s *Service) editRequest(method string, params tgbotapi.Params) error {
+	_, err := s.bot.MakeRequest(method, params)
+	if err != nil && !strings.Contains(err.Error(), "message is not modified") {
+		return wrapSendError(ErrEditMessage, err)
+	}
+	return nil
+}
+
+// DeleteSentMessages удаляет отправленные сообщения уведомления из чата
+// Telegram позволяет удалять сообщения бота не старше 48 часов
+func (s *Service) DeleteSentMessages(chatID int64, sent domain.SentMessages) error {
+	if chatID == 0 {
+		return ErrInvalidChatID
+	}
+
+	ids := sent.IDs()
+	for len(ids) > 0 {
+		batch := ids
+		if len(batch) > maxDeleteMessages {
+			batch = batch[:maxDeleteMessages]
+		}
+		ids = ids[len(batch):]
+
+		params := tgbotapi.Params{}
+		params.AddNonZero64("chat_id", chatID)
+		if err := params.AddInterface("message_ids", batch); err != nil {
+			return fmt.Errorf("%w: %v", ErrDeleteMessage, err)
+		}
+
+		if _, err := s.bot.MakeRequest("deleteMessages", params); err != nil {
+			return fmt.Errorf("%w: %v", ErrDeleteMessage, err)
+		}
+	}
+
+	return nil
+}
@@ -12,15 +12,53 @@ var (
 	// ErrSendMediaGroup возвращается при ошибке отправки media group
 	ErrSendMediaGroup = errors.New("service.telegram: failed to send media group")
 
+	// ErrSendAttachment возвращается при ошибке отправки вложения
+	ErrSendAttachment = errors.New("service.telegram: failed to send attachment")
+
+	// ErrParseEntities возвращается, когда Telegram не смог разобрать разметку текста ("can't parse entities")
+	ErrParseEntities = errors.New("service.telegram: can't parse message entities")
+
+	// ErrChatUnreachable возвращается, когда Telegram отказал в отправке с кодом 403
+	// (пользователь заблокировал бота, бота удалили из группы, аккаунт удалён)
+	ErrChatUnreachable = errors.New("service.telegram: chat is unreachable for the bot")
+
+	// ErrEditMessage возвращается при ошибке редактирования отправленного сообщения
+	ErrEditMessage = errors.New("service.telegram: failed to edit message")
+
+	// ErrDeleteMessage возвращается при ошибке удаления отправленных сообщений
+	ErrDeleteMessage = errors.New("service.telegram: failed to delete messages")
+
+	// ErrNotEditable возвращается, когда изменение нельзя применить к отправленным сообщениям
+	// (например, добавить кнопки к медиагруппе или изменить уведомление без текста)
+	ErrNotEditable = errors.New("service.telegram: sent messages can't be edited this way")
+
+	// ErrTextTooLong возвращается, когда новый текст не помещается в уже отправленные сообщения
+	ErrTextTooLong = errors.New("service.telegram: edited text doesn't fit into sent messages")
+
 	// ErrInvalidChatID возвращается при некорректном chat_id
 	ErrInvalidChatID = errors.New("service.telegram: invalid chat_id")
 
 	// ErrEmptyMessage возвращается при пустом тексте сообщения
 	ErrEmptyMessage = errors.New("service.telegram: message text is empty")
 
+	// ErrAnswerCallback возвращается при ошибке ответа на callback-запрос
+	ErrAnswerCallback = errors.New("service.telegram: failed to answer callback query")
+
+	// ErrGetChatMember возвращается при ошибке получения участника группы
+	ErrGetChatMember = errors.New("service.telegram: failed to get chat member")
+
 	// ErrSetWebhook возвращается при ошибке установки webhook
 	ErrSetWebhook = errors.New("service.telegram: failed to set webhook")
 
 	// ErrDeleteWebhook возвращается при ошибке удаления webhook
 	ErrDeleteWebhook = errors.New("service.telegram: failed to delete webhook")
+
+	// ErrSetCommands возвращается при ошибке публикации списка команд бота
+	ErrSetCommands = errors.New("service.telegram: failed to set bot commands")
+
+	// ErrSetDescription возвращается при ошибке установки описания бота
+	ErrSetDescription = errors.New("service.telegram: failed to set bot description")
+
+	// ErrSetMenuButton возвращается при ошибке установки кнопки меню
+	ErrSetMenuButton = errors.New("service.telegram: failed to set menu button")
 )
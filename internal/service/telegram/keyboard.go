@@ -0,0 +1,60 @@
+package telegram
+
+import (
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// inlineKeyboardMarkup inline-клавиатура в формате Telegram Bot API
+// Собственная структура нужна, потому что tgbotapi не поддерживает кнопки copy_text
+type inlineKeyboardMarkup struct {
+	InlineKeyboard [][]inlineKeyboardButton `json:"inline_keyboard"`
+}
+
+// inlineKeyboardButton кнопка tgbotapi, дополненная полями, которых нет в библиотеке
+type inlineKeyboardButton struct {
+	tgbotapi.InlineKeyboardButton
+	CopyText *copyTextButton `json:"copy_text,omitempty"`
+}
+
+// copyTextButton параметры кнопки копирования текста
+type copyTextButton struct {
+	Text string `json:"text"`
+}
+
+// buildInlineKeyboard создает inline-клавиатуру из массива кнопок
+// Кнопки раскладываются по рядам согласно полю Row
+func (s *Service) buildInlineKeyboard(buttons domain.InlineButtons) inlineKeyboardMarkup {
+	rows := buttons.Rows()
+	keyboard := make([][]inlineKeyboardButton, 0, len(rows))
+
+	for _, row := range rows {
+		keyboardRow := make([]inlineKeyboardButton, 0, len(row))
+		for _, btn := range row {
+			keyboardRow = append(keyboardRow, newInlineKeyboardButton(btn))
+		}
+		keyboard = append(keyboard, keyboardRow)
+	}
+
+	return inlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
+
+// newInlineKeyboardButton создает кнопку клавиатуры в зависимости от её типа
+func newInlineKeyboardButton(btn domain.InlineButton) inlineKeyboardButton {
+	switch btn.GetKind() {
+	case domain.ButtonKindWebApp:
+		return inlineKeyboardButton{InlineKeyboardButton: tgbotapi.NewInlineKeyboardButtonWebApp(btn.Text, tgbotapi.WebAppInfo{URL: btn.URL})}
+	case domain.ButtonKindCallback:
+		return inlineKeyboardButton{InlineKeyboardButton: tgbotapi.NewInlineKeyboardButtonData(btn.Text, btn.CallbackData)}
+	case domain.ButtonKindSwitchInlineQuery:
+		return inlineKeyboardButton{InlineKeyboardButton: tgbotapi.NewInlineKeyboardButtonSwitch(btn.Text, btn.SwitchInlineQuery)}
+	case domain.ButtonKindCopyText:
+		return inlineKeyboardButton{
+			InlineKeyboardButton: tgbotapi.InlineKeyboardButton{Text: btn.Text},
+			CopyText:             &copyTextButton{Text: btn.CopyText},
+		}
+	default:
+		return inlineKeyboardButton{InlineKeyboardButton: tgbotapi.NewInlineKeyboardButtonURL(btn.Text, btn.URL)}
+	}
+}
@@ -0,0 +1,133 @@
+package telegram
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// previewToken токен бота для предпросмотра: запросы не покидают сервис
+const previewToken = "preview"
+
+// maxPreviewForm ограничение памяти на разбор multipart-запроса при предпросмотре
+const maxPreviewForm = 1 << 20
+
+// Preview возвращает запросы к Telegram Bot API, которые выполнит SendMessage, не отправляя их
+// Сообщение проходит тот же путь, что и при отправке (разбиение текста, подписи, клавиатура, медиагруппы),
+// но HTTP-клиент бота записывает запросы вместо их выполнения. Файлы не скачиваются и не загружаются.
+func (s *Service) Preview(msg *domain.TelegramMessage) ([]domain.TelegramRequest, error) {
+	recorder := &previewClient{}
+
+	bot := &tgbotapi.BotAPI{Token: previewToken, Client: recorder}
+	bot.SetAPIEndpoint(tgbotapi.APIEndpoint)
+
+	preview := &Service{
+		bot:        bot,
+		files:      s.files,
+		media:      s.media,
+		welcome:    s.welcome,
+		httpClient: s.httpClient,
+		dryRun:     true,
+	}
+
+	if _, err := preview.SendMessage(msg); err != nil {
+		return nil, err
+	}
+
+	return recorder.requests, nil
+}
+
+// previewClient HTTP-клиент Bot API, который записывает запросы и отвечает как Telegram
+type previewClient struct {
+	requests      []domain.TelegramRequest
+	lastMessageID int
+}
+
+// Do записывает запрос и возвращает успешный ответ с выдуманными сообщениями
+func (c *previewClient) Do(req *http.Request) (*http.Response, error) {
+	request := domain.TelegramRequest{
+		Method: path.Base(req.URL.Path),
+		Params: make(map[string]interface{}),
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		if err := req.ParseMultipartForm(maxPreviewForm); err != nil {
+			return nil, fmt.Errorf("preview: parse multipart request: %w", err)
+		}
+		for field, values := range req.MultipartForm.Value {
+			request.Params[field] = previewValue(values[0])
+		}
+		for field, headers := range req.MultipartForm.File {
+			if request.Files == nil {
+				request.Files = make(map[string]string)
+			}
+			request.Files[field] = headers[0].Filename
+		}
+	} else {
+		if err := req.ParseForm(); err != nil {
+			return nil, fmt.Errorf("preview: parse request: %w", err)
+		}
+		for field, values := range req.PostForm {
+			request.Params[field] = previewValue(values[0])
+		}
+	}
+
+	c.requests = append(c.requests, request)
+
+	return c.respond(request)
+}
+
+// respond формирует ответ Telegram: медиагруппа возвращает массив сообщений, остальные методы - одно сообщение
+func (c *previewClient) respond(request domain.TelegramRequest) (*http.Response, error) {
+	count := 1
+	if media, ok := request.Params["media"].(json.RawMessage); ok {
+		var items []json.RawMessage
+		if err := json.Unmarshal(media, &items); err == nil && len(items) > 0 {
+			count = len(items)
+		}
+	}
+
+	messages := make([]tgbotapi.Message, 0, count)
+	for i := 0; i < count; i++ {
+		c.lastMessageID++
+		messages = append(messages, tgbotapi.Message{MessageID: c.lastMessageID})
+	}
+
+	var result interface{} = messages[0]
+	if request.Method == "sendMediaGroup" {
+		result = messages
+	}
+
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(tgbotapi.APIResponse{Ok: true, Result: encoded})
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}, nil
+}
+
+// previewValue раскрывает JSON-параметры (reply_markup, media, entities), остальные оставляет строками
+func previewValue(value string) interface{} {
+	trimmed := strings.TrimSpace(value)
+	if (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Valid([]byte(trimmed)) {
+		return json.RawMessage(trimmed)
+	}
+	return value
+}
@@ -1,235 +1,242 @@
 package telegram
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/m04kA/SMC-NotificationService/internal/domain"
 	"github.com/m04kA/SMC-NotificationService/internal/service/telegram/templates"
+	"github.com/m04kA/SMC-NotificationService/pkg/tghtml"
 )
 
+// downloadTimeout таймаут загрузки файла, который нужно отправить под другим именем
+const downloadTimeout = 60 * time.Second
+
+// buttonsMessageText текст сообщения с кнопками после медиагруппы: стрелка вверх указывает на MediaGroup
+const buttonsMessageText = "⬆️"
+
 // Service сервис для отправки сообщений через Telegram Bot API
 type Service struct {
-	bot BotAPI
+	bot        BotAPI
+	files      FileCache
+	media      MediaStore
+	welcome    *templates.Welcome
+	httpClient *http.Client // Загрузка файлов с указанным filename
+	dryRun     bool         // Предпросмотр: файлы не скачиваются и не читаются из медиатеки
+	delivery   *delivery    // Параметры доставки уведомления; nil - параметры по умолчанию
+
+	localKeys sync.Map // Путь к локальному файлу -> ключ кэша file_id (хэш содержимого)
 }
 
 // NewService создает новый экземпляр Telegram сервиса
-func NewService(bot BotAPI) *Service {
+// files - кэш file_id: повторные отправки одного файла не загружают его заново
+// media - медиатека, из которой загружаются файлы вложений с media_id
+// welcome - сценарий приветствия по команде /start
+func NewService(bot BotAPI, files FileCache, media MediaStore, welcome *templates.Welcome) *Service {
 	return &Service{
-		bot: bot,
+		bot:        bot,
+		files:      files,
+		media:      media,
+		welcome:    welcome,
+		httpClient: &http.Client{Timeout: downloadTimeout},
 	}
 }
 
 // SendMessage отправляет уведомление через Telegram Bot API
 // Автоматически определяет тип отправки (текст, фото, media group)
-func (s *Service) SendMessage(msg *domain.TelegramMessage) error {
+// Возвращает все отправленные сообщения (длинный текст разбивается на несколько) с их ролями,
+// чтобы уведомление можно было изменить или удалить после отправки
+func (s *Service) SendMessage(msg *domain.TelegramMessage) (domain.SentMessages, error) {
 	if msg.ChatID == 0 {
-		return ErrInvalidChatID
+		return nil, ErrInvalidChatID
 	}
 
 	if msg.MessageText == "" {
-		return ErrEmptyMessage
+		return nil, ErrEmptyMessage
 	}
 
-	// Если есть изображения
-	if msg.HasImages() {
-		// Если изображений > 1 - отправляем как MediaGroup
-		if msg.IsMediaGroup() {
-			return s.sendMediaGroup(msg)
-		}
-		// Одно изображение - отправляем как фото с caption
-		return s.sendPhoto(msg)
+	// Без звука, защита от пересылки, превью ссылок, ответ и тема форума
+	sender := s.withDeliveryOptions(msg.Options)
+
+	// Изображения, документы, видео, аудио, точки на карте и контакты
+	if msg.HasImages() || msg.HasAttachments() {
+		return sender.sendWithAttachments(msg)
 	}
 
-	// Текстовое сообщение с кнопками
-	return s.sendTextMessage(msg)
+	// Текстовое сообщение с кнопками; части, доставленные прошлой попыткой, не отправляются повторно
+	return sender.sendTextMessage(msg, msg.Delivered)
 }
 
 // sendTextMessage отправляет текстовое сообщение
-func (s *Service) sendTextMessage(msg *domain.TelegramMessage) error {
-	tgMsg := tgbotapi.NewMessage(msg.ChatID, msg.MessageText)
-	tgMsg.ParseMode = msg.ParseMode
+// Текст длиннее лимита Telegram разбивается на части, кнопки добавляются к последней части
+// sent - уже отправленные сообщения этого уведомления (например, фото перед длинным текстом);
+// части текста из sent пропускаются. При ошибке возвращаются и сообщения, доставленные до неё
+func (s *Service) sendTextMessage(msg *domain.TelegramMessage, sent domain.SentMessages) (domain.SentMessages, error) {
+	parts := tghtml.Split(msg.MessageText, tghtml.MaxMessageLength, msg.IsHTML())
+	plain := false
+
+	for i := sent.Count(domain.SentMessageRoleText); i < len(parts); i++ {
+		tgMsg := tgbotapi.NewMessage(msg.ChatID, parts[i])
+		tgMsg.ParseMode = msg.ParseMode
+		if plain {
+			tgMsg.Text, tgMsg.ParseMode = tghtml.StripTags(parts[i]), domain.ParseModePlain
+		}
 
-	// Добавляем inline-кнопки если есть
-	if msg.HasButtons() {
-		tgMsg.ReplyMarkup = s.buildInlineKeyboard(msg.InlineButtons)
-	}
+		// Добавляем inline-кнопки к последней части
+		withButtons := i == len(parts)-1 && msg.HasButtons()
+		if withButtons {
+			tgMsg.ReplyMarkup = s.buildInlineKeyboard(msg.InlineButtons)
+		}
 
-	_, err := s.bot.Send(tgMsg)
-	if err != nil {
-		return fmt.Errorf("%w: %v", ErrSendMessage, err)
+		result, err := s.send(tgMsg)
+
+		// Telegram не разобрал разметку части - эту и следующие части отправляем без форматирования,
+		// доставленные части не повторяются
+		if err != nil && !plain && msg.IsHTML() && isParseEntitiesError(err) {
+			plain = true
+			tgMsg.Text, tgMsg.ParseMode = tghtml.StripTags(parts[i]), domain.ParseModePlain
+			result, err = s.send(tgMsg)
+		}
+		if err != nil {
+			return sent, wrapSendError(ErrSendMessage, err)
+		}
+		sent = append(sent, domain.SentMessage{MessageID: result.MessageID, Role: domain.SentMessageRoleText, HasKeyboard: withButtons})
 	}
 
-	return nil
+	return sent, nil
 }
 
-// sendPhoto отправляет фото с caption и кнопками
-func (s *Service) sendPhoto(msg *domain.TelegramMessage) error {
-	if len(msg.ImageURLs) == 0 {
-		return fmt.Errorf("%w: no images provided", ErrSendPhoto)
+// sendButtons отправляет inline-кнопки отдельным сообщением после медиагруппы
+// Telegram не поддерживает inline-кнопки в MediaGroup
+func (s *Service) sendButtons(msg *domain.TelegramMessage, sent domain.SentMessages) (domain.SentMessages, error) {
+	if !msg.HasButtons() {
+		return sent, nil
 	}
 
-	photo := tgbotapi.NewPhoto(msg.ChatID, tgbotapi.FileURL(msg.ImageURLs[0]))
-	photo.Caption = msg.MessageText
-	photo.ParseMode = msg.ParseMode
-
-	// Добавляем inline-кнопки если есть
-	if msg.HasButtons() {
-		photo.ReplyMarkup = s.buildInlineKeyboard(msg.InlineButtons)
-	}
+	buttonMsg := tgbotapi.NewMessage(msg.ChatID, buttonsMessageText)
+	buttonMsg.ReplyMarkup = s.buildInlineKeyboard(msg.InlineButtons)
 
-	_, err := s.bot.Send(photo)
+	result, err := s.send(buttonMsg)
 	if err != nil {
-		return fmt.Errorf("%w: %v", ErrSendPhoto, err)
+		// Не критично - MediaGroup уже отправлена
+		return sent, fmt.Errorf("%w: media group sent but buttons failed: %v", ErrSendMessage, err)
 	}
 
-	return nil
+	return append(sent, domain.SentMessage{MessageID: result.MessageID, Role: domain.SentMessageRoleButtons, HasKeyboard: true}), nil
 }
 
-// sendMediaGroup отправляет группу изображений (2-10 штук)
-// Кнопки добавляются к последнему изображению
-func (s *Service) sendMediaGroup(msg *domain.TelegramMessage) error {
-	if len(msg.ImageURLs) < 2 {
-		return fmt.Errorf("%w: media group requires at least 2 images", ErrSendMediaGroup)
-	}
-
-	if len(msg.ImageURLs) > 10 {
-		return fmt.Errorf("%w: media group supports maximum 10 images", ErrSendMediaGroup)
-	}
-
-	var mediaGroup []interface{}
-
-	// Первое изображение с текстом
-	firstPhoto := tgbotapi.NewInputMediaPhoto(tgbotapi.FileURL(msg.ImageURLs[0]))
-	firstPhoto.Caption = msg.MessageText
-	firstPhoto.ParseMode = msg.ParseMode
-	mediaGroup = append(mediaGroup, firstPhoto)
-
-	// Остальные изображения без текста
-	for i := 1; i < len(msg.ImageURLs); i++ {
-		photo := tgbotapi.NewInputMediaPhoto(tgbotapi.FileURL(msg.ImageURLs[i]))
-		mediaGroup = append(mediaGroup, photo)
-	}
-
-	mediaGroupConfig := tgbotapi.NewMediaGroup(msg.ChatID, mediaGroup)
-
+// requestMediaGroup отправляет медиагруппу и возвращает её сообщения
+func (s *Service) requestMediaGroup(mediaGroupConfig tgbotapi.MediaGroupConfig) ([]tgbotapi.Message, error) {
 	// Используем Request вместо Send, так как MediaGroup возвращает массив сообщений
-	resp, err := s.bot.Request(mediaGroupConfig)
+	resp, err := s.sendMediaGroup(mediaGroupConfig)
 	if err != nil {
-		return fmt.Errorf("%w: %v", ErrSendMediaGroup, err)
+		return nil, wrapSendError(ErrSendMediaGroup, err)
 	}
 
 	// Проверяем успешность отправки
 	if !resp.Ok {
-		return fmt.Errorf("%w: telegram API error: %s", ErrSendMediaGroup, resp.Description)
+		return nil, fmt.Errorf("%w: telegram API error: %s", ErrSendMediaGroup, resp.Description)
 	}
 
-	// Если есть кнопки - отправляем отдельным сообщением
-	// Telegram не поддерживает inline-кнопки в MediaGroup
-	if msg.HasButtons() {
-		buttonMsg := tgbotapi.NewMessage(msg.ChatID, "⬆️") // Стрелка вверх указывает на MediaGroup
-		buttonMsg.ReplyMarkup = s.buildInlineKeyboard(msg.InlineButtons)
-
-		_, err := s.bot.Send(buttonMsg)
-		if err != nil {
-			// Не критично - MediaGroup уже отправлена
-			return fmt.Errorf("%w: media group sent but buttons failed: %v", ErrSendMessage, err)
-		}
+	var messages []tgbotapi.Message
+	if err := json.Unmarshal(resp.Result, &messages); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode sent messages: %v", ErrSendMediaGroup, err)
 	}
 
-	return nil
+	return messages, nil
 }
 
-// buildInlineKeyboard создает inline-клавиатуру из массива кнопок
-func (s *Service) buildInlineKeyboard(buttons []domain.InlineButton) tgbotapi.InlineKeyboardMarkup {
-	var rows [][]tgbotapi.InlineKeyboardButton
+// fitsCaption проверяет, помещается ли текст в подпись к фото
+func fitsCaption(msg *domain.TelegramMessage) bool {
+	return tghtml.Length(msg.MessageText) <= tghtml.MaxCaptionLength
+}
 
-	// Каждая кнопка на отдельной строке
-	for _, btn := range buttons {
-		row := tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonURL(btn.Text, btn.URL),
-		)
-		rows = append(rows, row)
+// wrapSendError оборачивает ошибку отправки
+// Ошибка разбора разметки дополнительно помечается ErrParseEntities, чтобы вызывающий код мог повторить отправку без форматирования
+// Отказ с кодом 403 помечается ErrChatUnreachable, чтобы вызывающий код перестал писать в чат;
+// исходная *tgbotapi.Error сохраняется в цепочке для UnreachableReason
+func wrapSendError(sentinel error, err error) error {
+	if isParseEntitiesError(err) {
+		return fmt.Errorf("%w: %w: %v", sentinel, ErrParseEntities, err)
 	}
 
-	return tgbotapi.NewInlineKeyboardMarkup(rows...)
-}
-
-// SendWelcomeMessage отправляет приветственное сообщение при команде /start
-// Отправляет медиагруппу из 3 изображений с текстом и кнопку в отдельном сообщении
-// tgUserID опционален - если передан nil, используется дефолтный URL без параметра
-func (s *Service) SendWelcomeMessage(chatID int64, tgUserID *int64) error {
-	// Пути к изображениям в правильном порядке
-	imageFiles := []string{
-		"./static/welcome/Step1.PNG",
-		"./static/welcome/Step2.PNG",
-		"./static/welcome/Step3.PNG",
-		"./static/welcome/Step4.PNG",
-		"./static/welcome/Step5.PNG",
+	var apiErr *tgbotapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusForbidden {
+		return fmt.Errorf("%w: %w: %w", sentinel, ErrChatUnreachable, err)
 	}
 
-	// Создаем медиагруппу
-	var mediaGroup []interface{}
+	return fmt.Errorf("%w: %v", sentinel, err)
+}
 
-	// Первое изображение с текстом приветствия
-	firstPhoto := tgbotapi.NewInputMediaPhoto(tgbotapi.FilePath(imageFiles[0]))
-	firstPhoto.Caption = templates.WelcomeMessageText
-	mediaGroup = append(mediaGroup, firstPhoto)
+// isParseEntitiesError проверяет, что Telegram не смог разобрать разметку текста
+func isParseEntitiesError(err error) bool {
+	return strings.Contains(err.Error(), "can't parse entities")
+}
 
-	// Остальные изображения без текста
-	for i := 1; i < len(imageFiles); i++ {
-		photo := tgbotapi.NewInputMediaPhoto(tgbotapi.FilePath(imageFiles[i]))
-		mediaGroup = append(mediaGroup, photo)
+// UnreachableReason определяет причину недоступности чата по ошибке отправки
+// Возвращает false, если ошибка не помечена ErrChatUnreachable
+// Причина определяется по описанию ответа Telegram с кодом 403; текст всей ошибки используется,
+// только если ответ Telegram не сохранился в цепочке
+func UnreachableReason(err error) (domain.UnreachableReason, bool) {
+	if !errors.Is(err, ErrChatUnreachable) {
+		return "", false
 	}
 
-	// Отправляем медиагруппу
-	mediaGroupConfig := tgbotapi.NewMediaGroup(chatID, mediaGroup)
-
-	// Используем Request вместо Send, так как MediaGroup возвращает массив сообщений
-	resp, err := s.bot.Request(mediaGroupConfig)
-	if err != nil {
-		return fmt.Errorf("%w: %v", ErrSendMediaGroup, err)
+	description := err.Error()
+	var apiErr *tgbotapi.Error
+	if errors.As(err, &apiErr) {
+		if apiErr.Code != http.StatusForbidden {
+			return "", false
+		}
+		description = apiErr.Message
 	}
 
-	// Проверяем успешность отправки
-	if !resp.Ok {
-		return fmt.Errorf("%w: telegram API error: %s", ErrSendMediaGroup, resp.Description)
+	description = strings.ToLower(description)
+	switch {
+	case strings.Contains(description, "blocked by the user"):
+		return domain.UnreachableReasonBlocked, true
+	case strings.Contains(description, "user is deactivated"):
+		return domain.UnreachableReasonDeactivated, true
+	case strings.Contains(description, "kicked"), strings.Contains(description, "not a member"):
+		return domain.UnreachableReasonKicked, true
+	default:
+		return domain.UnreachableReasonForbidden, true
 	}
+}
 
-	// Формируем URL кнопки
-	buttonURL := templates.WelcomeButtonBaseURL
-	if tgUserID != nil {
-		buttonURL = templates.GetWelcomeButtonURL(*tgUserID)
-	}
+// AnswerCallbackQuery отвечает на нажатие callback-кнопки
+// Без ответа клиент Telegram показывает индикатор загрузки на кнопке; showAlert показывает текст во всплывающем окне
+func (s *Service) AnswerCallbackQuery(callbackQueryID, text string, showAlert bool) error {
+	answer := tgbotapi.NewCallback(callbackQueryID, text)
+	answer.ShowAlert = showAlert
 
-	// Отправляем кнопку отдельным сообщением (Telegram не поддерживает inline-кнопки в MediaGroup)
-	buttonMsg := tgbotapi.NewMessage(chatID, "Нажмите на кнопку ниже, чтобы открыть приложение:")
-	buttonMsg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
-		tgbotapi.NewInlineKeyboardRow(
-			tgbotapi.NewInlineKeyboardButtonWebApp(templates.WelcomeButtonText, tgbotapi.WebAppInfo{
-				URL: buttonURL,
-			}),
-		),
-	)
-
-	_, err = s.bot.Send(buttonMsg)
-	if err != nil {
-		// Медиагруппа уже отправлена, ошибка кнопки не критична
-		return fmt.Errorf("%w: media group sent but button failed: %v", ErrSendMessage, err)
+	if _, err := s.bot.Request(answer); err != nil {
+		return fmt.Errorf("%w: %v", ErrAnswerCallback, err)
 	}
 
 	return nil
 }
 
 // SetWebhook устанавливает webhook URL для получения обновлений от Telegram
-func (s *Service) SetWebhook(webhookURL string) error {
-	webhook, err := tgbotapi.NewWebhook(webhookURL)
-	if err != nil {
-		return fmt.Errorf("%w: failed to create webhook config: %v", ErrSetWebhook, err)
+// secretToken Telegram передаёт в заголовке X-Telegram-Bot-Api-Secret-Token каждого запроса webhook
+// tgbotapi не поддерживает secret_token, поэтому запрос формируется вручную
+func (s *Service) SetWebhook(webhookURL, secretToken string) error {
+	if _, err := url.ParseRequestURI(webhookURL); err != nil {
+		return fmt.Errorf("%w: invalid webhook URL: %v", ErrSetWebhook, err)
 	}
 
-	_, err = s.bot.Request(webhook)
-	if err != nil {
+	params := tgbotapi.Params{}
+	params["url"] = webhookURL
+	params.AddNonEmpty("secret_token", secretToken)
+
+	if _, err := s.bot.MakeRequest("setWebhook", params); err != nil {
 		return fmt.Errorf("%w: %v", ErrSetWebhook, err)
 	}
 
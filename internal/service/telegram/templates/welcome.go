@@ -1,25 +1,284 @@
 package templates
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
 
 const (
-	// WelcomeMessageText приветственное сообщение при команде /start
-	WelcomeMessageText = `Добро пожаловать!
+	// userIDPlaceholder подстановка Telegram ID пользователя в URL кнопки
+	userIDPlaceholder = "{user_id}"
+
+	// startValuePlaceholder подстановка значения deep-link параметра /start в URL кнопки
+	startValuePlaceholder = "{start_value}"
 
-Для удобного доступа к нашему сервису, вы можете создать иконку приложения на главном экране вашего устройства.
-1. Нажать на три точки в правом верхнем углу и в выпадающем меню нажать «Добавить на экран домой»
-2. В открывшейся страницы нажать на значок поделиться
-3. Промотать всплывающее меню и нажать на кнопку «На экран Домой»
-`
+	// maxCaptionLength максимальная длина подписи к изображению
+	maxCaptionLength = 1024
 
-	// WelcomeButtonText текст кнопки в приветственном сообщении
-	WelcomeButtonText = "Открыть приложение"
+	// maxMessageLength максимальная длина текстового сообщения
+	maxMessageLength = 4096
 
-	// WelcomeButtonBaseURL базовый URL кнопки в приветственном сообщении (без query параметров)
-	WelcomeButtonBaseURL = "https://faberon24.vercel.app/index.html"
+	// sniffLength количество первых байт файла, по которым определяется его формат
+	sniffLength = 512
 )
 
-// GetWelcomeButtonURL возвращает URL кнопки с подставленным tgUserId
-func GetWelcomeButtonURL(tgUserID int64) string {
-	return fmt.Sprintf("%s?X-UserID=%d", WelcomeButtonBaseURL, tgUserID)
+// ErrInvalidWelcome возвращается, если сценарий приветствия настроен некорректно
+var ErrInvalidWelcome = errors.New("telegram.templates: invalid welcome flow")
+
+// WelcomeTexts тексты приветственного сообщения на одном языке
+type WelcomeTexts struct {
+	Caption      string // Подпись к изображениям (или текст сообщения, если изображений нет)
+	ButtonPrompt string // Текст сообщения с кнопкой, если кнопку нельзя прикрепить к изображениям
+	ButtonText   string // Текст кнопки
+}
+
+// WelcomeVariant вариант приветствия для вида deep-link ссылки
+// Незаданные URL кнопки и тексты берутся из основного сценария
+type WelcomeVariant struct {
+	ButtonURL string
+	Texts     map[string]WelcomeTexts
+}
+
+// Welcome сценарий приветствия по команде /start
+// Собирается из конфигурации и проверяется при запуске, чтобы ошибка настройки не проявлялась у каждого пользователя
+type Welcome struct {
+	media         []string                // Пути к изображениям в порядке отправки
+	buttonURL     string                  // Шаблон URL кнопки мини-приложения
+	texts         map[string]WelcomeTexts // Тексты по языкам
+	variants      map[domain.StartKind]WelcomeVariant
+	defaultLocale string
+}
+
+// NewWelcome создает сценарий приветствия и проверяет его
+// Изображения должны существовать и подходить для отправки фото, тексты - укладываться в ограничения Telegram,
+// для языка по умолчанию тексты обязательны. Варианты задаются по видам deep-link ссылок (company, booking, ref, source)
+func NewWelcome(media []string, buttonURL string, texts map[string]WelcomeTexts, variants map[string]WelcomeVariant, defaultLocale string) (*Welcome, error) {
+	if len(media) > domain.MaxMediaGroupSize {
+		return nil, fmt.Errorf("%w: at most %d images allowed", ErrInvalidWelcome, domain.MaxMediaGroupSize)
+	}
+	for _, path := range media {
+		if err := validateImage(path); err != nil {
+			return nil, fmt.Errorf("%w: image %s: %v", ErrInvalidWelcome, path, err)
+		}
+	}
+
+	if err := validateButtonURL(buttonURL); err != nil {
+		return nil, fmt.Errorf("%w: button url: %v", ErrInvalidWelcome, err)
+	}
+
+	normalized, err := normalizeTexts("texts", texts, len(media), defaultLocale)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedVariants := make(map[domain.StartKind]WelcomeVariant, len(variants))
+	for name, variant := range variants {
+		kind := domain.StartKind(name)
+		if !kind.IsValid() {
+			return nil, fmt.Errorf("%w: variants.%s: unknown deep-link kind", ErrInvalidWelcome, name)
+		}
+
+		if variant.ButtonURL != "" {
+			if err := validateButtonURL(variant.ButtonURL); err != nil {
+				return nil, fmt.Errorf("%w: variants.%s: button url: %v", ErrInvalidWelcome, name, err)
+			}
+		}
+
+		if len(variant.Texts) > 0 {
+			variant.Texts, err = normalizeTexts("variants."+name+".texts", variant.Texts, len(media), defaultLocale)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		normalizedVariants[kind] = variant
+	}
+
+	return &Welcome{
+		media:         media,
+		buttonURL:     buttonURL,
+		texts:         normalized,
+		variants:      normalizedVariants,
+		defaultLocale: defaultLocale,
+	}, nil
+}
+
+// normalizeTexts проверяет тексты приветствия и приводит языки к нормализованному виду
+func normalizeTexts(section string, texts map[string]WelcomeTexts, mediaCount int, defaultLocale string) (map[string]WelcomeTexts, error) {
+	captionLimit := maxCaptionLength
+	if mediaCount == 0 {
+		captionLimit = maxMessageLength
+	}
+
+	normalized := make(map[string]WelcomeTexts, len(texts))
+	for locale, t := range texts {
+		if strings.TrimSpace(t.Caption) == "" || strings.TrimSpace(t.ButtonText) == "" {
+			return nil, fmt.Errorf("%w: %s.%s: caption and button_text are required", ErrInvalidWelcome, section, locale)
+		}
+		if textLength(t.Caption) > captionLimit {
+			return nil, fmt.Errorf("%w: %s.%s: caption exceeds %d characters", ErrInvalidWelcome, section, locale, captionLimit)
+		}
+		// Кнопка отправляется отдельным сообщением после медиагруппы
+		if mediaCount > 1 && strings.TrimSpace(t.ButtonPrompt) == "" {
+			return nil, fmt.Errorf("%w: %s.%s: button_prompt is required with several images", ErrInvalidWelcome, section, locale)
+		}
+		normalized[domain.NormalizeLocale(locale)] = t
+	}
+
+	if _, ok := normalized[defaultLocale]; !ok {
+		return nil, fmt.Errorf("%w: %s for default locale %q are required", ErrInvalidWelcome, section, defaultLocale)
+	}
+
+	return normalized, nil
+}
+
+// Media возвращает пути к изображениям в порядке отправки
+func (w *Welcome) Media() []string {
+	return w.media
+}
+
+// Texts возвращает тексты приветственного сообщения для языка и deep-link ссылки
+// Если у вида ссылки нет своих текстов, используются основные; если нет перевода - язык по умолчанию
+func (w *Welcome) Texts(locale string, start *domain.StartPayload) WelcomeTexts {
+	texts := w.texts
+	if start != nil {
+		if variant, ok := w.variants[start.Kind]; ok && len(variant.Texts) > 0 {
+			texts = variant.Texts
+		}
+	}
+
+	if t, ok := texts[domain.NormalizeLocale(locale)]; ok {
+		return t
+	}
+	return texts[w.defaultLocale]
+}
+
+// ButtonURL возвращает URL кнопки с подставленными tgUserID и значением deep-link ссылки
+// URL берётся из варианта для вида ссылки, если он задан.
+// Параметры запроса с неизвестными подстановками ({user_id} без пользователя, {start_value} без ссылки) удаляются
+func (w *Welcome) ButtonURL(tgUserID *int64, start *domain.StartPayload) string {
+	pattern := w.buttonURL
+	if start != nil {
+		if variant, ok := w.variants[start.Kind]; ok && variant.ButtonURL != "" {
+			pattern = variant.ButtonURL
+		}
+	}
+
+	var unknown []string
+	if tgUserID != nil {
+		pattern = strings.ReplaceAll(pattern, userIDPlaceholder, fmt.Sprint(*tgUserID))
+	} else {
+		unknown = append(unknown, userIDPlaceholder)
+	}
+	if start != nil {
+		pattern = strings.ReplaceAll(pattern, startValuePlaceholder, url.QueryEscape(start.Value))
+	} else {
+		unknown = append(unknown, startValuePlaceholder)
+	}
+
+	return dropPlaceholderParams(pattern, unknown)
+}
+
+// dropPlaceholderParams удаляет параметры запроса, содержащие незаполненные подстановки
+func dropPlaceholderParams(pattern string, placeholders []string) string {
+	found := false
+	for _, placeholder := range placeholders {
+		if strings.Contains(pattern, placeholder) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return pattern
+	}
+
+	// Шаблон проверен при запуске: подстановки встречаются только в параметрах запроса
+	u, _ := url.Parse(pattern)
+	query := u.Query()
+	for key, values := range query {
+		for _, v := range values {
+			if containsAny(v, placeholders) {
+				query.Del(key)
+				break
+			}
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// containsAny проверяет, содержит ли строка хотя бы одну из подстрок
+func containsAny(s string, substrs []string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateImage проверяет, что файл существует и Telegram примет его как фото
+func validateImage(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return errors.New("not a regular file")
+	}
+	if info.Size() > domain.MaxMediaPhotoSize {
+		return fmt.Errorf("exceeds %d MB", domain.MaxMediaPhotoSize>>20)
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+	if contentType := http.DetectContentType(head[:n]); !domain.AttachmentTypePhoto.AcceptsContentType(contentType) {
+		return fmt.Errorf("unsupported format %s (JPEG, PNG or WebP expected)", contentType)
+	}
+
+	return nil
+}
+
+// validateButtonURL проверяет шаблон URL кнопки мини-приложения
+// Telegram открывает мини-приложения только по HTTPS
+func validateButtonURL(pattern string) error {
+	u, err := url.Parse(strings.NewReplacer(userIDPlaceholder, "0", startValuePlaceholder, "0").Replace(pattern))
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return errors.New("absolute https url expected")
+	}
+
+	raw, err := url.Parse(pattern)
+	if err != nil {
+		return err
+	}
+	if containsAny(raw.Scheme+raw.Host+raw.Path+raw.Fragment, []string{userIDPlaceholder, startValuePlaceholder}) {
+		return fmt.Errorf("%s and %s are supported only in query parameters", userIDPlaceholder, startValuePlaceholder)
+	}
+
+	return nil
+}
+
+// textLength возвращает длину текста в единицах, которыми Telegram ограничивает сообщения (UTF-16)
+func textLength(s string) int {
+	return len(utf16.Encode([]rune(s)))
 }
@@ -0,0 +1,158 @@
+package telegram
+
+import (
+	"fmt"
+	"os"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// SendWelcomeMessage отправляет приветственное сообщение при команде /start
+// Изображения, тексты и URL кнопки берутся из сценария приветствия:
+// без изображений - текст с кнопкой, одно изображение - фото с подписью и кнопкой,
+// несколько - медиагруппа с подписью и кнопка отдельным сообщением (Telegram не поддерживает inline-кнопки в MediaGroup)
+// tgUserID опционален - если передан nil, в URL кнопки не подставляется ID пользователя
+// locale - язык пользователя из Telegram; при отсутствии перевода используется язык по умолчанию
+// start - deep-link параметр /start (может быть nil): выбирает вариант текстов и URL кнопки
+func (s *Service) SendWelcomeMessage(chatID int64, tgUserID *int64, locale string, start *domain.StartPayload) error {
+	texts := s.welcome.Texts(locale, start)
+	media := s.welcome.Media()
+
+	markup := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonWebApp(texts.ButtonText, tgbotapi.WebAppInfo{
+				URL: s.welcome.ButtonURL(tgUserID, start),
+			}),
+		),
+	)
+
+	switch len(media) {
+	case 0:
+		msg := tgbotapi.NewMessage(chatID, texts.Caption)
+		msg.ReplyMarkup = markup
+		if _, err := s.bot.Send(msg); err != nil {
+			return fmt.Errorf("%w: %v", ErrSendMessage, err)
+		}
+		return nil
+	case 1:
+		return s.sendWelcomePhoto(chatID, media[0], texts.Caption, markup)
+	}
+
+	if err := s.sendWelcomeMediaGroup(chatID, media, texts.Caption); err != nil {
+		return err
+	}
+
+	buttonMsg := tgbotapi.NewMessage(chatID, texts.ButtonPrompt)
+	buttonMsg.ReplyMarkup = markup
+	if _, err := s.bot.Send(buttonMsg); err != nil {
+		// Медиагруппа уже отправлена, ошибка кнопки не критична
+		return fmt.Errorf("%w: media group sent but button failed: %v", ErrSendMessage, err)
+	}
+
+	return nil
+}
+
+// sendWelcomePhoto отправляет единственное изображение приветствия с подписью и кнопкой
+func (s *Service) sendWelcomePhoto(chatID int64, path, caption string, markup tgbotapi.InlineKeyboardMarkup) error {
+	send := func(useCache bool) (tgbotapi.Message, *preparedFile, error) {
+		file, err := s.prepareLocalFile(path, useCache)
+		if err != nil {
+			return tgbotapi.Message{}, nil, err
+		}
+
+		photo := tgbotapi.NewPhoto(chatID, file.data)
+		photo.Caption = caption
+		photo.ReplyMarkup = markup
+
+		sent, err := s.bot.Send(photo)
+		if err != nil {
+			err = fmt.Errorf("%w: %v", ErrSendPhoto, err)
+		}
+		return sent, file, err
+	}
+
+	sent, file, err := send(true)
+	if err != nil && file != nil && file.cached && isStaleFileID(err) {
+		s.files.Forget(file.key)
+		sent, file, err = send(false)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.rememberFile(file, sent)
+	return nil
+}
+
+// sendWelcomeMediaGroup отправляет изображения приветствия медиагруппой, подпись - у первого изображения
+// Изображения загружаются в Telegram один раз, дальше отправляются по file_id
+func (s *Service) sendWelcomeMediaGroup(chatID int64, media []string, caption string) error {
+	send := func(useCache bool) ([]tgbotapi.Message, []*preparedFile, error) {
+		var mediaGroup []interface{}
+		var files []*preparedFile
+		for i, path := range media {
+			file, err := s.prepareLocalFile(path, useCache)
+			if err != nil {
+				return nil, nil, err
+			}
+			files = append(files, file)
+
+			photo := tgbotapi.NewInputMediaPhoto(file.data)
+			if i == 0 {
+				photo.Caption = caption
+			}
+			mediaGroup = append(mediaGroup, photo)
+		}
+
+		sent, err := s.requestMediaGroup(tgbotapi.NewMediaGroup(chatID, mediaGroup))
+		return sent, files, err
+	}
+
+	sent, files, err := send(true)
+	if err != nil && hasCachedFile(files) && isStaleFileID(err) {
+		for _, file := range files {
+			if file.cached {
+				s.files.Forget(file.key)
+			}
+		}
+		sent, files, err = send(false)
+	}
+	if err != nil {
+		return err
+	}
+
+	for i, m := range sent {
+		if i < len(files) {
+			s.rememberFile(files[i], m)
+		}
+	}
+	return nil
+}
+
+// prepareLocalFile возвращает источник локального изображения для Telegram
+// Ключ кэша - хэш содержимого: после замены файла на диске и перезапуска изображение будет загружено заново
+func (s *Service) prepareLocalFile(path string, useCache bool) (*preparedFile, error) {
+	file := &preparedFile{kind: domain.AttachmentTypePhoto, close: func() {}}
+
+	key, ok := s.localKeys.Load(path)
+	if !ok {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: read %s: %v", ErrSendPhoto, path, err)
+		}
+		key, _ = s.localKeys.LoadOrStore(path, domain.TelegramFileContentKey(domain.AttachmentTypePhoto, content))
+	}
+	file.key = key.(string)
+
+	if useCache {
+		if fileID, ok := s.files.Get(file.key); ok {
+			file.data = tgbotapi.FileID(fileID)
+			file.cached = true
+			return file, nil
+		}
+	}
+
+	file.data = tgbotapi.FilePath(path)
+	return file, nil
+}
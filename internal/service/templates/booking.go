@@ -0,0 +1,129 @@
+package templates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/bookingservice"
+)
+
+// Переменные, доступные во встроенных шаблонах бронирований
+const (
+	VarBookingID          = "booking_id"
+	VarBookingDate        = "booking_date"
+	VarStartTime          = "start_time"
+	VarEndTime            = "end_time"
+	VarServiceName        = "service_name"
+	VarServicePrice       = "service_price"
+	VarCar                = "car"
+	VarAddress            = "address"
+	VarNotes              = "notes"
+	VarCancellationReason = "cancellation_reason"
+	VarStatus             = "status"
+	VarBookingURL         = "booking_url"
+)
+
+// metadataKeyAddress ключ metadata с текстом адреса
+// BookingService возвращает только addressId, поэтому адрес передаёт вызывающая сторона
+const metadataKeyAddress = "address"
+
+// Форматы дат BookingService и сообщений
+const (
+	bookingDateLayout = "2006-01-02"
+	bookingTimeLayout = "15:04"
+	messageDateLayout = "02.01.2006"
+)
+
+// ResolveVariables собирает переменные для рендеринга уведомления
+// Для уведомлений о бронированиях с metadata.booking_id данные подтягиваются из BookingService,
+// явно переданные переменные имеют приоритет
+func (s *Service) ResolveVariables(ctx context.Context, notification *domain.Notification) (domain.Metadata, error) {
+	bookingID, ok := notification.BookingID()
+	if !notification.Type.IsBooking() || !ok || s.bookingClient == nil {
+		return notification.TemplateVariables, nil
+	}
+
+	userID := s.serviceUserID
+	if notification.TelegramUserID != nil {
+		userID = *notification.TelegramUserID
+	}
+
+	booking, err := s.bookingClient.GetBooking(ctx, bookingID, userID)
+	if err != nil {
+		if errors.Is(err, bookingservice.ErrBookingNotFound) {
+			return nil, ErrBookingNotFound
+		}
+		return nil, fmt.Errorf("%w: ResolveVariables - bookingservice error: %v", ErrInternal, err)
+	}
+
+	vars := s.bookingVariables(booking, userID)
+	if address, ok := notification.Metadata.GetString(metadataKeyAddress); ok {
+		vars[VarAddress] = address
+	}
+
+	for key, value := range notification.TemplateVariables {
+		vars[key] = value
+	}
+
+	return vars, nil
+}
+
+// bookingVariables преобразует бронирование в переменные шаблона
+func (s *Service) bookingVariables(booking *bookingservice.Booking, userID int64) domain.Metadata {
+	vars := domain.Metadata{
+		VarBookingID:          booking.ID,
+		VarBookingDate:        booking.BookingDate,
+		VarStartTime:          booking.StartTime,
+		VarEndTime:            "",
+		VarServiceName:        booking.ServiceName,
+		VarServicePrice:       strconv.FormatFloat(booking.ServicePrice, 'f', -1, 64),
+		VarCar:                formatCar(booking),
+		VarAddress:            "",
+		VarNotes:              derefString(booking.Notes),
+		VarCancellationReason: derefString(booking.CancellationReason),
+		VarStatus:             string(booking.Status),
+		VarBookingURL:         s.bookingURL(booking.ID, userID),
+	}
+
+	if date, err := time.Parse(bookingDateLayout, booking.BookingDate); err == nil {
+		vars[VarBookingDate] = date.Format(messageDateLayout)
+	}
+
+	if start, err := time.Parse(bookingTimeLayout, booking.StartTime); err == nil {
+		vars[VarEndTime] = start.Add(time.Duration(booking.DurationMinutes) * time.Minute).Format(bookingTimeLayout)
+	}
+
+	return vars
+}
+
+// bookingURL формирует ссылку на бронирование в мини-приложении
+func (s *Service) bookingURL(bookingID, userID int64) string {
+	return strings.NewReplacer(
+		"{booking_id}", strconv.FormatInt(bookingID, 10),
+		"{user_id}", strconv.FormatInt(userID, 10),
+	).Replace(s.bookingURLPattern)
+}
+
+// formatCar собирает описание автомобиля: марка, модель и госномер
+func formatCar(booking *bookingservice.Booking) string {
+	parts := make([]string, 0, 3)
+	for _, part := range []*string{booking.CarBrand, booking.CarModel, booking.CarLicensePlate} {
+		if part != nil && *part != "" {
+			parts = append(parts, *part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// derefString возвращает значение строки или пустую строку для nil
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
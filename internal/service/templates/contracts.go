@@ -0,0 +1,24 @@
+package templates
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	templateRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/template"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/bookingservice"
+)
+
+// TemplateRepository интерфейс репозитория шаблонов
+type TemplateRepository interface {
+	CreateVersion(ctx context.Context, template *domain.Template) error
+	GetLatest(ctx context.Context, name, locale string) (*domain.Template, error)
+	GetVersion(ctx context.Context, name, locale string, version int) (*domain.Template, error)
+	List(ctx context.Context, filter templateRepo.ListFilter) ([]*domain.Template, error)
+	ListVersions(ctx context.Context, name string, locale *string) ([]*domain.Template, error)
+	Delete(ctx context.Context, name string, locale *string) error
+}
+
+// BookingClient интерфейс клиента BookingService
+type BookingClient interface {
+	GetBooking(ctx context.Context, bookingID int64, userID int64) (*bookingservice.Booking, error)
+}
@@ -0,0 +1,26 @@
+package templates
+
+import "errors"
+
+var (
+	// ErrTemplateNotFound возвращается, когда шаблон не найден
+	ErrTemplateNotFound = errors.New("service.templates: template not found")
+
+	// ErrTemplateAlreadyExists возвращается при создании шаблона с занятым именем
+	ErrTemplateAlreadyExists = errors.New("service.templates: template already exists")
+
+	// ErrInvalidInput возвращается при некорректных входных данных
+	ErrInvalidInput = errors.New("service.templates: invalid input data")
+
+	// ErrInvalidTemplate возвращается, когда шаблон не компилируется
+	ErrInvalidTemplate = errors.New("service.templates: invalid template syntax")
+
+	// ErrRender возвращается при ошибке рендеринга шаблона (например, не передана переменная)
+	ErrRender = errors.New("service.templates: failed to render template")
+
+	// ErrBookingNotFound возвращается, когда бронирование из metadata.booking_id не найдено в BookingService
+	ErrBookingNotFound = errors.New("service.templates: booking not found")
+
+	// ErrInternal возвращается при внутренних ошибках сервиса
+	ErrInternal = errors.New("service.templates: internal error")
+)
@@ -0,0 +1,32 @@
+package models
+
+import "github.com/m04kA/SMC-NotificationService/internal/domain"
+
+// CreateTemplateInput входные данные для создания шаблона
+type CreateTemplateInput struct {
+	Name          string
+	Locale        string // Пустое значение - язык по умолчанию
+	Type          domain.NotificationType
+	ParseMode     string
+	Text          string
+	InlineButtons []domain.InlineButton
+	Description   *string
+}
+
+// UpdateTemplateInput входные данные для создания новой версии шаблона
+type UpdateTemplateInput struct {
+	Locale        string // Пустое значение - язык по умолчанию
+	Type          domain.NotificationType
+	ParseMode     string
+	Text          string
+	InlineButtons []domain.InlineButton
+	Description   *string
+}
+
+// ListTemplatesFilter фильтр для получения списка шаблонов
+type ListTemplatesFilter struct {
+	Type   *domain.NotificationType
+	Locale *string
+	Limit  int
+	Offset int
+}
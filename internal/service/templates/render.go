@@ -0,0 +1,134 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/tghtml"
+)
+
+// missingKeyOption запрещает рендеринг при отсутствии переменной
+// Без этой опции text/template подставляет "<no value>", и сообщение уходит пользователю с мусором
+const missingKeyOption = "missingkey=error"
+
+// executor общий интерфейс для скомпилированных text/template и html/template
+type executor interface {
+	Execute(wr io.Writer, data interface{}) error
+}
+
+// compileText компилирует шаблон текста сообщения
+// Для parse_mode=HTML используется html/template: переменные экранируются,
+// поэтому значения вида "<b>" или "&" не ломают разметку Telegram
+func compileText(name, text, parseMode string) (executor, error) {
+	if parseMode == domain.ParseModeHTML {
+		return htmltemplate.New(name).Option(missingKeyOption).Parse(text)
+	}
+
+	return compilePlain(name, text)
+}
+
+// compilePlain компилирует шаблон без экранирования (текст и URL кнопок)
+func compilePlain(name, text string) (executor, error) {
+	return texttemplate.New(name).Option(missingKeyOption).Parse(text)
+}
+
+// Validate проверяет синтаксис шаблона текста и кнопок без рендеринга
+func Validate(template *domain.Template) error {
+	if _, err := compileText(template.Name, template.Text, template.ParseMode); err != nil {
+		return fmt.Errorf("%w: text: %v", ErrInvalidTemplate, err)
+	}
+
+	for i, btn := range template.InlineButtons {
+		for field, value := range buttonFields(&btn) {
+			if _, err := compilePlain(template.Name, *value); err != nil {
+				return fmt.Errorf("%w: inline_buttons[%d].%s: %v", ErrInvalidTemplate, i, field, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Render рендерит шаблон с переданными переменными
+func Render(template *domain.Template, vars domain.Metadata) (*domain.RenderedMessage, error) {
+	// Пустая карта вместо nil, чтобы missingkey=error срабатывал на отсутствующих переменных
+	data := map[string]interface{}(vars)
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+
+	textExec, err := compileText(template.Name, template.Text, template.ParseMode)
+	if err != nil {
+		return nil, fmt.Errorf("%w: text: %v", ErrInvalidTemplate, err)
+	}
+
+	text, err := execute(textExec, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: text: %v", ErrRender, err)
+	}
+
+	// Статическая часть шаблона может содержать теги, которые Telegram не поддерживает
+	if template.IsHTML() {
+		text = tghtml.Sanitize(text)
+	}
+
+	buttons := make([]domain.InlineButton, 0, len(template.InlineButtons))
+	for i, btn := range template.InlineButtons {
+		rendered, err := renderButton(template.Name, btn, data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: inline_buttons[%d]: %v", ErrRender, i, err)
+		}
+		buttons = append(buttons, rendered)
+	}
+
+	// Значения кнопок известны только после подстановки переменных - проверяем их здесь
+	if err := domain.InlineButtons(buttons).Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRender, err)
+	}
+
+	return &domain.RenderedMessage{
+		Text:          strings.TrimSpace(text),
+		InlineButtons: buttons,
+		ParseMode:     template.ParseMode,
+	}, nil
+}
+
+// renderButton рендерит текст и значение одной кнопки
+func renderButton(name string, btn domain.InlineButton, data map[string]interface{}) (domain.InlineButton, error) {
+	rendered := btn
+	for field, value := range buttonFields(&rendered) {
+		exec, err := compilePlain(name, *value)
+		if err != nil {
+			return domain.InlineButton{}, fmt.Errorf("%s: %v", field, err)
+		}
+		if *value, err = execute(exec, data); err != nil {
+			return domain.InlineButton{}, fmt.Errorf("%s: %v", field, err)
+		}
+	}
+	return rendered, nil
+}
+
+// buttonFields возвращает поля кнопки, которые могут содержать переменные шаблона
+func buttonFields(btn *domain.InlineButton) map[string]*string {
+	return map[string]*string{
+		"text":                &btn.Text,
+		"url":                 &btn.URL,
+		"callback_data":       &btn.CallbackData,
+		"switch_inline_query": &btn.SwitchInlineQuery,
+		"copy_text":           &btn.CopyText,
+	}
+}
+
+// execute выполняет скомпилированный шаблон
+func execute(exec executor, data map[string]interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := exec.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,316 @@
+package templates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	templateRepo "github.com/m04kA/SMC-NotificationService/internal/infra/storage/template"
+	"github.com/m04kA/SMC-NotificationService/internal/service/templates/models"
+)
+
+// templateNamePattern допустимый формат имени шаблона
+var templateNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.\-]{0,127}$`)
+
+// localePattern допустимый формат кода языка (ISO 639-1/639-2)
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}$`)
+
+// Service сервис реестра шаблонов уведомлений
+type Service struct {
+	templateRepo      TemplateRepository
+	bookingClient     BookingClient
+	bookingURLPattern string
+	serviceUserID     int64
+	defaultLocale     string
+}
+
+// NewService создает новый экземпляр сервиса шаблонов
+// bookingURLPattern - шаблон ссылки на бронирование с плейсхолдерами {user_id} и {booking_id}
+// serviceUserID - X-User-ID для запросов в BookingService, когда у уведомления нет telegram_user_id
+// defaultLocale - язык, вариант на котором используется, если для языка получателя варианта нет
+func NewService(templateRepo TemplateRepository, bookingClient BookingClient, bookingURLPattern string, serviceUserID int64, defaultLocale string) *Service {
+	return &Service{
+		templateRepo:      templateRepo,
+		bookingClient:     bookingClient,
+		bookingURLPattern: bookingURLPattern,
+		serviceUserID:     serviceUserID,
+		defaultLocale:     defaultLocale,
+	}
+}
+
+// Create создаёт первую версию шаблона (или первую версию нового языкового варианта)
+func (s *Service) Create(ctx context.Context, input *models.CreateTemplateInput) (*domain.Template, error) {
+	if !templateNamePattern.MatchString(input.Name) {
+		return nil, fmt.Errorf("%w: name must match %s", ErrInvalidInput, templateNamePattern.String())
+	}
+
+	locale, err := s.normalizeLocale(input.Locale)
+	if err != nil {
+		return nil, fmt.Errorf("Create - %w", err)
+	}
+
+	// Вариант занят, если есть неудалённая версия на этом языке
+	_, err = s.templateRepo.GetLatest(ctx, input.Name, locale)
+	if err == nil {
+		return nil, ErrTemplateAlreadyExists
+	}
+	if !errors.Is(err, templateRepo.ErrTemplateNotFound) {
+		return nil, fmt.Errorf("%w: Create - repository error: %v", ErrInternal, err)
+	}
+
+	template := &domain.Template{
+		Name:          input.Name,
+		Locale:        locale,
+		Type:          input.Type,
+		ParseMode:     input.ParseMode,
+		Text:          input.Text,
+		InlineButtons: input.InlineButtons,
+		Description:   input.Description,
+	}
+
+	if err := s.saveVersion(ctx, template); err != nil {
+		return nil, fmt.Errorf("Create - %w", err)
+	}
+
+	return template, nil
+}
+
+// Update создаёт новую версию существующего языкового варианта шаблона
+// Уже созданные уведомления продолжают использовать зафиксированную версию
+func (s *Service) Update(ctx context.Context, name string, input *models.UpdateTemplateInput) (*domain.Template, error) {
+	current, err := s.Get(ctx, name, input.Locale)
+	if err != nil {
+		return nil, fmt.Errorf("Update - %w", err)
+	}
+
+	template := &domain.Template{
+		Name:          current.Name,
+		Locale:        current.Locale,
+		Type:          input.Type,
+		ParseMode:     input.ParseMode,
+		Text:          input.Text,
+		InlineButtons: input.InlineButtons,
+		Description:   input.Description,
+	}
+	if template.Type == "" {
+		template.Type = current.Type
+	}
+
+	if err := s.saveVersion(ctx, template); err != nil {
+		return nil, fmt.Errorf("Update - %w", err)
+	}
+
+	return template, nil
+}
+
+// Get получает последнюю версию языкового варианта шаблона (пустой locale - язык по умолчанию)
+func (s *Service) Get(ctx context.Context, name, locale string) (*domain.Template, error) {
+	locale, err := s.normalizeLocale(locale)
+	if err != nil {
+		return nil, fmt.Errorf("Get - %w", err)
+	}
+
+	template, err := s.templateRepo.GetLatest(ctx, name, locale)
+	if err != nil {
+		if errors.Is(err, templateRepo.ErrTemplateNotFound) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("%w: Get - repository error: %v", ErrInternal, err)
+	}
+
+	return template, nil
+}
+
+// GetLocalized получает последнюю версию шаблона для языка получателя
+// Если варианта на этом языке нет, используется вариант на языке по умолчанию
+func (s *Service) GetLocalized(ctx context.Context, name, locale string) (*domain.Template, error) {
+	locale = domain.NormalizeLocale(locale)
+	if locale != "" && locale != s.defaultLocale {
+		template, err := s.templateRepo.GetLatest(ctx, name, locale)
+		if err == nil {
+			return template, nil
+		}
+		if !errors.Is(err, templateRepo.ErrTemplateNotFound) {
+			return nil, fmt.Errorf("%w: GetLocalized - repository error: %v", ErrInternal, err)
+		}
+	}
+
+	return s.Get(ctx, name, s.defaultLocale)
+}
+
+// GetVersion получает конкретную версию языкового варианта шаблона
+func (s *Service) GetVersion(ctx context.Context, name, locale string, version int) (*domain.Template, error) {
+	locale, err := s.normalizeLocale(locale)
+	if err != nil {
+		return nil, fmt.Errorf("GetVersion - %w", err)
+	}
+
+	template, err := s.templateRepo.GetVersion(ctx, name, locale, version)
+	if err != nil {
+		if errors.Is(err, templateRepo.ErrTemplateNotFound) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("%w: GetVersion - repository error: %v", ErrInternal, err)
+	}
+
+	return template, nil
+}
+
+// List получает последние версии шаблонов с фильтрацией
+func (s *Service) List(ctx context.Context, filter models.ListTemplatesFilter) ([]*domain.Template, error) {
+	templates, err := s.templateRepo.List(ctx, templateRepo.ListFilter{
+		Type:   filter.Type,
+		Locale: filter.Locale,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: List - repository error: %v", ErrInternal, err)
+	}
+
+	return templates, nil
+}
+
+// ListVersions получает историю версий шаблона (всех языков или одного)
+func (s *Service) ListVersions(ctx context.Context, name string, locale *string) ([]*domain.Template, error) {
+	versions, err := s.templateRepo.ListVersions(ctx, name, locale)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ListVersions - repository error: %v", ErrInternal, err)
+	}
+
+	if len(versions) == 0 {
+		return nil, ErrTemplateNotFound
+	}
+
+	return versions, nil
+}
+
+// Delete удаляет шаблон (все версии всех языков или одного языкового варианта)
+func (s *Service) Delete(ctx context.Context, name string, locale *string) error {
+	if err := s.templateRepo.Delete(ctx, name, locale); err != nil {
+		if errors.Is(err, templateRepo.ErrTemplateNotFound) {
+			return ErrTemplateNotFound
+		}
+		return fmt.Errorf("%w: Delete - repository error: %v", ErrInternal, err)
+	}
+
+	return nil
+}
+
+// Render рендерит шаблон с переменными
+func (s *Service) Render(template *domain.Template, vars domain.Metadata) (*domain.RenderedMessage, error) {
+	return Render(template, vars)
+}
+
+// BuildMessage формирует сообщение для отправки из уведомления
+// Если уведомление ссылается на шаблон - текст и кнопки рендерятся из зафиксированной версии
+func (s *Service) BuildMessage(ctx context.Context, notification *domain.Notification) (*domain.TelegramMessage, error) {
+	msg := domain.NewTelegramMessage(notification)
+
+	if !notification.HasTemplate() {
+		return msg, nil
+	}
+
+	var locale string
+	if notification.Locale != nil {
+		locale = *notification.Locale
+	}
+
+	var template *domain.Template
+	var err error
+	if notification.TemplateVersion != nil {
+		template, err = s.GetVersion(ctx, *notification.TemplateName, locale, *notification.TemplateVersion)
+	} else {
+		template, err = s.GetLocalized(ctx, *notification.TemplateName, locale)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("BuildMessage - notification %d: %w", notification.ID, err)
+	}
+
+	vars, err := s.ResolveVariables(ctx, notification)
+	if err != nil {
+		return nil, fmt.Errorf("BuildMessage - notification %d: %w", notification.ID, err)
+	}
+
+	rendered, err := Render(template, vars)
+	if err != nil {
+		return nil, fmt.Errorf("BuildMessage - notification %d: %w", notification.ID, err)
+	}
+
+	msg.MessageText = rendered.Text
+	msg.ParseMode = rendered.ParseMode
+
+	// Явно переданные кнопки имеют приоритет над кнопками шаблона
+	if !notification.HasButtons() {
+		msg.InlineButtons = rendered.InlineButtons
+	}
+
+	return msg, nil
+}
+
+// saveVersion валидирует шаблон и сохраняет его новой версией
+func (s *Service) saveVersion(ctx context.Context, template *domain.Template) error {
+	if err := validateTemplate(template); err != nil {
+		return err
+	}
+
+	if err := s.templateRepo.CreateVersion(ctx, template); err != nil {
+		if errors.Is(err, templateRepo.ErrTemplateAlreadyExists) {
+			return ErrTemplateAlreadyExists
+		}
+		return fmt.Errorf("%w: repository error: %v", ErrInternal, err)
+	}
+
+	return nil
+}
+
+// normalizeLocale проверяет код языка; пустое значение заменяется языком по умолчанию
+func (s *Service) normalizeLocale(locale string) (string, error) {
+	locale = domain.NormalizeLocale(locale)
+	if locale == "" {
+		return s.defaultLocale, nil
+	}
+
+	if !localePattern.MatchString(locale) {
+		return "", fmt.Errorf("%w: locale must match %s", ErrInvalidInput, localePattern.String())
+	}
+
+	return locale, nil
+}
+
+// validateTemplate проверяет обязательные поля и синтаксис шаблона
+func validateTemplate(template *domain.Template) error {
+	if template.Type == "" {
+		return fmt.Errorf("%w: type is required", ErrInvalidInput)
+	}
+
+	if strings.TrimSpace(template.Text) == "" {
+		return fmt.Errorf("%w: text is required", ErrInvalidInput)
+	}
+
+	if template.ParseMode != domain.ParseModeHTML && template.ParseMode != domain.ParseModePlain {
+		return fmt.Errorf("%w: parse_mode must be %q or empty", ErrInvalidInput, domain.ParseModeHTML)
+	}
+
+	// Полная проверка значений выполняется после рендеринга: до подстановки переменных URL и callback_data неизвестны
+	for i, btn := range template.InlineButtons {
+		kind := btn.GetKind()
+		if !kind.IsValid() {
+			return fmt.Errorf("%w: inline_buttons[%d]: unknown kind %q", ErrInvalidInput, i, btn.Kind)
+		}
+		if btn.Text == "" {
+			return fmt.Errorf("%w: inline_buttons[%d] requires text", ErrInvalidInput, i)
+		}
+		if btn.RequiresPayload() && btn.Payload() == "" {
+			return fmt.Errorf("%w: inline_buttons[%d] requires %s", ErrInvalidInput, i, btn.PayloadField())
+		}
+		if btn.Row < 0 {
+			return fmt.Errorf("%w: inline_buttons[%d]: row must be non-negative", ErrInvalidInput, i)
+		}
+	}
+
+	return Validate(template)
+}
@@ -0,0 +1,15 @@
+package bot_profile
+
+import "github.com/m04kA/SMC-NotificationService/internal/domain"
+
+// TelegramService интерфейс для настройки профиля бота
+type TelegramService interface {
+	SetMyCommands(commands []domain.BotCommand, languageCode string) error
+	SetMyDescription(description, shortDescription, languageCode string) error
+	SetMenuButtonWebApp(text, webAppURL string) error
+}
+
+// CommandRegistry реестр зарегистрированных команд бота
+type CommandRegistry interface {
+	Commands() []string
+}
@@ -0,0 +1,6 @@
+package bot_profile
+
+import "errors"
+
+// ErrInvalidProfile возвращается, если профиль бота настроен некорректно
+var ErrInvalidProfile = errors.New("usecase.bot_profile: invalid bot profile")
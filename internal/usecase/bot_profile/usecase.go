@@ -0,0 +1,133 @@
+package bot_profile
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// UseCase синхронизирует профиль бота в Telegram: меню команд, описание и кнопку меню с мини-приложением
+// Профиль задаётся в конфигурации и публикуется при каждом запуске, поэтому изменения в BotFather перезаписываются
+type UseCase struct {
+	telegramService TelegramService
+	registry        CommandRegistry
+	texts           map[string]domain.BotProfileTexts // Тексты по языкам
+	defaultLocale   string
+	menuButtonText  string
+	menuButtonURL   string
+}
+
+// New создаёт use case профиля бота и проверяет тексты
+// Для языка по умолчанию тексты обязательны (если профиль задан): они публикуются для всех языков без своего варианта.
+// Кнопка меню устанавливается, только если заданы текст и URL
+func New(telegramService TelegramService, registry CommandRegistry, texts map[string]domain.BotProfileTexts, defaultLocale, menuButtonText, menuButtonURL string) (*UseCase, error) {
+	normalized := make(map[string]domain.BotProfileTexts, len(texts))
+	for locale, t := range texts {
+		if err := validateTexts(t); err != nil {
+			return nil, fmt.Errorf("%w: texts.%s: %v", ErrInvalidProfile, locale, err)
+		}
+		normalized[domain.NormalizeLocale(locale)] = t
+	}
+
+	defaultLocale = domain.NormalizeLocale(defaultLocale)
+	if _, ok := normalized[defaultLocale]; len(normalized) > 0 && !ok {
+		return nil, fmt.Errorf("%w: texts for default locale %q are required", ErrInvalidProfile, defaultLocale)
+	}
+
+	if menuButtonText != "" && menuButtonURL != "" {
+		parsed, err := url.ParseRequestURI(menuButtonURL)
+		if err != nil || parsed.Scheme != "https" {
+			return nil, fmt.Errorf("%w: menu button url must be an absolute https URL", ErrInvalidProfile)
+		}
+	}
+
+	return &UseCase{
+		telegramService: telegramService,
+		registry:        registry,
+		texts:           normalized,
+		defaultLocale:   defaultLocale,
+		menuButtonText:  menuButtonText,
+		menuButtonURL:   menuButtonURL,
+	}, nil
+}
+
+// Sync публикует профиль бота в Telegram
+// Тексты языка по умолчанию публикуются без language_code, остальные - для своего языка.
+// Ошибка одного запроса не останавливает остальные: возвращаются все ошибки
+func (uc *UseCase) Sync() error {
+	var errs []error
+
+	locales := make([]string, 0, len(uc.texts))
+	for locale := range uc.texts {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	for _, locale := range locales {
+		languageCode := locale
+		if locale == uc.defaultLocale {
+			languageCode = ""
+		}
+
+		t := uc.texts[locale]
+		if err := uc.telegramService.SetMyCommands(uc.commands(locale), languageCode); err != nil {
+			errs = append(errs, err)
+		}
+		if err := uc.telegramService.SetMyDescription(t.Description, t.ShortDescription, languageCode); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if uc.menuButtonText != "" && uc.menuButtonURL != "" {
+		if err := uc.telegramService.SetMenuButtonWebApp(uc.menuButtonText, uc.menuButtonURL); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("usecase.SyncBotProfile: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// commands возвращает зарегистрированные команды с описаниями на языке locale в порядке регистрации
+// Описание берётся из языка по умолчанию, если для locale его нет; команды без описания не публикуются
+func (uc *UseCase) commands(locale string) []domain.BotCommand {
+	t := uc.texts[locale]
+	fallback := uc.texts[uc.defaultLocale]
+
+	commands := make([]domain.BotCommand, 0, len(t.Commands))
+	for _, name := range uc.registry.Commands() {
+		description := t.Commands[name]
+		if description == "" {
+			description = fallback.Commands[name]
+		}
+		if description == "" {
+			continue
+		}
+		commands = append(commands, domain.BotCommand{Command: name, Description: description})
+	}
+
+	return commands
+}
+
+// validateTexts проверяет тексты профиля на ограничения Telegram
+func validateTexts(t domain.BotProfileTexts) error {
+	if utf8.RuneCountInString(t.Description) > domain.MaxBotDescriptionLength {
+		return fmt.Errorf("description exceeds %d characters", domain.MaxBotDescriptionLength)
+	}
+	if utf8.RuneCountInString(t.ShortDescription) > domain.MaxBotShortDescriptionLength {
+		return fmt.Errorf("short_description exceeds %d characters", domain.MaxBotShortDescriptionLength)
+	}
+	for name, description := range t.Commands {
+		if utf8.RuneCountInString(description) > domain.MaxBotCommandDescription {
+			return fmt.Errorf("command %s description exceeds %d characters", name, domain.MaxBotCommandDescription)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package callback_query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/bookingservice"
+)
+
+const (
+	// MaxSnoozeMinutes максимальная отсрочка напоминания (сутки)
+	MaxSnoozeMinutes = 24 * 60
+
+	// metadataKeySnoozedFrom ключ metadata с ID исходного уведомления для отложенной копии
+	metadataKeySnoozedFrom = "snoozed_from"
+)
+
+// confirmBooking подтверждает запись клиентом
+// В BookingService нет отдельного API подтверждения клиентом, поэтому проверяется доступ к бронированию, а факт подтверждения фиксируется в журнале
+func (uc *UseCase) confirmBooking(ctx context.Context, req *ActionRequest) (*ActionResult, error) {
+	bookingID, ok := req.Notification.BookingID()
+	if !ok {
+		return rejected(req.Texts.NoBooking, "notification has no booking_id"), nil
+	}
+
+	booking, err := uc.bookingClient.GetBooking(ctx, bookingID, req.From.ID)
+	if err != nil {
+		if errors.Is(err, bookingservice.ErrBookingNotFound) || errors.Is(err, bookingservice.ErrForbidden) {
+			return rejected(req.Texts.BookingNotFound, err.Error()), nil
+		}
+		return nil, fmt.Errorf("get booking %d: %w", bookingID, err)
+	}
+
+	if booking.IsCancelled() {
+		return rejected(req.Texts.AlreadyCanceled, fmt.Sprintf("booking %d is %s", bookingID, booking.Status)), nil
+	}
+
+	return success(req.Texts.Confirmed), nil
+}
+
+// cancelBooking отменяет запись через BookingService (operationId: cancelBooking)
+func (uc *UseCase) cancelBooking(ctx context.Context, req *ActionRequest) (*ActionResult, error) {
+	bookingID, ok := req.Notification.BookingID()
+	if !ok {
+		return rejected(req.Texts.NoBooking, "notification has no booking_id"), nil
+	}
+
+	reason := req.Texts.CancelReason
+	err := uc.bookingClient.CancelBooking(ctx, bookingID, &bookingservice.CancelBookingRequest{
+		UserID:             req.From.ID,
+		CancellationReason: &reason,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, bookingservice.ErrBookingNotFound), errors.Is(err, bookingservice.ErrForbidden):
+			return rejected(req.Texts.BookingNotFound, err.Error()), nil
+		case errors.Is(err, bookingservice.ErrCannotCancel):
+			return rejected(req.Texts.CannotCancel, err.Error()), nil
+		}
+		return nil, fmt.Errorf("cancel booking %d: %w", bookingID, err)
+	}
+
+	return success(req.Texts.Cancelled), nil
+}
+
+// snooze повторяет уведомление через указанное в аргументе число минут
+// Создаётся отложенная копия уведомления, исходное остаётся без изменений
+func (uc *UseCase) snooze(ctx context.Context, req *ActionRequest) (*ActionResult, error) {
+	minutes := uc.snoozeMinutes
+	if req.Arg != "" {
+		parsed, err := strconv.Atoi(req.Arg)
+		if err != nil || parsed <= 0 || parsed > MaxSnoozeMinutes {
+			return rejected(req.Texts.UnknownAction, fmt.Sprintf("invalid snooze minutes %q", req.Arg)), nil
+		}
+		minutes = parsed
+	}
+
+	scheduledFor := time.Now().Add(time.Duration(minutes) * time.Minute)
+	copied := snoozedCopy(req.Notification, scheduledFor)
+
+	id, err := uc.notificationRepo.Create(ctx, copied)
+	if err != nil {
+		return nil, fmt.Errorf("create snoozed notification: %w", err)
+	}
+	copied.ID = id
+
+	if err := uc.scheduler.ScheduleNotification(copied); err != nil {
+		return nil, fmt.Errorf("schedule snoozed notification %d: %w", id, err)
+	}
+
+	return success(fmt.Sprintf(req.Texts.Snoozed, minutes)), nil
+}
+
+// snoozedCopy создаёт отложенную копию уведомления
+// Шаблон и его версия сохраняются, поэтому текст будет отрендерен заново с актуальными данными
+func snoozedCopy(n *domain.Notification, scheduledFor time.Time) *domain.Notification {
+	copied := *n
+	copied.ID = 0
+	copied.SpanID = nil
+	copied.Status = domain.NotificationStatusScheduled
+	copied.ScheduledFor = &scheduledFor
+	copied.SentAt = nil
+	copied.ErrorMessage = nil
+	copied.RetryCount = 0
+
+	copied.Metadata = make(domain.Metadata, len(n.Metadata)+1)
+	for k, v := range n.Metadata {
+		copied.Metadata[k] = v
+	}
+	copied.Metadata[metadataKeySnoozedFrom] = n.ID
+
+	return &copied
+}
@@ -0,0 +1,49 @@
+package callback_query
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/bookingservice"
+	"github.com/m04kA/SMC-NotificationService/pkg/callbackdata"
+)
+
+// TelegramService интерфейс для ответа на callback-запросы
+type TelegramService interface {
+	AnswerCallbackQuery(callbackQueryID, text string, showAlert bool) error
+}
+
+// CallbackVerifier интерфейс для проверки подписи callback_data
+type CallbackVerifier interface {
+	Verify(data string) (*callbackdata.Data, error)
+}
+
+// NotificationRepository интерфейс для работы с уведомлениями
+type NotificationRepository interface {
+	GetByID(ctx context.Context, id int64) (*domain.Notification, error)
+	Create(ctx context.Context, notification *domain.Notification) (int64, error)
+}
+
+// Scheduler интерфейс для планирования отложенных уведомлений
+type Scheduler interface {
+	ScheduleNotification(notification *domain.Notification) error
+}
+
+// AuditRepository интерфейс для журнала callback-действий
+type AuditRepository interface {
+	Create(ctx context.Context, audit *domain.CallbackAudit) error
+	HasSuccess(ctx context.Context, notificationID int64, action string) (bool, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
+
+// BookingServiceClient интерфейс для работы с BookingService
+type BookingServiceClient interface {
+	GetBooking(ctx context.Context, bookingID int64, userID int64) (*bookingservice.Booking, error)
+	CancelBooking(ctx context.Context, bookingID int64, req *bookingservice.CancelBookingRequest) error
+}
@@ -0,0 +1,62 @@
+package callback_query
+
+import "github.com/m04kA/SMC-NotificationService/internal/domain"
+
+// answerTexts тексты ответов на нажатие кнопок на одном языке
+type answerTexts struct {
+	Invalid         string // Подпись не прошла проверку или уведомление не найдено
+	Forbidden       string // Кнопку нажал не получатель уведомления
+	UnknownAction   string // Действие не зарегистрировано
+	Failed          string // Ошибка при выполнении действия
+	AlreadyDone     string // Действие по уведомлению уже выполнено
+	NoBooking       string // Уведомление не связано с бронированием
+	BookingNotFound string // Бронирование не найдено или недоступно
+	Confirmed       string
+	AlreadyCanceled string
+	Cancelled       string
+	CannotCancel    string
+	CancelReason    string // Причина отмены, передаваемая в BookingService
+	Snoozed         string // Формат с количеством минут
+}
+
+// texts варианты ответов по языкам
+var texts = map[string]answerTexts{
+	"ru": {
+		Invalid:         "Кнопка устарела",
+		Forbidden:       "Это действие недоступно",
+		UnknownAction:   "Действие не поддерживается",
+		Failed:          "Не удалось выполнить действие, попробуйте позже",
+		AlreadyDone:     "Это действие уже выполнено",
+		NoBooking:       "Сообщение не связано с записью",
+		BookingNotFound: "Запись не найдена",
+		Confirmed:       "Спасибо! Ждём вас",
+		AlreadyCanceled: "Запись уже отменена",
+		Cancelled:       "Запись отменена",
+		CannotCancel:    "Эту запись уже нельзя отменить",
+		CancelReason:    "Отменено клиентом в Telegram",
+		Snoozed:         "Напомним через %d мин.",
+	},
+	"en": {
+		Invalid:         "This button has expired",
+		Forbidden:       "This action is not available",
+		UnknownAction:   "This action is not supported",
+		Failed:          "Could not complete the action, please try again later",
+		AlreadyDone:     "This action has already been completed",
+		NoBooking:       "This message is not linked to a booking",
+		BookingNotFound: "Booking not found",
+		Confirmed:       "Thank you! See you soon",
+		AlreadyCanceled: "The booking is already cancelled",
+		Cancelled:       "Booking cancelled",
+		CannotCancel:    "This booking can no longer be cancelled",
+		CancelReason:    "Cancelled by the client in Telegram",
+		Snoozed:         "We will remind you in %d min.",
+	},
+}
+
+// getTexts возвращает тексты для языка (или для языка по умолчанию)
+func getTexts(locale string) answerTexts {
+	if t, ok := texts[domain.NormalizeLocale(locale)]; ok {
+		return t
+	}
+	return texts[domain.DefaultLocale]
+}
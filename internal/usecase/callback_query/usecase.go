@@ -0,0 +1,206 @@
+package callback_query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/notification"
+)
+
+// ActionRequest данные нажатия кнопки, передаваемые в обработчик действия
+type ActionRequest struct {
+	Notification *domain.Notification
+	Arg          string
+	From         *tgbotapi.User
+	Texts        answerTexts
+}
+
+// ActionResult результат обработки действия
+type ActionResult struct {
+	Text   string                // Ответ пользователю (всплывающее уведомление)
+	Status domain.CallbackStatus // success или rejected
+	Reason string                // Причина отказа для журнала
+}
+
+// ActionFunc обработчик действия callback-кнопки
+// Ошибка означает сбой выполнения; ожидаемые отказы возвращаются в ActionResult со статусом rejected
+type ActionFunc func(ctx context.Context, req *ActionRequest) (*ActionResult, error)
+
+// UseCase обрабатывает нажатия callback-кнопок
+type UseCase struct {
+	telegramService  TelegramService
+	verifier         CallbackVerifier
+	notificationRepo NotificationRepository
+	auditRepo        AuditRepository
+	bookingClient    BookingServiceClient
+	scheduler        Scheduler
+	snoozeMinutes    int
+	logger           Logger
+	actions          map[domain.CallbackAction]ActionFunc
+}
+
+// New создаёт новый use case и регистрирует встроенные действия
+// snoozeMinutes - на сколько минут откладывается напоминание кнопкой snooze без аргумента
+func New(
+	telegramService TelegramService,
+	verifier CallbackVerifier,
+	notificationRepo NotificationRepository,
+	auditRepo AuditRepository,
+	bookingClient BookingServiceClient,
+	scheduler Scheduler,
+	snoozeMinutes int,
+	logger Logger,
+) *UseCase {
+	uc := &UseCase{
+		telegramService:  telegramService,
+		verifier:         verifier,
+		notificationRepo: notificationRepo,
+		auditRepo:        auditRepo,
+		bookingClient:    bookingClient,
+		scheduler:        scheduler,
+		snoozeMinutes:    snoozeMinutes,
+		logger:           logger,
+		actions:          make(map[domain.CallbackAction]ActionFunc),
+	}
+
+	uc.Register(domain.CallbackActionConfirmBooking, uc.confirmBooking)
+	uc.Register(domain.CallbackActionCancelBooking, uc.cancelBooking)
+	uc.Register(domain.CallbackActionSnooze, uc.snooze)
+
+	return uc
+}
+
+// Register регистрирует обработчик действия (повторная регистрация заменяет обработчик)
+func (uc *UseCase) Register(action domain.CallbackAction, fn ActionFunc) {
+	uc.actions[action] = fn
+}
+
+// Execute обрабатывает нажатие callback-кнопки
+// Каждое нажатие фиксируется в журнале; пользователь всегда получает ответ, чтобы кнопка не «зависла»
+// Ошибки ответа и журнала только логируются: выполненное действие не должно повториться при повторной обработке обновления
+func (uc *UseCase) Execute(ctx context.Context, query *tgbotapi.CallbackQuery) error {
+	if query == nil || query.From == nil {
+		return nil
+	}
+
+	audit := &domain.CallbackAudit{
+		CallbackQueryID: query.ID,
+		TgUserID:        query.From.ID,
+	}
+	answerTexts := getTexts(query.From.LanguageCode)
+
+	result, execErr := uc.execute(ctx, query, audit, &answerTexts)
+	if execErr != nil {
+		result = &ActionResult{Text: answerTexts.Failed, Status: domain.CallbackStatusFailed, Reason: execErr.Error()}
+	}
+
+	audit.Status = result.Status
+	if result.Reason != "" {
+		audit.ErrorMessage = &result.Reason
+	}
+
+	// Отказ показываем во всплывающем окне, чтобы пользователь точно его увидел
+	showAlert := result.Status != domain.CallbackStatusSuccess
+	answerErr := uc.telegramService.AnswerCallbackQuery(query.ID, result.Text, showAlert)
+	if answerErr != nil {
+		uc.logger.Error("Failed to answer callback %s: %v", query.ID, answerErr)
+	}
+
+	if err := uc.auditRepo.Create(ctx, audit); err != nil {
+		uc.logger.Error("Failed to save audit for callback %s: %v", query.ID, err)
+	}
+
+	if execErr == nil {
+		return nil
+	}
+
+	err := fmt.Errorf("usecase.CallbackQuery: callback %s from user %d: %w", query.ID, query.From.ID, execErr)
+	// Пользователь увидел ошибку и может нажать кнопку снова: повтор обновления выполнил бы действие без его ведома
+	if answerErr == nil {
+		return fmt.Errorf("%w: %w", domain.ErrUpdatePartiallyHandled, err)
+	}
+	return err
+}
+
+// execute проверяет callback_data и права пользователя, затем вызывает обработчик действия
+// Заполняет в записи журнала уведомление и действие по мере их определения
+func (uc *UseCase) execute(ctx context.Context, query *tgbotapi.CallbackQuery, audit *domain.CallbackAudit, answerTexts *answerTexts) (*ActionResult, error) {
+	data, err := uc.verifier.Verify(query.Data)
+	if err != nil {
+		return rejected(answerTexts.Invalid, err.Error()), nil
+	}
+
+	audit.Action = &data.Action
+	if data.Arg != "" {
+		audit.Arg = &data.Arg
+	}
+
+	n, err := uc.notificationRepo.GetByID(ctx, data.NotificationID)
+	if err != nil {
+		if errors.Is(err, notification.ErrNotificationNotFound) {
+			return rejected(answerTexts.Invalid, fmt.Sprintf("notification %d not found", data.NotificationID)), nil
+		}
+		return nil, fmt.Errorf("get notification %d: %w", data.NotificationID, err)
+	}
+	audit.NotificationID = &n.ID
+
+	// Отвечаем на языке уведомления, если он известен
+	if n.Locale != nil {
+		*answerTexts = getTexts(*n.Locale)
+	}
+
+	if !canUse(n, query) {
+		return rejected(answerTexts.Forbidden, "user is not a recipient of the notification"), nil
+	}
+
+	action, ok := uc.actions[domain.CallbackAction(data.Action)]
+	if !ok {
+		return rejected(answerTexts.UnknownAction, fmt.Sprintf("unknown action %q", data.Action)), nil
+	}
+
+	// Подписанную кнопку можно нажать повторно: действие по уведомлению выполняется один раз
+	done, err := uc.auditRepo.HasSuccess(ctx, n.ID, data.Action)
+	if err != nil {
+		return nil, fmt.Errorf("check previous %s for notification %d: %w", data.Action, n.ID, err)
+	}
+	if done {
+		return rejected(answerTexts.AlreadyDone, fmt.Sprintf("action %s already completed for notification %d", data.Action, n.ID)), nil
+	}
+
+	result, err := action(ctx, &ActionRequest{
+		Notification: n,
+		Arg:          data.Arg,
+		From:         query.From,
+		Texts:        *answerTexts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("action %s for notification %d: %w", data.Action, n.ID, err)
+	}
+
+	return result, nil
+}
+
+// canUse проверяет, что кнопку нажал получатель уведомления
+// Для личных уведомлений сравнивается пользователь, для уведомлений в чат - чат сообщения
+func canUse(n *domain.Notification, query *tgbotapi.CallbackQuery) bool {
+	if n.TelegramUserID != nil {
+		return *n.TelegramUserID == query.From.ID
+	}
+	if n.ChatID != nil && query.Message != nil && query.Message.Chat != nil {
+		return *n.ChatID == query.Message.Chat.ID
+	}
+	return false
+}
+
+// success формирует успешный результат
+func success(text string) *ActionResult {
+	return &ActionResult{Text: text, Status: domain.CallbackStatusSuccess}
+}
+
+// rejected формирует отказ с причиной для журнала
+func rejected(text, reason string) *ActionResult {
+	return &ActionResult{Text: text, Status: domain.CallbackStatusRejected, Reason: reason}
+}
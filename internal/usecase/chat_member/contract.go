@@ -0,0 +1,26 @@
+package chat_member
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// ReachabilityService интерфейс доступности чатов
+type ReachabilityService interface {
+	MarkUnreachable(ctx context.Context, chatID int64, reason domain.UnreachableReason, source domain.ReachabilitySource, errorMessage string) error
+	Restore(ctx context.Context, chatID int64, source domain.ReachabilitySource) (bool, error)
+}
+
+// GroupChatService интерфейс реестра групповых чатов
+type GroupChatService interface {
+	Register(ctx context.Context, chatID int64, chatType, title string, addedBy *int64) error
+	Deactivate(ctx context.Context, chatID int64) (bool, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
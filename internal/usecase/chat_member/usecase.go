@@ -0,0 +1,81 @@
+package chat_member
+
+import (
+	"context"
+	"fmt"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// Статусы участника чата из Telegram Bot API
+const (
+	statusCreator       = "creator"
+	statusAdministrator = "administrator"
+	statusMember        = "member"
+	statusRestricted    = "restricted"
+	statusLeft          = "left"
+	statusKicked        = "kicked"
+)
+
+// UseCase обрабатывает изменение статуса бота в чате (обновления my_chat_member)
+type UseCase struct {
+	reachability ReachabilityService
+	groupChats   GroupChatService
+	logger       Logger
+}
+
+// New создаёт use case обработки my_chat_member
+func New(reachability ReachabilityService, groupChats GroupChatService, logger Logger) *UseCase {
+	return &UseCase{
+		reachability: reachability,
+		groupChats:   groupChats,
+		logger:       logger,
+	}
+}
+
+// ExecuteMyChatMember обновляет доступность чата по новому статусу бота
+// Пользователь заблокировал бота - личный чат недоступен, разблокировал - снова доступен;
+// для групп и каналов недоступность означает, что бота удалили из чата.
+// Группы и супергруппы дополнительно регистрируются в реестре групповых чатов (привязка к компании - /link)
+func (uc *UseCase) ExecuteMyChatMember(ctx context.Context, update *tgbotapi.ChatMemberUpdated) error {
+	chatID := update.Chat.ID
+	status := update.NewChatMember.Status
+
+	switch status {
+	case statusKicked, statusLeft:
+		reason := domain.UnreachableReasonKicked
+		if update.Chat.IsPrivate() {
+			reason = domain.UnreachableReasonBlocked
+		}
+		if err := uc.reachability.MarkUnreachable(ctx, chatID, reason, domain.ReachabilitySourceMyChatMember, ""); err != nil {
+			return fmt.Errorf("usecase.ExecuteMyChatMember: mark chat %d unreachable: %w", chatID, err)
+		}
+		uc.logger.Info("Chat %d is unreachable: bot status %s (%s)", chatID, status, reason)
+
+		if domain.IsGroupChatType(update.Chat.Type) {
+			if _, err := uc.groupChats.Deactivate(ctx, chatID); err != nil {
+				return fmt.Errorf("usecase.ExecuteMyChatMember: deactivate group chat %d: %w", chatID, err)
+			}
+		}
+
+	case statusMember, statusAdministrator, statusCreator, statusRestricted:
+		restored, err := uc.reachability.Restore(ctx, chatID, domain.ReachabilitySourceMyChatMember)
+		if err != nil {
+			return fmt.Errorf("usecase.ExecuteMyChatMember: restore chat %d: %w", chatID, err)
+		}
+		if restored {
+			uc.logger.Info("Chat %d is reachable again: bot status %s", chatID, status)
+		}
+
+		if domain.IsGroupChatType(update.Chat.Type) {
+			addedBy := update.From.ID
+			if err := uc.groupChats.Register(ctx, chatID, update.Chat.Type, update.Chat.Title, &addedBy); err != nil {
+				return fmt.Errorf("usecase.ExecuteMyChatMember: register group chat %d: %w", chatID, err)
+			}
+			uc.logger.Info("Group chat %d (%s) registered: bot status %s", chatID, update.Chat.Title, status)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package contact_message
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/userservice"
+)
+
+// TelegramService интерфейс для клавиатуры запроса контакта
+type TelegramService interface {
+	SendContactRequest(chatID int64, text, buttonText string) error
+	SendRemoveKeyboard(chatID int64, text string) error
+}
+
+// UserServiceClient интерфейс для сохранения номера телефона в UserService
+type UserServiceClient interface {
+	UpdateUser(ctx context.Context, tgUserID int64, req *userservice.UpdateUserRequest) (*userservice.User, error)
+}
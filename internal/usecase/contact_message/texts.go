@@ -0,0 +1,44 @@
+package contact_message
+
+import "github.com/m04kA/SMC-NotificationService/internal/domain"
+
+// contactTexts тексты запроса номера телефона на одном языке
+type contactTexts struct {
+	Request  string // Предложение поделиться номером после приветствия
+	Button   string // Текст кнопки request_contact
+	NotOwn   string // Пользователь отправил чужой контакт
+	Invalid  string // Номер не удалось распознать
+	Saved    string // Номер сохранён
+	NotFound string // Пользователь ещё не создан в UserService
+	Failed   string // Не удалось сохранить номер
+}
+
+// texts варианты текстов по языкам
+var texts = map[string]contactTexts{
+	"ru": {
+		Request:  "Поделитесь номером телефона, чтобы мы могли связаться с вами по бронированию.",
+		Button:   "📱 Поделиться номером",
+		NotOwn:   "Это не ваш контакт. Нажмите кнопку ниже, чтобы поделиться своим номером.",
+		Invalid:  "Не удалось распознать номер телефона. Нажмите кнопку ниже, чтобы поделиться номером.",
+		Saved:    "Спасибо! Номер телефона сохранён.",
+		NotFound: "Сначала отправьте /start, затем поделитесь номером.",
+		Failed:   "Не удалось сохранить номер, попробуйте позже.",
+	},
+	"en": {
+		Request:  "Share your phone number so we can contact you about your bookings.",
+		Button:   "📱 Share phone number",
+		NotOwn:   "This is not your contact. Tap the button below to share your own number.",
+		Invalid:  "We couldn't recognize this phone number. Tap the button below to share your number.",
+		Saved:    "Thank you! Your phone number has been saved.",
+		NotFound: "Send /start first, then share your number.",
+		Failed:   "Couldn't save your number, please try again later.",
+	},
+}
+
+// getTexts возвращает тексты для языка (или для языка по умолчанию)
+func getTexts(locale string) contactTexts {
+	if t, ok := texts[domain.NormalizeLocale(locale)]; ok {
+		return t
+	}
+	return texts[domain.DefaultLocale]
+}
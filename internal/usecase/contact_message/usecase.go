@@ -0,0 +1,82 @@
+package contact_message
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/userservice"
+	"github.com/m04kA/SMC-NotificationService/pkg/phone"
+)
+
+// UseCase собирает номера телефонов пользователей через кнопку request_contact
+type UseCase struct {
+	telegramService   TelegramService
+	userServiceClient UserServiceClient
+}
+
+// New создаёт use case сбора номеров телефонов
+func New(telegramService TelegramService, userServiceClient UserServiceClient) *UseCase {
+	return &UseCase{
+		telegramService:   telegramService,
+		userServiceClient: userServiceClient,
+	}
+}
+
+// RequestPhone предлагает пользователю поделиться номером телефона кнопкой клавиатуры
+func (uc *UseCase) RequestPhone(chatID int64, locale string) error {
+	t := getTexts(locale)
+	if err := uc.telegramService.SendContactRequest(chatID, t.Request, t.Button); err != nil {
+		return fmt.Errorf("usecase.RequestPhone: send contact request to chat %d: %w", chatID, err)
+	}
+	return nil
+}
+
+// IsContact проверяет, что пользователь отправил контакт в личном чате с ботом
+func (uc *UseCase) IsContact(msg *tgbotapi.Message) bool {
+	return msg.Contact != nil && msg.From != nil && msg.Chat != nil && msg.Chat.IsPrivate()
+}
+
+// Execute сохраняет номер телефона из контакта в UserService
+// Принимается только собственный контакт пользователя (кнопка request_contact), а не пересланный чужой
+// Если пользователь уже получил ответ об ошибке, она помечается domain.ErrUpdatePartiallyHandled и не повторяется
+func (uc *UseCase) Execute(ctx context.Context, msg *tgbotapi.Message) error {
+	from := msg.From
+	chatID := msg.Chat.ID
+	t := getTexts(from.LanguageCode)
+
+	if msg.Contact.UserID != from.ID {
+		if err := uc.telegramService.SendContactRequest(chatID, t.NotOwn, t.Button); err != nil {
+			return fmt.Errorf("usecase.SaveContact: reply to user %d: %w", from.ID, err)
+		}
+		return nil
+	}
+
+	number, err := phone.Normalize(msg.Contact.PhoneNumber)
+	if err != nil {
+		if err := uc.telegramService.SendContactRequest(chatID, t.Invalid, t.Button); err != nil {
+			return fmt.Errorf("usecase.SaveContact: reply to user %d: %w", from.ID, err)
+		}
+		return nil
+	}
+
+	_, err = uc.userServiceClient.UpdateUser(ctx, from.ID, &userservice.UpdateUserRequest{PhoneNumber: &number})
+	if err != nil {
+		reply := t.Failed
+		if errors.Is(err, userservice.ErrUserNotFound) {
+			reply = t.NotFound
+		}
+		if replyErr := uc.telegramService.SendRemoveKeyboard(chatID, reply); replyErr != nil {
+			return fmt.Errorf("usecase.SaveContact: update phone of user %d: %w (reply: %v)", from.ID, err, replyErr)
+		}
+		return fmt.Errorf("usecase.SaveContact: %w: update phone of user %d: %w", domain.ErrUpdatePartiallyHandled, from.ID, err)
+	}
+
+	if err := uc.telegramService.SendRemoveKeyboard(chatID, t.Saved); err != nil {
+		return fmt.Errorf("usecase.SaveContact: reply to user %d: %w", from.ID, err)
+	}
+
+	return nil
+}
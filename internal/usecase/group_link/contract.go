@@ -0,0 +1,34 @@
+package group_link
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/startparam"
+)
+
+// TelegramService интерфейс для ответов на команду /link и проверки прав отправителя
+type TelegramService interface {
+	SendMessage(msg *domain.TelegramMessage) (domain.SentMessages, error)
+	IsChatAdmin(chatID, userID int64) (bool, error)
+}
+
+// GroupChatService интерфейс реестра групповых чатов
+type GroupChatService interface {
+	Link(ctx context.Context, chatID int64, chatType, title string, companyID, linkedBy int64) error
+	Migrate(ctx context.Context, fromChatID, toChatID int64) (bool, error)
+}
+
+// LinkCodeSigner интерфейс подписи и разбора кодов привязки компании
+// Используется отдельный ключ, чтобы приглашение компании из /start нельзя было использовать для /link
+type LinkCodeSigner interface {
+	Sign(kind, value string) (string, error)
+	Parse(param string) (*startparam.Payload, error)
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
@@ -0,0 +1,8 @@
+package group_link
+
+import "errors"
+
+var (
+	// ErrInvalidInput возвращается при некорректном ID компании
+	ErrInvalidInput = errors.New("usecase.group_link: invalid input")
+)
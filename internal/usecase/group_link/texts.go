@@ -0,0 +1,41 @@
+package group_link
+
+import "github.com/m04kA/SMC-NotificationService/internal/domain"
+
+// linkTexts ответы на команду /link на одном языке
+type linkTexts struct {
+	GroupOnly   string // Команда отправлена не в группе
+	Usage       string // Команда без кода
+	InvalidCode string // Код не прошёл проверку
+	ExpiredCode string // Срок действия кода истёк
+	NotAdmin    string // Команду отправил не администратор группы
+	Linked      string // Чат привязан к компании
+}
+
+// texts варианты ответов по языкам
+var texts = map[string]linkTexts{
+	"ru": {
+		GroupOnly:   "Команда /link работает в группе сотрудников: добавьте бота в группу и отправьте команду там.",
+		Usage:       "Отправьте /link <код компании>. Код выдаёт администратор сервиса.",
+		InvalidCode: "Код компании не подходит. Проверьте код или запросите новый.",
+		ExpiredCode: "Срок действия кода истёк. Запросите новый код у администратора сервиса.",
+		NotAdmin:    "Привязать группу к компании может только администратор группы.",
+		Linked:      "Группа привязана к компании. Уведомления для сотрудников будут приходить сюда.",
+	},
+	"en": {
+		GroupOnly:   "The /link command works in a staff group: add the bot to the group and send the command there.",
+		Usage:       "Send /link <company code>. The code is issued by the service administrator.",
+		InvalidCode: "This company code is not valid. Check the code or request a new one.",
+		ExpiredCode: "This company code has expired. Request a new one from the service administrator.",
+		NotAdmin:    "Only a group administrator can link the group to a company.",
+		Linked:      "The group is linked to the company. Staff notifications will be delivered here.",
+	},
+}
+
+// getTexts возвращает тексты для языка (или для языка по умолчанию)
+func getTexts(locale string) linkTexts {
+	if t, ok := texts[domain.NormalizeLocale(locale)]; ok {
+		return t
+	}
+	return texts[domain.DefaultLocale]
+}
@@ -0,0 +1,168 @@
+package group_link
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/startparam"
+)
+
+// LinkCode код привязки группового чата к компании
+type LinkCode struct {
+	CompanyID int64
+	Code      string    // Подписанный код компании
+	Command   string    // Команда для отправки в группе: /link <код>
+	ExpiresAt time.Time // После этого времени код не принимается
+}
+
+// UseCase привязывает групповые чаты к компаниям и переносит привязку при преобразовании группы в супергруппу
+type UseCase struct {
+	telegramService TelegramService
+	groupChats      GroupChatService
+	signer          LinkCodeSigner
+	codeTTL         time.Duration
+	logger          Logger
+}
+
+// New создаёт use case привязки групповых чатов
+// codeTTL - срок действия кода привязки: утёкший код перестаёт работать, новый код выдаётся через API
+func New(telegramService TelegramService, groupChats GroupChatService, signer LinkCodeSigner, codeTTL time.Duration, logger Logger) *UseCase {
+	return &UseCase{
+		telegramService: telegramService,
+		groupChats:      groupChats,
+		signer:          signer,
+		codeTTL:         codeTTL,
+		logger:          logger,
+	}
+}
+
+// CreateCode формирует подписанный код привязки для компании
+// Код выдаётся сотрудникам компании: по нему нельзя подобрать код другой компании.
+// Срок действия входит в подпись, поэтому каждый вызов выдаёт новый код
+func (uc *UseCase) CreateCode(companyID int64) (*LinkCode, error) {
+	if companyID <= 0 {
+		return nil, fmt.Errorf("%w: company_id must be positive", ErrInvalidInput)
+	}
+
+	expiresAt := time.Now().Add(uc.codeTTL).UTC().Truncate(time.Second)
+
+	code, err := uc.signer.Sign(startparam.KindLink, startparam.LinkValue(companyID, expiresAt))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	return &LinkCode{
+		CompanyID: companyID,
+		Code:      code,
+		Command:   "/link " + code,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// ExecuteLink обрабатывает команду /link <код компании> в групповом чате
+// Привязать группу может только её создатель или администратор: иначе любой участник с утёкшим кодом
+// перенаправил бы уведомления сотрудников в свою группу
+func (uc *UseCase) ExecuteLink(ctx context.Context, msg *tgbotapi.Message, args string) error {
+	var locale string
+	if msg.From != nil {
+		locale = msg.From.LanguageCode
+	}
+	t := getTexts(locale)
+
+	if !domain.IsGroupChatType(msg.Chat.Type) || msg.From == nil {
+		return uc.reply(msg, t.GroupOnly)
+	}
+
+	code := strings.TrimSpace(args)
+	if code == "" {
+		return uc.reply(msg, t.Usage)
+	}
+
+	admin, err := uc.isAdmin(msg)
+	if err != nil {
+		return fmt.Errorf("usecase.ExecuteLink: check admin %d in chat %d: %w", msg.From.ID, msg.Chat.ID, err)
+	}
+	if !admin {
+		uc.logger.Warn("User %d is not an admin of chat %d, /link rejected", msg.From.ID, msg.Chat.ID)
+		return uc.reply(msg, t.NotAdmin)
+	}
+
+	companyID, expiresAt, ok := uc.parseCode(code)
+	if !ok {
+		uc.logger.Warn("Invalid company code in /link from user %d in chat %d", msg.From.ID, msg.Chat.ID)
+		return uc.reply(msg, t.InvalidCode)
+	}
+	if !time.Now().Before(expiresAt) {
+		uc.logger.Warn("Expired company code for company %d in /link from user %d in chat %d", companyID, msg.From.ID, msg.Chat.ID)
+		return uc.reply(msg, t.ExpiredCode)
+	}
+
+	if err := uc.groupChats.Link(ctx, msg.Chat.ID, msg.Chat.Type, msg.Chat.Title, companyID, msg.From.ID); err != nil {
+		return fmt.Errorf("usecase.ExecuteLink: link chat %d to company %d: %w", msg.Chat.ID, companyID, err)
+	}
+	uc.logger.Info("Group chat %d (%s) linked to company %d by user %d", msg.Chat.ID, msg.Chat.Title, companyID, msg.From.ID)
+
+	return uc.reply(msg, t.Linked)
+}
+
+// IsMigration проверяет, что сообщение - служебное уведомление о преобразовании группы в супергруппу
+// Telegram присылает его дважды: в старую группу (migrate_to_chat_id) и в новую супергруппу (migrate_from_chat_id)
+func (uc *UseCase) IsMigration(msg *tgbotapi.Message) bool {
+	return msg.MigrateToChatID != 0 || msg.MigrateFromChatID != 0
+}
+
+// ExecuteMigration переносит чат и его привязку к компании на новый ID супергруппы
+// Повторная обработка второго служебного сообщения ничего не меняет
+func (uc *UseCase) ExecuteMigration(ctx context.Context, msg *tgbotapi.Message) error {
+	fromChatID, toChatID := msg.Chat.ID, msg.MigrateToChatID
+	if msg.MigrateFromChatID != 0 {
+		fromChatID, toChatID = msg.MigrateFromChatID, msg.Chat.ID
+	}
+
+	migrated, err := uc.groupChats.Migrate(ctx, fromChatID, toChatID)
+	if err != nil {
+		return fmt.Errorf("usecase.ExecuteMigration: migrate chat %d to %d: %w", fromChatID, toChatID, err)
+	}
+	if migrated {
+		uc.logger.Info("Group chat %d migrated to supergroup %d", fromChatID, toChatID)
+	}
+
+	return nil
+}
+
+// isAdmin проверяет, что команду отправил создатель или администратор группы
+// Анонимный администратор пишет от имени самой группы (sender_chat), getChatMember для него не подходит
+func (uc *UseCase) isAdmin(msg *tgbotapi.Message) (bool, error) {
+	if msg.SenderChat != nil && msg.SenderChat.ID == msg.Chat.ID {
+		return true, nil
+	}
+	return uc.telegramService.IsChatAdmin(msg.Chat.ID, msg.From.ID)
+}
+
+// parseCode проверяет подписанный код компании и возвращает ID компании и срок действия кода
+func (uc *UseCase) parseCode(code string) (int64, time.Time, bool) {
+	payload, err := uc.signer.Parse(code)
+	if err != nil || !payload.Signed {
+		return 0, time.Time{}, false
+	}
+	return payload.Link()
+}
+
+// reply отвечает на команду в том же чате (и той же теме форума)
+func (uc *UseCase) reply(msg *tgbotapi.Message, text string) error {
+	replyTo := msg.MessageID
+	_, err := uc.telegramService.SendMessage(&domain.TelegramMessage{
+		ChatID:      msg.Chat.ID,
+		MessageText: text,
+		ParseMode:   domain.ParseModePlain,
+		Options:     &domain.DeliveryOptions{ReplyToMessageID: &replyTo},
+	})
+	if err != nil {
+		return fmt.Errorf("usecase.ExecuteLink: reply to chat %d: %w", msg.Chat.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package group_link
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/pkg/startparam"
+)
+
+func TestUseCase_ExecuteLink(t *testing.T) {
+	signer := startparam.NewSigner("secret")
+
+	t.Run("expired code", func(t *testing.T) {
+		telegram := &fakeTelegram{admin: true}
+		groupChats := &fakeGroupChats{}
+		uc := New(telegram, groupChats, signer, -time.Hour, fakeLogger{})
+
+		code, err := uc.CreateCode(42)
+		require.NoError(t, err)
+
+		require.NoError(t, uc.ExecuteLink(context.Background(), groupMessage(), code.Code))
+		assert.Zero(t, groupChats.companyID)
+		assert.Equal(t, texts["en"].ExpiredCode, telegram.reply)
+	})
+
+	tests := []struct {
+		name          string
+		admin         bool
+		anonymous     bool
+		wantCompanyID int64
+		wantReply     string
+	}{
+		{name: "admin", admin: true, wantCompanyID: 42, wantReply: texts["en"].Linked},
+		{name: "anonymous admin", anonymous: true, wantCompanyID: 42, wantReply: texts["en"].Linked},
+		{name: "member", wantReply: texts["en"].NotAdmin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			telegram := &fakeTelegram{admin: tt.admin}
+			groupChats := &fakeGroupChats{}
+			uc := New(telegram, groupChats, signer, time.Hour, fakeLogger{})
+
+			code, err := uc.CreateCode(42)
+			require.NoError(t, err)
+
+			msg := groupMessage()
+			if tt.anonymous {
+				msg.SenderChat = msg.Chat
+			}
+
+			require.NoError(t, uc.ExecuteLink(context.Background(), msg, code.Code))
+			assert.Equal(t, tt.wantCompanyID, groupChats.companyID)
+			assert.Equal(t, tt.wantReply, telegram.reply)
+		})
+	}
+}
+
+func groupMessage() *tgbotapi.Message {
+	return &tgbotapi.Message{
+		MessageID: 1,
+		From:      &tgbotapi.User{ID: 7, LanguageCode: "en"},
+		Chat:      &tgbotapi.Chat{ID: -100, Type: "supergroup", Title: "Staff"},
+	}
+}
+
+type fakeTelegram struct {
+	admin bool
+	reply string
+}
+
+func (f *fakeTelegram) SendMessage(msg *domain.TelegramMessage) (domain.SentMessages, error) {
+	f.reply = msg.MessageText
+	return nil, nil
+}
+
+func (f *fakeTelegram) IsChatAdmin(int64, int64) (bool, error) {
+	return f.admin, nil
+}
+
+type fakeGroupChats struct {
+	companyID int64
+}
+
+func (f *fakeGroupChats) Link(_ context.Context, _ int64, _, _ string, companyID, _ int64) error {
+	f.companyID = companyID
+	return nil
+}
+
+func (f *fakeGroupChats) Migrate(context.Context, int64, int64) (bool, error) {
+	return false, nil
+}
+
+type fakeLogger struct{}
+
+func (fakeLogger) Info(string, ...interface{})  {}
+func (fakeLogger) Warn(string, ...interface{})  {}
+func (fakeLogger) Error(string, ...interface{}) {}
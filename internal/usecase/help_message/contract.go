@@ -0,0 +1,13 @@
+package help_message
+
+import "github.com/m04kA/SMC-NotificationService/internal/domain"
+
+// TelegramService интерфейс для отправки сообщений
+type TelegramService interface {
+	SendMessage(msg *domain.TelegramMessage) (domain.SentMessages, error)
+}
+
+// CommandRegistry интерфейс реестра команд бота
+type CommandRegistry interface {
+	Commands() []string
+}
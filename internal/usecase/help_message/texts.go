@@ -0,0 +1,44 @@
+package help_message
+
+import "github.com/m04kA/SMC-NotificationService/internal/domain"
+
+// helpTexts тексты справки на одном языке
+type helpTexts struct {
+	Header         string            // Заголовок списка команд
+	UnknownCommand string            // Ответ на неизвестную команду
+	Commands       map[string]string // Описания команд (имя без "/")
+}
+
+// texts варианты справки по языкам
+var texts = map[string]helpTexts{
+	"ru": {
+		Header:         "Доступные команды:",
+		UnknownCommand: "Неизвестная команда. Список команд: /help",
+		Commands: map[string]string{
+			"start":      "открыть приложение",
+			"help":       "список команд",
+			"settings":   "настройки уведомлений",
+			"mybookings": "мои записи",
+			"link":       "привязать группу к компании",
+		},
+	},
+	"en": {
+		Header:         "Available commands:",
+		UnknownCommand: "Unknown command. See /help for the list of commands",
+		Commands: map[string]string{
+			"start":      "open the app",
+			"help":       "list of commands",
+			"settings":   "notification settings",
+			"mybookings": "my bookings",
+			"link":       "link the group to a company",
+		},
+	},
+}
+
+// getTexts возвращает тексты для языка (или для языка по умолчанию)
+func getTexts(locale string) helpTexts {
+	if t, ok := texts[domain.NormalizeLocale(locale)]; ok {
+		return t
+	}
+	return texts[domain.DefaultLocale]
+}
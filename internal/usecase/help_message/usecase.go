@@ -0,0 +1,75 @@
+package help_message
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// UseCase отвечает на /help и на неизвестные команды
+type UseCase struct {
+	telegramService TelegramService
+	commands        CommandRegistry
+}
+
+// New создаёт use case справки
+// commands - реестр команд, из которого берётся актуальный список
+func New(telegramService TelegramService, commands CommandRegistry) *UseCase {
+	return &UseCase{
+		telegramService: telegramService,
+		commands:        commands,
+	}
+}
+
+// Execute отправляет список команд на языке пользователя
+func (uc *UseCase) Execute(ctx context.Context, msg *tgbotapi.Message, _ string) error {
+	t := getTexts(languageCode(msg))
+
+	lines := []string{html.EscapeString(t.Header)}
+	for _, name := range uc.commands.Commands() {
+		line := "/" + name
+		if description, ok := t.Commands[name]; ok {
+			line += " - " + html.EscapeString(description)
+		}
+		lines = append(lines, line)
+	}
+
+	return uc.reply(msg.Chat.ID, strings.Join(lines, "\n"))
+}
+
+// ExecuteUnknown отвечает на команду, для которой нет обработчика
+func (uc *UseCase) ExecuteUnknown(ctx context.Context, msg *tgbotapi.Message, _ string) error {
+	// В группах команды адресованы и другим ботам - отвечаем только в личных чатах
+	if !msg.Chat.IsPrivate() {
+		return nil
+	}
+
+	return uc.reply(msg.Chat.ID, html.EscapeString(getTexts(languageCode(msg)).UnknownCommand))
+}
+
+// reply отправляет ответ в чат
+func (uc *UseCase) reply(chatID int64, text string) error {
+	msg := &domain.TelegramMessage{
+		ChatID:      chatID,
+		MessageText: text,
+		ParseMode:   domain.ParseModeHTML,
+	}
+
+	if _, err := uc.telegramService.SendMessage(msg); err != nil {
+		return fmt.Errorf("usecase.HelpMessage: send to chat %d: %w", chatID, err)
+	}
+
+	return nil
+}
+
+// languageCode возвращает язык отправителя сообщения
+func languageCode(msg *tgbotapi.Message) string {
+	if msg.From == nil {
+		return ""
+	}
+	return msg.From.LanguageCode
+}
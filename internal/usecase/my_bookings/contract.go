@@ -0,0 +1,18 @@
+package my_bookings
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/bookingservice"
+)
+
+// TelegramService интерфейс для отправки списка бронирований
+type TelegramService interface {
+	SendMessage(msg *domain.TelegramMessage) (domain.SentMessages, error)
+}
+
+// BookingServiceClient интерфейс для работы с BookingService
+type BookingServiceClient interface {
+	GetUserBookings(ctx context.Context, userID int64, status bookingservice.BookingStatus) ([]*bookingservice.Booking, error)
+}
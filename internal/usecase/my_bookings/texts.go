@@ -0,0 +1,55 @@
+package my_bookings
+
+import (
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/bookingservice"
+)
+
+// bookingsTexts тексты списка бронирований на одном языке
+type bookingsTexts struct {
+	Header      string                                  // Заголовок списка
+	Empty       string                                  // Предстоящих бронирований нет
+	More        string                                  // Формат с количеством бронирований, не попавших в список
+	PrivateOnly string                                  // Ответ на /mybookings в группе
+	Failed      string                                  // BookingService недоступен
+	Open        string                                  // Текст кнопки бронирования (формат с датой и временем)
+	Statuses    map[bookingservice.BookingStatus]string // Названия статусов
+}
+
+// texts варианты списка по языкам
+var texts = map[string]bookingsTexts{
+	"ru": {
+		Header:      "<b>Ваши записи</b>",
+		Empty:       "У вас нет предстоящих записей. Записаться можно в приложении: /start",
+		More:        "И ещё %d - все записи в приложении.",
+		PrivateOnly: "Список записей доступен в личном чате с ботом.",
+		Failed:      "Не удалось загрузить записи, попробуйте позже.",
+		Open:        "📅 %s, %s",
+		Statuses: map[bookingservice.BookingStatus]string{
+			bookingservice.BookingStatusPending:    "ожидает подтверждения",
+			bookingservice.BookingStatusConfirmed:  "подтверждена",
+			bookingservice.BookingStatusInProgress: "выполняется",
+		},
+	},
+	"en": {
+		Header:      "<b>Your bookings</b>",
+		Empty:       "You have no upcoming bookings. You can book in the app: /start",
+		More:        "And %d more - see all bookings in the app.",
+		PrivateOnly: "Your bookings are available in a private chat with the bot.",
+		Failed:      "Could not load your bookings, please try again later.",
+		Open:        "📅 %s, %s",
+		Statuses: map[bookingservice.BookingStatus]string{
+			bookingservice.BookingStatusPending:    "awaiting confirmation",
+			bookingservice.BookingStatusConfirmed:  "confirmed",
+			bookingservice.BookingStatusInProgress: "in progress",
+		},
+	},
+}
+
+// getTexts возвращает тексты для языка (или для языка по умолчанию)
+func getTexts(locale string) bookingsTexts {
+	if t, ok := texts[domain.NormalizeLocale(locale)]; ok {
+		return t
+	}
+	return texts[domain.DefaultLocale]
+}
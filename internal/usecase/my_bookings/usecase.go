@@ -0,0 +1,174 @@
+package my_bookings
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/bookingservice"
+)
+
+const (
+	// MaxListedBookings максимальное количество бронирований в ответе на /mybookings
+	MaxListedBookings = 10
+
+	bookingDateLayout = "2006-01-02"
+	messageDateLayout = "02.01.2006"
+)
+
+// UseCase показывает предстоящие бронирования пользователя по /mybookings
+type UseCase struct {
+	telegramService   TelegramService
+	bookingClient     BookingServiceClient
+	bookingURLPattern string // Ссылка на бронирование в мини-приложении: {user_id} и {booking_id} заменяются значениями
+}
+
+// New создаёт use case списка бронирований
+func New(telegramService TelegramService, bookingClient BookingServiceClient, bookingURLPattern string) *UseCase {
+	return &UseCase{
+		telegramService:   telegramService,
+		bookingClient:     bookingClient,
+		bookingURLPattern: bookingURLPattern,
+	}
+}
+
+// Execute отправляет предстоящие и выполняемые бронирования пользователя, начиная с ближайших
+// Бронирования личные, поэтому в группах показывается только подсказка
+func (uc *UseCase) Execute(ctx context.Context, msg *tgbotapi.Message, _ string) error {
+	if msg.From == nil {
+		return nil
+	}
+	t := getTexts(msg.From.LanguageCode)
+
+	if !msg.Chat.IsPrivate() {
+		if err := uc.reply(msg.Chat.ID, html.EscapeString(t.PrivateOnly), nil); err != nil {
+			return fmt.Errorf("usecase.MyBookings: send hint to chat %d: %w", msg.Chat.ID, err)
+		}
+		return nil
+	}
+
+	bookings, err := uc.bookingClient.GetUserBookings(ctx, msg.From.ID, "")
+	if err != nil {
+		if replyErr := uc.reply(msg.Chat.ID, html.EscapeString(t.Failed), nil); replyErr != nil {
+			return fmt.Errorf("usecase.MyBookings: get bookings of user %d: %w (reply: %v)", msg.From.ID, err, replyErr)
+		}
+		return fmt.Errorf("usecase.MyBookings: %w: get bookings of user %d: %w", domain.ErrUpdatePartiallyHandled, msg.From.ID, err)
+	}
+
+	active := activeBookings(bookings)
+	if len(active) == 0 {
+		if err := uc.reply(msg.Chat.ID, html.EscapeString(t.Empty), nil); err != nil {
+			return fmt.Errorf("usecase.MyBookings: send empty list to chat %d: %w", msg.Chat.ID, err)
+		}
+		return nil
+	}
+
+	text, buttons := uc.bookingsMessage(active, msg.From.ID, t)
+	if err := uc.reply(msg.Chat.ID, text, buttons); err != nil {
+		return fmt.Errorf("usecase.MyBookings: send bookings to chat %d: %w", msg.Chat.ID, err)
+	}
+
+	return nil
+}
+
+// bookingsMessage формирует список бронирований и кнопки для открытия каждого в мини-приложении
+func (uc *UseCase) bookingsMessage(bookings []*bookingservice.Booking, userID int64, t bookingsTexts) (string, []domain.InlineButton) {
+	listed := bookings
+	if len(listed) > MaxListedBookings {
+		listed = listed[:MaxListedBookings]
+	}
+
+	blocks := []string{t.Header}
+	buttons := make([]domain.InlineButton, 0, len(listed))
+	for _, b := range listed {
+		date := formatDate(b.BookingDate)
+
+		lines := []string{fmt.Sprintf("📅 %s, %s - %s", date, html.EscapeString(b.StartTime), html.EscapeString(b.ServiceName))}
+		if car := formatCar(b); car != "" {
+			lines = append(lines, "🚗 "+html.EscapeString(car))
+		}
+		if status, ok := t.Statuses[b.Status]; ok {
+			lines = append(lines, "<i>"+status+"</i>")
+		}
+		blocks = append(blocks, strings.Join(lines, "\n"))
+
+		if uc.bookingURLPattern != "" {
+			buttons = append(buttons, domain.InlineButton{
+				Kind: domain.ButtonKindWebApp,
+				Text: fmt.Sprintf(t.Open, date, b.StartTime),
+				URL:  uc.bookingURL(b.ID, userID),
+			})
+		}
+	}
+
+	if rest := len(bookings) - len(listed); rest > 0 {
+		blocks = append(blocks, fmt.Sprintf(t.More, rest))
+	}
+
+	return strings.Join(blocks, "\n\n"), buttons
+}
+
+// reply отправляет ответ в чат
+func (uc *UseCase) reply(chatID int64, text string, buttons []domain.InlineButton) error {
+	_, err := uc.telegramService.SendMessage(&domain.TelegramMessage{
+		ChatID:        chatID,
+		MessageText:   text,
+		InlineButtons: buttons,
+		ParseMode:     domain.ParseModeHTML,
+	})
+	return err
+}
+
+// bookingURL формирует ссылку на бронирование в мини-приложении
+func (uc *UseCase) bookingURL(bookingID, userID int64) string {
+	return strings.NewReplacer(
+		"{booking_id}", strconv.FormatInt(bookingID, 10),
+		"{user_id}", strconv.FormatInt(userID, 10),
+	).Replace(uc.bookingURLPattern)
+}
+
+// activeBookings отбирает предстоящие и выполняемые бронирования и сортирует их по времени начала
+func activeBookings(bookings []*bookingservice.Booking) []*bookingservice.Booking {
+	active := make([]*bookingservice.Booking, 0, len(bookings))
+	for _, b := range bookings {
+		if b.IsActive() {
+			active = append(active, b)
+		}
+	}
+
+	// Дата YYYY-MM-DD и время HH:MM сравниваются как строки
+	sort.SliceStable(active, func(i, j int) bool {
+		if active[i].BookingDate != active[j].BookingDate {
+			return active[i].BookingDate < active[j].BookingDate
+		}
+		return active[i].StartTime < active[j].StartTime
+	})
+
+	return active
+}
+
+// formatDate переводит дату бронирования в формат сообщений (ДД.ММ.ГГГГ)
+func formatDate(value string) string {
+	date, err := time.Parse(bookingDateLayout, value)
+	if err != nil {
+		return value
+	}
+	return date.Format(messageDateLayout)
+}
+
+// formatCar собирает описание автомобиля: марка, модель и госномер
+func formatCar(b *bookingservice.Booking) string {
+	parts := make([]string, 0, 3)
+	for _, part := range []*string{b.CarBrand, b.CarModel, b.CarLicensePlate} {
+		if part != nil && *part != "" {
+			parts = append(parts, *part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
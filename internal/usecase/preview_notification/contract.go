@@ -0,0 +1,29 @@
+package preview_notification
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+)
+
+// NotificationService интерфейс для проверки уведомления без сохранения
+type NotificationService interface {
+	Prepare(ctx context.Context, input *models.CreateNotificationInput) (*domain.Notification, error)
+}
+
+// MessageBuilder интерфейс для подготовки сообщения к отправке (рендеринг шаблона)
+type MessageBuilder interface {
+	BuildMessage(ctx context.Context, notification *domain.Notification) (*domain.TelegramMessage, error)
+}
+
+// CallbackSigner интерфейс для подписи callback_data кнопок
+type CallbackSigner interface {
+	Sign(payload string, notificationID int64) (string, error)
+}
+
+// TelegramService интерфейс для предпросмотра и отправки сообщений
+type TelegramService interface {
+	Preview(msg *domain.TelegramMessage) ([]domain.TelegramRequest, error)
+	SendMessage(msg *domain.TelegramMessage) (domain.SentMessages, error)
+}
@@ -0,0 +1,11 @@
+package preview_notification
+
+import "errors"
+
+var (
+	// ErrChatNotAllowed возвращается, если чат для тестовой отправки не входит в список разрешённых
+	ErrChatNotAllowed = errors.New("usecase.preview_notification: chat is not allowed for test sending")
+
+	// ErrSend возвращается, если Telegram не принял тестовую отправку
+	ErrSend = errors.New("usecase.preview_notification: failed to send preview")
+)
@@ -0,0 +1,106 @@
+package preview_notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/service/notifications/models"
+	"github.com/m04kA/SMC-NotificationService/internal/service/telegram"
+)
+
+// previewNotificationID ID уведомления, с которым подписываются кнопки предпросмотра
+// Уведомление не сохраняется, поэтому нажатие callback-кнопки в тестовом чате ответит, что уведомление не найдено
+const previewNotificationID = 0
+
+// Result результат предпросмотра
+type Result struct {
+	Notification   *domain.Notification     // Проверенное уведомление (не сохранено, без ID)
+	Requests       []domain.TelegramRequest // Запросы к Telegram Bot API, которые будут выполнены при отправке
+	SentChatID     *int64                   // Чат, в который отправлен предпросмотр
+	SentMessageIDs []int                    // ID отправленных сообщений
+}
+
+// UseCase формирует предпросмотр уведомления без сохранения и отправки получателю
+type UseCase struct {
+	notificationService NotificationService
+	messageBuilder      MessageBuilder
+	callbackSigner      CallbackSigner
+	telegramService     TelegramService
+	testChatIDs         map[int64]struct{}
+}
+
+// New создаёт use case предпросмотра
+// testChatIDs - чаты, в которые разрешено отправлять предпросмотр
+func New(notificationService NotificationService, messageBuilder MessageBuilder, callbackSigner CallbackSigner, telegramService TelegramService, testChatIDs []int64) *UseCase {
+	allowed := make(map[int64]struct{}, len(testChatIDs))
+	for _, id := range testChatIDs {
+		allowed[id] = struct{}{}
+	}
+
+	return &UseCase{
+		notificationService: notificationService,
+		messageBuilder:      messageBuilder,
+		callbackSigner:      callbackSigner,
+		telegramService:     telegramService,
+		testChatIDs:         allowed,
+	}
+}
+
+// Execute проверяет и рендерит уведомление так же, как при создании и отправке, и возвращает запросы к Telegram
+// sendToChatID - если указан, предпросмотр дополнительно отправляется в этот тестовый чат
+func (uc *UseCase) Execute(ctx context.Context, input *models.CreateNotificationInput, sendToChatID *int64) (*Result, error) {
+	if sendToChatID != nil {
+		if _, ok := uc.testChatIDs[*sendToChatID]; !ok {
+			return nil, fmt.Errorf("%w: %d", ErrChatNotAllowed, *sendToChatID)
+		}
+	}
+
+	notification, err := uc.notificationService.Prepare(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := uc.messageBuilder.BuildMessage(ctx, notification)
+	if err != nil {
+		return nil, fmt.Errorf("usecase.PreviewNotification: build message: %w", err)
+	}
+
+	msg.InlineButtons, err = domain.InlineButtons(msg.InlineButtons).WithSignedCallbacks(func(action string) (string, error) {
+		return uc.callbackSigner.Sign(action, previewNotificationID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("usecase.PreviewNotification: sign callbacks: %w", err)
+	}
+
+	requests, err := uc.telegramService.Preview(msg)
+	if err != nil {
+		return nil, fmt.Errorf("usecase.PreviewNotification: preview: %w", err)
+	}
+
+	result := &Result{
+		Notification: notification,
+		Requests:     requests,
+	}
+
+	if sendToChatID == nil {
+		return result, nil
+	}
+
+	testMsg := *msg
+	testMsg.ChatID = *sendToChatID
+
+	messages, err := uc.telegramService.SendMessage(&testMsg)
+	// Как и при обычной отправке: Telegram не смог разобрать разметку - повторяем без форматирования
+	if errors.Is(err, telegram.ErrParseEntities) && testMsg.IsHTML() {
+		messages, err = uc.telegramService.SendMessage(testMsg.AsPlainText())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSend, err)
+	}
+
+	result.SentChatID = sendToChatID
+	result.SentMessageIDs = messages.IDs()
+	return result, nil
+}
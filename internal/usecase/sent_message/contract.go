@@ -0,0 +1,31 @@
+package sent_message
+
+import (
+	"context"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// NotificationRepository интерфейс для работы с уведомлениями
+type NotificationRepository interface {
+	GetByID(ctx context.Context, id int64) (*domain.Notification, error)
+	MarkMessagesEdited(ctx context.Context, id int64, messages domain.SentMessages, editedAt time.Time) error
+	MarkMessagesDeleted(ctx context.Context, id int64, deletedAt time.Time) error
+}
+
+// MessageBuilder интерфейс для подготовки сообщения (рендеринг шаблона)
+type MessageBuilder interface {
+	BuildMessage(ctx context.Context, notification *domain.Notification) (*domain.TelegramMessage, error)
+}
+
+// CallbackSigner интерфейс для подписи callback_data кнопок
+type CallbackSigner interface {
+	Sign(payload string, notificationID int64) (string, error)
+}
+
+// TelegramService интерфейс для изменения и удаления отправленных сообщений
+type TelegramService interface {
+	EditSentMessages(msg *domain.TelegramMessage, sent domain.SentMessages) (domain.SentMessages, error)
+	DeleteSentMessages(chatID int64, sent domain.SentMessages) error
+}
@@ -0,0 +1,26 @@
+package sent_message
+
+import "errors"
+
+var (
+	// ErrNotificationNotFound возвращается, если уведомление не найдено
+	ErrNotificationNotFound = errors.New("usecase.sent_message: notification not found")
+
+	// ErrNotSent возвращается, если у уведомления нет отправленных сообщений
+	ErrNotSent = errors.New("usecase.sent_message: notification has no sent messages")
+
+	// ErrAlreadyDeleted возвращается, если сообщения уведомления уже удалены из чата
+	ErrAlreadyDeleted = errors.New("usecase.sent_message: messages already deleted")
+
+	// ErrInvalidInput возвращается при некорректных изменениях
+	ErrInvalidInput = errors.New("usecase.sent_message: invalid input")
+
+	// ErrNotEditable возвращается, если изменение нельзя применить к отправленным сообщениям
+	ErrNotEditable = errors.New("usecase.sent_message: sent messages can't be edited this way")
+
+	// ErrTelegram возвращается, если Telegram не принял изменение или удаление
+	ErrTelegram = errors.New("usecase.sent_message: telegram request failed")
+
+	// ErrInternal возвращается при внутренних ошибках
+	ErrInternal = errors.New("usecase.sent_message: internal error")
+)
@@ -0,0 +1,155 @@
+package sent_message
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/infra/storage/notification"
+	"github.com/m04kA/SMC-NotificationService/internal/service/telegram"
+	"github.com/m04kA/SMC-NotificationService/pkg/tghtml"
+)
+
+// EditInput изменения отправленного уведомления
+// Незаданные поля остаются такими, какими были при отправке
+type EditInput struct {
+	Text          *string                // Новый текст (или подпись, если текст отправлен подписью к файлу)
+	ParseMode     *string                // Режим парсинга нового текста: HTML или пустая строка
+	InlineButtons *[]domain.InlineButton // Новые кнопки; пустой список убирает кнопки
+}
+
+// UseCase изменяет и удаляет сообщения уже отправленных уведомлений
+type UseCase struct {
+	notificationRepo NotificationRepository
+	messageBuilder   MessageBuilder
+	callbackSigner   CallbackSigner
+	telegramService  TelegramService
+}
+
+// New создаёт use case работы с отправленными сообщениями
+func New(notificationRepo NotificationRepository, messageBuilder MessageBuilder, callbackSigner CallbackSigner, telegramService TelegramService) *UseCase {
+	return &UseCase{
+		notificationRepo: notificationRepo,
+		messageBuilder:   messageBuilder,
+		callbackSigner:   callbackSigner,
+		telegramService:  telegramService,
+	}
+}
+
+// Edit изменяет текст и кнопки отправленного уведомления на месте, например "Запись подтверждена" -> "Запись отменена"
+// Содержимое уведомления в БД не меняется: сохраняются только итоговые сообщения и время изменения
+func (uc *UseCase) Edit(ctx context.Context, id int64, input *EditInput) (*domain.Notification, error) {
+	n, err := uc.getSent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := uc.messageBuilder.BuildMessage(ctx, n)
+	if err != nil {
+		return nil, fmt.Errorf("%w: build message: %v", ErrInternal, err)
+	}
+
+	if err := applyEdit(msg, input); err != nil {
+		return nil, err
+	}
+
+	msg.InlineButtons, err = domain.InlineButtons(msg.InlineButtons).WithSignedCallbacks(func(action string) (string, error) {
+		return uc.callbackSigner.Sign(action, n.ID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: sign callbacks: %v", ErrInternal, err)
+	}
+
+	messages, err := uc.telegramService.EditSentMessages(msg, n.SentMessages)
+	if err != nil {
+		if errors.Is(err, telegram.ErrNotEditable) || errors.Is(err, telegram.ErrTextTooLong) {
+			return nil, fmt.Errorf("%w: %v", ErrNotEditable, err)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrTelegram, err)
+	}
+
+	editedAt := time.Now()
+	if err := uc.notificationRepo.MarkMessagesEdited(ctx, n.ID, messages, editedAt); err != nil {
+		return nil, fmt.Errorf("%w: save edited messages: %v", ErrInternal, err)
+	}
+
+	n.SentMessages = messages
+	n.MessagesEditedAt = &editedAt
+	return n, nil
+}
+
+// Delete удаляет отправленные сообщения уведомления из чата
+func (uc *UseCase) Delete(ctx context.Context, id int64) error {
+	n, err := uc.getSent(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.telegramService.DeleteSentMessages(n.GetChatID(), n.SentMessages); err != nil {
+		return fmt.Errorf("%w: %v", ErrTelegram, err)
+	}
+
+	if err := uc.notificationRepo.MarkMessagesDeleted(ctx, n.ID, time.Now()); err != nil {
+		return fmt.Errorf("%w: save deletion: %v", ErrInternal, err)
+	}
+
+	return nil
+}
+
+// getSent загружает уведомление и проверяет, что его сообщения отправлены и ещё не удалены
+func (uc *UseCase) getSent(ctx context.Context, id int64) (*domain.Notification, error) {
+	n, err := uc.notificationRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, notification.ErrNotificationNotFound) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, fmt.Errorf("%w: get notification: %v", ErrInternal, err)
+	}
+
+	if len(n.SentMessages) == 0 {
+		return nil, ErrNotSent
+	}
+
+	if n.MessagesDeletedAt != nil {
+		return nil, ErrAlreadyDeleted
+	}
+
+	return n, nil
+}
+
+// applyEdit применяет изменения к сообщению с теми же проверками, что и при создании уведомления
+func applyEdit(msg *domain.TelegramMessage, input *EditInput) error {
+	if input.Text == nil && input.ParseMode == nil && input.InlineButtons == nil {
+		return fmt.Errorf("%w: nothing to change", ErrInvalidInput)
+	}
+
+	if input.ParseMode != nil {
+		if *input.ParseMode != domain.ParseModeHTML && *input.ParseMode != domain.ParseModePlain {
+			return fmt.Errorf("%w: parse_mode must be %q or empty", ErrInvalidInput, domain.ParseModeHTML)
+		}
+		msg.ParseMode = *input.ParseMode
+	}
+
+	if input.Text != nil {
+		if *input.Text == "" {
+			return fmt.Errorf("%w: text must not be empty", ErrInvalidInput)
+		}
+		msg.MessageText = *input.Text
+	}
+
+	// Неподдерживаемые теги и одиночные "<", "&" экранируются, поэтому Telegram не отклонит изменение
+	if msg.IsHTML() {
+		msg.MessageText = tghtml.Sanitize(msg.MessageText)
+	}
+
+	if input.InlineButtons != nil {
+		if err := domain.InlineButtons(*input.InlineButtons).Validate(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+		msg.InlineButtons = *input.InlineButtons
+	}
+
+	return nil
+}
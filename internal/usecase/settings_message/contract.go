@@ -0,0 +1,21 @@
+package settings_message
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/service/preferences/models"
+)
+
+// TelegramService интерфейс для отправки и изменения сообщения с настройками
+type TelegramService interface {
+	SendMessage(msg *domain.TelegramMessage) (domain.SentMessages, error)
+	EditSentMessages(msg *domain.TelegramMessage, sent domain.SentMessages) (domain.SentMessages, error)
+	AnswerCallbackQuery(callbackQueryID, text string, showAlert bool) error
+}
+
+// PreferencesService интерфейс настроек уведомлений пользователей
+type PreferencesService interface {
+	Get(ctx context.Context, tgUserID int64) (*domain.UserPreferences, error)
+	Update(ctx context.Context, tgUserID int64, input *models.UpdatePreferencesInput) (*domain.UserPreferences, error)
+}
@@ -0,0 +1,54 @@
+package settings_message
+
+import "github.com/m04kA/SMC-NotificationService/internal/domain"
+
+// settingsTexts тексты настроек на одном языке
+type settingsTexts struct {
+	Header      string                                // Заголовок сообщения с настройками
+	Mandatory   string                                // Пояснение про обязательные уведомления
+	PrivateOnly string                                // Ответ на /settings в группе
+	Saved       string                                // Ответ на нажатие переключателя
+	Invalid     string                                // Ответ на устаревшую или повреждённую кнопку
+	Types       map[domain.NotificationType]string    // Названия типов уведомлений
+	Channels    map[domain.NotificationChannel]string // Названия каналов доставки
+}
+
+// texts варианты настроек по языкам
+var texts = map[string]settingsTexts{
+	"ru": {
+		Header:      "<b>Настройки уведомлений</b>\nНажмите на пункт, чтобы включить или отключить его.",
+		Mandatory:   "Уведомления о бронированиях приходят всегда.",
+		PrivateOnly: "Настройки уведомлений доступны в личном чате с ботом.",
+		Saved:       "Сохранено",
+		Invalid:     "Кнопка устарела, откройте /settings заново",
+		Types: map[domain.NotificationType]string{
+			domain.NotificationTypePromo:   "Акции и предложения",
+			domain.NotificationTypeWelcome: "Приветственные сообщения",
+		},
+		Channels: map[domain.NotificationChannel]string{
+			domain.NotificationChannelTelegram: "Все уведомления в Telegram",
+		},
+	},
+	"en": {
+		Header:      "<b>Notification settings</b>\nTap an item to turn it on or off.",
+		Mandatory:   "Booking notifications are always delivered.",
+		PrivateOnly: "Notification settings are available in a private chat with the bot.",
+		Saved:       "Saved",
+		Invalid:     "This button is outdated, open /settings again",
+		Types: map[domain.NotificationType]string{
+			domain.NotificationTypePromo:   "Promotions and offers",
+			domain.NotificationTypeWelcome: "Welcome messages",
+		},
+		Channels: map[domain.NotificationChannel]string{
+			domain.NotificationChannelTelegram: "All notifications in Telegram",
+		},
+	},
+}
+
+// getTexts возвращает тексты для языка (или для языка по умолчанию)
+func getTexts(locale string) settingsTexts {
+	if t, ok := texts[domain.NormalizeLocale(locale)]; ok {
+		return t
+	}
+	return texts[domain.DefaultLocale]
+}
@@ -0,0 +1,185 @@
+package settings_message
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/service/preferences/models"
+)
+
+// CallbackPrefix префикс callback_data переключателей настроек
+// Данные не подписываются: переключатель меняет настройки только того, кто нажал кнопку
+const CallbackPrefix = "settings:"
+
+// Виды переключателей в callback_data: "settings:<вид>:<значение>"
+const (
+	toggleType    = "type"
+	toggleChannel = "channel"
+)
+
+// Отметки состояния переключателя
+const (
+	markEnabled  = "✅"
+	markDisabled = "🔕"
+)
+
+// UseCase показывает настройки уведомлений по /settings и переключает их inline-кнопками
+type UseCase struct {
+	telegramService TelegramService
+	preferences     PreferencesService
+}
+
+// New создаёт use case настроек уведомлений
+func New(telegramService TelegramService, preferences PreferencesService) *UseCase {
+	return &UseCase{
+		telegramService: telegramService,
+		preferences:     preferences,
+	}
+}
+
+// Execute отправляет настройки уведомлений с переключателями
+// Настройки личные, поэтому в группах показывается только подсказка
+func (uc *UseCase) Execute(ctx context.Context, msg *tgbotapi.Message, _ string) error {
+	if msg.From == nil {
+		return nil
+	}
+	t := getTexts(msg.From.LanguageCode)
+
+	if !msg.Chat.IsPrivate() {
+		reply := &domain.TelegramMessage{ChatID: msg.Chat.ID, MessageText: html.EscapeString(t.PrivateOnly), ParseMode: domain.ParseModeHTML}
+		if _, err := uc.telegramService.SendMessage(reply); err != nil {
+			return fmt.Errorf("usecase.SettingsMessage: send hint to chat %d: %w", msg.Chat.ID, err)
+		}
+		return nil
+	}
+
+	preferences, err := uc.preferences.Get(ctx, msg.From.ID)
+	if err != nil {
+		return fmt.Errorf("usecase.SettingsMessage: get preferences of user %d: %w", msg.From.ID, err)
+	}
+
+	if _, err := uc.telegramService.SendMessage(settingsMessage(msg.Chat.ID, preferences, t)); err != nil {
+		return fmt.Errorf("usecase.SettingsMessage: send settings to chat %d: %w", msg.Chat.ID, err)
+	}
+
+	return nil
+}
+
+// Toggle переключает настройку по нажатию кнопки и обновляет сообщение с настройками
+// Пользователь всегда получает ответ, чтобы кнопка не «зависла»
+func (uc *UseCase) Toggle(ctx context.Context, query *tgbotapi.CallbackQuery) error {
+	if query.From == nil {
+		return nil
+	}
+	t := getTexts(query.From.LanguageCode)
+
+	input, ok := parseToggle(strings.TrimPrefix(query.Data, CallbackPrefix))
+	if !ok || query.Message == nil || query.Message.Chat == nil {
+		return uc.answer(query.ID, t.Invalid, true)
+	}
+
+	preferences, err := uc.preferences.Get(ctx, query.From.ID)
+	if err != nil {
+		_ = uc.answer(query.ID, "", false)
+		return fmt.Errorf("usecase.SettingsMessage: get preferences of user %d: %w", query.From.ID, err)
+	}
+
+	// Кнопка хранит только что переключать: новое значение вычисляется от текущих настроек
+	for typ := range input.Types {
+		input.Types[typ] = !preferences.IsTypeEnabled(typ)
+	}
+	for channel := range input.Channels {
+		input.Channels[channel] = !preferences.IsChannelEnabled(channel)
+	}
+
+	preferences, err = uc.preferences.Update(ctx, query.From.ID, input)
+	if err != nil {
+		// Пользователь увидел отказ: повтор молча применил бы переключение позже
+		_ = uc.answer(query.ID, t.Invalid, true)
+		return fmt.Errorf("usecase.SettingsMessage: %w: update preferences of user %d: %w", domain.ErrUpdatePartiallyHandled, query.From.ID, err)
+	}
+
+	// Переключение применено: повтор обновления вернул бы настройку обратно
+	settings := settingsMessage(query.Message.Chat.ID, preferences, t)
+	sent := domain.SentMessages{{MessageID: query.Message.MessageID, Role: domain.SentMessageRoleText, HasKeyboard: true}}
+	if _, err := uc.telegramService.EditSentMessages(settings, sent); err != nil {
+		_ = uc.answer(query.ID, t.Saved, false)
+		return fmt.Errorf("usecase.SettingsMessage: %w: edit settings message %d: %w", domain.ErrUpdatePartiallyHandled, query.Message.MessageID, err)
+	}
+
+	if err := uc.answer(query.ID, t.Saved, false); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrUpdatePartiallyHandled, err)
+	}
+
+	return nil
+}
+
+// answer отвечает на нажатие кнопки
+func (uc *UseCase) answer(callbackQueryID, text string, showAlert bool) error {
+	if err := uc.telegramService.AnswerCallbackQuery(callbackQueryID, text, showAlert); err != nil {
+		return fmt.Errorf("usecase.SettingsMessage: answer callback %s: %w", callbackQueryID, err)
+	}
+	return nil
+}
+
+// settingsMessage формирует сообщение с настройками и переключателями
+func settingsMessage(chatID int64, preferences *domain.UserPreferences, t settingsTexts) *domain.TelegramMessage {
+	var buttons []domain.InlineButton
+	for _, typ := range domain.OptionalNotificationTypes {
+		buttons = append(buttons, toggleButton(t.Types[typ], preferences.IsTypeEnabled(typ), toggleType, string(typ)))
+	}
+	for _, channel := range domain.NotificationChannels {
+		buttons = append(buttons, toggleButton(t.Channels[channel], preferences.IsChannelEnabled(channel), toggleChannel, string(channel)))
+	}
+
+	return &domain.TelegramMessage{
+		ChatID:        chatID,
+		MessageText:   t.Header + "\n\n" + html.EscapeString(t.Mandatory),
+		InlineButtons: buttons,
+		ParseMode:     domain.ParseModeHTML,
+	}
+}
+
+// toggleButton формирует кнопку переключателя с отметкой текущего состояния
+func toggleButton(label string, enabled bool, kind, value string) domain.InlineButton {
+	mark := markEnabled
+	if !enabled {
+		mark = markDisabled
+	}
+
+	return domain.InlineButton{
+		Kind:         domain.ButtonKindCallback,
+		Text:         mark + " " + label,
+		CallbackData: CallbackPrefix + kind + ":" + value,
+	}
+}
+
+// parseToggle разбирает "<вид>:<значение>" в изменение настроек
+// Значения переключателей заполняются по текущим настройкам
+func parseToggle(data string) (*models.UpdatePreferencesInput, bool) {
+	kind, value, ok := strings.Cut(data, ":")
+	if !ok {
+		return nil, false
+	}
+
+	switch kind {
+	case toggleType:
+		typ := domain.NotificationType(value)
+		if typ.IsMandatory() {
+			return nil, false
+		}
+		return &models.UpdatePreferencesInput{Types: map[domain.NotificationType]bool{typ: false}}, true
+	case toggleChannel:
+		channel := domain.NotificationChannel(value)
+		if !channel.IsValid() {
+			return nil, false
+		}
+		return &models.UpdatePreferencesInput{Channels: map[domain.NotificationChannel]bool{channel: false}}, true
+	default:
+		return nil, false
+	}
+}
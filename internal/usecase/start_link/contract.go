@@ -0,0 +1,9 @@
+package start_link
+
+import "github.com/m04kA/SMC-NotificationService/pkg/startparam"
+
+// StartParamSigner интерфейс подписи и разбора deep-link параметров
+type StartParamSigner interface {
+	Sign(kind, value string) (string, error)
+	Parse(param string) (*startparam.Payload, error)
+}
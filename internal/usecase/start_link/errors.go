@@ -0,0 +1,8 @@
+package start_link
+
+import "errors"
+
+var (
+	// ErrInvalidInput возвращается при некорректном виде или значении ссылки
+	ErrInvalidInput = errors.New("usecase.start_link: invalid input")
+)
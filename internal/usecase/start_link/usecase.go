@@ -0,0 +1,67 @@
+package start_link
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+// botLinkBase адрес deep-link ссылок на бота
+const botLinkBase = "https://t.me/"
+
+// StartLink deep-link ссылка на бота
+type StartLink struct {
+	Kind       domain.StartKind
+	Value      string
+	StartParam string // Параметр start (подписанный для company, booking, ref)
+	URL        string // https://t.me/<bot>?start=<param>
+}
+
+// UseCase формирует deep-link ссылки /start для других сервисов (приглашения компаний, ссылки на бронирования, рефералы)
+type UseCase struct {
+	signer      StartParamSigner
+	botUserName string
+}
+
+// New создаёт use case формирования deep-link ссылок
+// botUserName - имя бота без "@" (из getMe)
+func New(signer StartParamSigner, botUserName string) *UseCase {
+	return &UseCase{
+		signer:      signer,
+		botUserName: botUserName,
+	}
+}
+
+// Create формирует ссылку заданного вида
+// Метка источника (source) не подписывается: она не даёт доступа к данным и нужна только для аналитики
+func (uc *UseCase) Create(kind domain.StartKind, value string) (*StartLink, error) {
+	if !kind.IsValid() {
+		return nil, fmt.Errorf("%w: unknown kind %q (company, booking, ref or source expected)", ErrInvalidInput, kind)
+	}
+
+	param := value
+	if kind != domain.StartKindSource {
+		signed, err := uc.signer.Sign(string(kind), value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+		param = signed
+	}
+
+	// Ссылка должна разбираться ботом в тот же вид: метка источника не может совпадать с подписанным форматом
+	payload, err := uc.signer.Parse(param)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if payload.Kind != string(kind) || payload.Value != value {
+		return nil, fmt.Errorf("%w: source label must not start with a signed kind prefix", ErrInvalidInput)
+	}
+
+	return &StartLink{
+		Kind:       kind,
+		Value:      value,
+		StartParam: param,
+		URL:        botLinkBase + uc.botUserName + "?start=" + url.QueryEscape(param),
+	}, nil
+}
@@ -2,13 +2,29 @@ package start_message
 
 import (
 	"context"
+	"time"
 
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
 	"github.com/m04kA/SMC-NotificationService/internal/integrations/userservice"
+	"github.com/m04kA/SMC-NotificationService/pkg/startparam"
 )
 
 // TelegramService интерфейс для работы с Telegram Bot API
 type TelegramService interface {
-	SendWelcomeMessage(chatID int64, tgUserID *int64) error
+	SendWelcomeMessage(chatID int64, tgUserID *int64, locale string, start *domain.StartPayload) error
+}
+
+// BotUserRepository интерфейс для сохранения настроек пользователя бота
+type BotUserRepository interface {
+	SaveLanguage(ctx context.Context, tgUserID int64, languageCode string) error
+	SaveAttribution(ctx context.Context, tgUserID int64, payload *domain.StartPayload, attributedAt time.Time) (bool, error)
+}
+
+// UpdateStepRepository интерфейс неповторяемых шагов обработки обновления
+// Приветствие запоминается для update_id, чтобы повторная обработка не отправила его второй раз
+type UpdateStepRepository interface {
+	IsStepDone(ctx context.Context, updateID int64, step domain.BotUpdateStep) (bool, error)
+	MarkStepDone(ctx context.Context, updateID int64, step domain.BotUpdateStep) error
 }
 
 // UserServiceClient интерфейс для работы с UserService
@@ -16,3 +32,25 @@ type UserServiceClient interface {
 	GetUser(ctx context.Context, tgUserID int64) (*userservice.User, error)
 	CreateUser(ctx context.Context, req *userservice.CreateUserRequest) (*userservice.User, error)
 }
+
+// ReachabilityService интерфейс доступности чатов
+type ReachabilityService interface {
+	Restore(ctx context.Context, chatID int64, source domain.ReachabilitySource) (bool, error)
+}
+
+// PhoneRequester интерфейс запроса номера телефона у пользователя
+type PhoneRequester interface {
+	RequestPhone(chatID int64, locale string) error
+}
+
+// Logger интерфейс для логирования
+type Logger interface {
+	Info(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+}
+
+// StartParamParser интерфейс разбора и проверки подписи deep-link параметров
+type StartParamParser interface {
+	Parse(param string) (*startparam.Payload, error)
+}
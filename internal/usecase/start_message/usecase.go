@@ -4,8 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
 	"github.com/m04kA/SMC-NotificationService/internal/integrations/userservice"
 )
 
@@ -18,54 +22,177 @@ const (
 type UseCase struct {
 	telegramService   TelegramService
 	userServiceClient UserServiceClient
+	botUserRepo       BotUserRepository
+	startParams       StartParamParser
+	reachability      ReachabilityService
+	phoneRequester    PhoneRequester
+	updateSteps       UpdateStepRepository
+	logger            Logger
 }
 
 // New создаёт новый use case для обработки /start
-func New(telegramService TelegramService, userServiceClient UserServiceClient) *UseCase {
+func New(telegramService TelegramService, userServiceClient UserServiceClient, botUserRepo BotUserRepository, startParams StartParamParser, reachability ReachabilityService, phoneRequester PhoneRequester, updateSteps UpdateStepRepository, logger Logger) *UseCase {
 	return &UseCase{
 		telegramService:   telegramService,
 		userServiceClient: userServiceClient,
+		botUserRepo:       botUserRepo,
+		startParams:       startParams,
+		reachability:      reachability,
+		phoneRequester:    phoneRequester,
+		updateSteps:       updateSteps,
+		logger:            logger,
 	}
 }
 
 // Execute выполняет обработку команды /start
-// Возвращает ошибку с полным контекстом для логирования на уровне выше
-func (uc *UseCase) Execute(ctx context.Context, from *tgbotapi.User, chatID int64) error {
-	// Определяем tgUserID (может быть nil если from == nil)
+// args - deep-link параметр из ссылки t.me/<bot>?start=<param>: выбирает вариант приветствия
+// и сохраняется как источник привлечения пользователя
+// Отправленное приветствие запоминается для update_id: повторная обработка его пропускает, а сохранение
+// пользователя, источника привлечения и запрос телефона повторяет. Вне очереди обновлений (нет update_id)
+// ошибка после приветствия помечается domain.ErrUpdatePartiallyHandled
+func (uc *UseCase) Execute(ctx context.Context, msg *tgbotapi.Message, args string) error {
+	from := msg.From
+	chatID := msg.Chat.ID
+
+	// Определяем tgUserID и язык (могут быть пустыми если from == nil)
 	var tgUserID *int64
+	var locale string
 	if from != nil {
 		userID := from.ID
 		tgUserID = &userID
+		locale = domain.NormalizeLocale(from.LanguageCode)
+	}
+
+	// Поддельная или повреждённая ссылка не должна мешать приветствию - показываем основной вариант
+	start, err := uc.parseStartParam(args)
+	if err != nil {
+		uc.logger.Warn("Invalid start parameter %q in chat %d: %v", args, chatID, err)
+	}
+
+	updateID, tracked := domain.UpdateIDFromContext(ctx)
+
+	if err := uc.sendWelcome(ctx, updateID, tracked, chatID, tgUserID, locale, start); err != nil {
+		return err
+	}
+
+	if err := uc.afterWelcome(ctx, msg, locale, start); err != nil {
+		if tracked {
+			return fmt.Errorf("usecase.SendStartMessage: %w", err)
+		}
+		return fmt.Errorf("usecase.SendStartMessage: %w: %w", domain.ErrUpdatePartiallyHandled, err)
+	}
+
+	return nil
+}
+
+// sendWelcome отправляет приветствие, если прошлая попытка обработки этого обновления его ещё не отправила
+func (uc *UseCase) sendWelcome(ctx context.Context, updateID int64, tracked bool, chatID int64, tgUserID *int64, locale string, start *domain.StartPayload) error {
+	if tracked {
+		done, err := uc.updateSteps.IsStepDone(ctx, updateID, domain.BotUpdateStepWelcome)
+		if err != nil {
+			return fmt.Errorf("usecase.SendStartMessage: check welcome of update %d: %w", updateID, err)
+		}
+		if done {
+			return nil
+		}
 	}
 
 	// Отправляем приветственное сообщение сразу с tgUserID
-	if err := uc.telegramService.SendWelcomeMessage(chatID, tgUserID); err != nil {
+	if err := uc.telegramService.SendWelcomeMessage(chatID, tgUserID, locale, start); err != nil {
 		return fmt.Errorf("usecase.SendStartMessage: send welcome message to chat %d: %w", chatID, err)
 	}
 
+	if !tracked {
+		return nil
+	}
+
+	// Приветствие отправлено, но не запомнено: повтор отправил бы его снова
+	if err := uc.updateSteps.MarkStepDone(ctx, updateID, domain.BotUpdateStepWelcome); err != nil {
+		return fmt.Errorf("usecase.SendStartMessage: %w: save welcome of update %d: %w", domain.ErrUpdatePartiallyHandled, updateID, err)
+	}
+
+	return nil
+}
+
+// afterWelcome сохраняет данные пользователя после отправки приветствия и предлагает поделиться номером телефона
+func (uc *UseCase) afterWelcome(ctx context.Context, msg *tgbotapi.Message, locale string, start *domain.StartPayload) error {
+	from := msg.From
+	chatID := msg.Chat.ID
+
+	// Пользователь снова написал боту - чат доступен, даже если раньше бот был заблокирован
+	if _, err := uc.reachability.Restore(ctx, chatID, domain.ReachabilitySourceStart); err != nil {
+		return fmt.Errorf("restore reachability of chat %d: %w", chatID, err)
+	}
+
+	if from == nil {
+		return nil
+	}
+
+	// Запоминаем язык пользователя для последующих уведомлений
+	if locale != "" {
+		if err := uc.botUserRepo.SaveLanguage(ctx, from.ID, locale); err != nil {
+			return fmt.Errorf("save language for user %d: %w", from.ID, err)
+		}
+	}
+
+	// Запоминаем источник привлечения (учитывается только первая ссылка)
+	if start != nil {
+		if _, err := uc.botUserRepo.SaveAttribution(ctx, from.ID, start, time.Now()); err != nil {
+			return fmt.Errorf("save attribution for user %d: %w", from.ID, err)
+		}
+	}
+
 	// Проверяем существование пользователя и создаём при необходимости
-	if from != nil {
-		if err := uc.ensureUserExists(ctx, from); err != nil {
-			return fmt.Errorf("usecase.SendStartMessage: ensure user %d exists: %w", from.ID, err)
+	user, err := uc.ensureUserExists(ctx, from, start)
+	if err != nil {
+		return fmt.Errorf("ensure user %d exists: %w", from.ID, err)
+	}
+
+	// Номер телефона нужен для бронирований: предлагаем поделиться им кнопкой, пока он не сохранён
+	if user.PhoneNumber == "" && msg.Chat.IsPrivate() {
+		if err := uc.phoneRequester.RequestPhone(chatID, locale); err != nil {
+			return fmt.Errorf("request phone of user %d: %w", from.ID, err)
 		}
 	}
 
 	return nil
 }
 
+// parseStartParam разбирает deep-link параметр команды /start
+// Возвращает nil без ошибки, если параметра нет
+func (uc *UseCase) parseStartParam(args string) (*domain.StartPayload, error) {
+	param := strings.TrimSpace(args)
+	if param == "" {
+		return nil, nil
+	}
+
+	payload, err := uc.startParams.Parse(param)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.StartPayload{
+		Kind:  domain.StartKind(payload.Kind),
+		Value: payload.Value,
+		Param: param,
+	}, nil
+}
+
 // ensureUserExists проверяет существование пользователя и создаёт его при необходимости
-func (uc *UseCase) ensureUserExists(ctx context.Context, from *tgbotapi.User) error {
+// start передаётся в UserService как источник привлечения нового пользователя
+// Возвращает существующего или созданного пользователя
+func (uc *UseCase) ensureUserExists(ctx context.Context, from *tgbotapi.User, start *domain.StartPayload) (*userservice.User, error) {
 	tgUserID := from.ID
 
 	// Проверяем, существует ли пользователь
-	_, err := uc.userServiceClient.GetUser(ctx, tgUserID)
+	user, err := uc.userServiceClient.GetUser(ctx, tgUserID)
 	if err == nil {
-		return nil // Пользователь уже существует
+		return user, nil // Пользователь уже существует
 	}
 
 	// Если пользователь не найден - создаём
 	if !errors.Is(err, userservice.ErrUserNotFound) {
-		return fmt.Errorf("check user existence: %w", err)
+		return nil, fmt.Errorf("check user existence: %w", err)
 	}
 
 	// Формируем имя пользователя
@@ -88,10 +215,37 @@ func (uc *UseCase) ensureUserExists(ctx context.Context, from *tgbotapi.User) er
 		TgLink:   tgLink,
 		Role:     DefaultUserRole,
 	}
+	if start != nil {
+		createReq.Attribution = toUserServiceAttribution(start)
+	}
 
-	if _, err := uc.userServiceClient.CreateUser(ctx, createReq); err != nil {
-		return fmt.Errorf("create user '%s': %w", userName, err)
+	user, err = uc.userServiceClient.CreateUser(ctx, createReq)
+	if err != nil {
+		return nil, fmt.Errorf("create user '%s': %w", userName, err)
 	}
 
-	return nil
+	return user, nil
+}
+
+// toUserServiceAttribution преобразует deep-link параметр в источник привлечения UserService
+func toUserServiceAttribution(start *domain.StartPayload) *userservice.Attribution {
+	attribution := &userservice.Attribution{Type: string(start.Kind)}
+	value := start.Value
+
+	switch start.Kind {
+	case domain.StartKindCompany, domain.StartKindBooking:
+		// Значение подписано и проверено при разборе
+		id, _ := strconv.ParseInt(start.Value, 10, 64)
+		if start.Kind == domain.StartKindCompany {
+			attribution.CompanyID = &id
+		} else {
+			attribution.BookingID = &id
+		}
+	case domain.StartKindReferral:
+		attribution.ReferralCode = &value
+	default:
+		attribution.Source = &value
+	}
+
+	return attribution
 }
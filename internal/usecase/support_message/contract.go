@@ -0,0 +1,31 @@
+package support_message
+
+import (
+	"context"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/integrations/userservice"
+)
+
+// TelegramService интерфейс для пересылки сообщений в чат операторов и ответов пользователю
+type TelegramService interface {
+	SendMessage(msg *domain.TelegramMessage) (domain.SentMessages, error)
+}
+
+// SupportService интерфейс переписки с поддержкой
+type SupportService interface {
+	SaveInbound(ctx context.Context, tgUserID int64, userMessageID int, text string) (*domain.SupportMessage, error)
+	SetForwarded(ctx context.Context, id int64, operatorChatID int64, operatorMessageID int) error
+	FindForwarded(ctx context.Context, operatorChatID int64, operatorMessageID int) (*domain.SupportMessage, error)
+	SaveReply(ctx context.Context, reply *domain.SupportMessage) error
+}
+
+// UserServiceClient интерфейс для получения данных пользователя из UserService
+type UserServiceClient interface {
+	GetUser(ctx context.Context, tgUserID int64) (*userservice.User, error)
+}
+
+// ReachabilityService интерфейс доступности чатов
+type ReachabilityService interface {
+	MarkUnreachable(ctx context.Context, chatID int64, reason domain.UnreachableReason, source domain.ReachabilitySource, errorMessage string) error
+}
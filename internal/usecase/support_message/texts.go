@@ -0,0 +1,21 @@
+package support_message
+
+// Тексты для чата операторов
+const (
+	operatorHeader      = "📩 <b>%s</b>"                                                   // Имя пользователя
+	operatorUsername    = "Telegram: %s (ID <code>%d</code>)"                             // @username и Telegram ID
+	operatorUserID      = "Telegram ID: <code>%d</code>"                                  // Пользователь без username
+	operatorPhone       = "Телефон: %s"                                                   // Телефон из UserService
+	operatorReplyHint   = "<i>Ответьте на это сообщение, чтобы написать пользователю</i>" // Подсказка под сообщением
+	operatorReplyFailed = "Не удалось отправить ответ пользователю: %s"                   // Ошибка отправки ответа
+	operatorUnreachable = "Пользователь заблокировал бота, ответ не доставлен"            // Пользователь недоступен
+	operatorEmptyReply  = "Отправить можно только текст"                                  // Ответ без текста
+	operatorUnknownUser = "Пользователь"                                                  // Имя, если его не удалось определить
+)
+
+// attachmentLabel пометка для сообщения без текста (стикер, голосовое, файл без подписи)
+const attachmentLabel = "[вложение без текста]"
+
+// maxForwardedTextRunes максимальная длина текста пользователя в чате операторов
+// Сообщение с заголовком должно уместиться в одно сообщение Telegram (4096 символов), полный текст хранится в БД
+const maxForwardedTextRunes = 3500
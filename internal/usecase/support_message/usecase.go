@@ -0,0 +1,210 @@
+package support_message
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/service/support"
+	"github.com/m04kA/SMC-NotificationService/internal/service/telegram"
+)
+
+// UseCase обрабатывает переписку пользователей с поддержкой
+// Сообщения пользователя в личном чате сохраняются и пересылаются в чат операторов,
+// ответ оператора на пересланное сообщение отправляется пользователю
+type UseCase struct {
+	telegramService   TelegramService
+	supportService    SupportService
+	userServiceClient UserServiceClient
+	reachability      ReachabilityService
+	operatorChatID    int64 // 0 - чат операторов не настроен, сообщения только сохраняются
+}
+
+// New создаёт use case переписки с поддержкой
+func New(telegramService TelegramService, supportService SupportService, userServiceClient UserServiceClient, reachability ReachabilityService, operatorChatID int64) *UseCase {
+	return &UseCase{
+		telegramService:   telegramService,
+		supportService:    supportService,
+		userServiceClient: userServiceClient,
+		reachability:      reachability,
+		operatorChatID:    operatorChatID,
+	}
+}
+
+// IsInbound проверяет, что сообщение написано пользователем боту в личном чате
+// Команды сюда не попадают: их разбирает диспетчер
+func (uc *UseCase) IsInbound(msg *tgbotapi.Message) bool {
+	return msg.From != nil && msg.Chat != nil && msg.Chat.IsPrivate() && msg.Chat.ID != uc.operatorChatID
+}
+
+// IsOperatorReply проверяет, что сообщение - ответ оператора в чате операторов
+func (uc *UseCase) IsOperatorReply(msg *tgbotapi.Message) bool {
+	return uc.operatorChatID != 0 && msg.Chat != nil && msg.Chat.ID == uc.operatorChatID && msg.ReplyToMessage != nil
+}
+
+// ExecuteInbound сохраняет сообщение пользователя и пересылает его в чат операторов
+// При повторной обработке обновления сообщение не сохраняется и не пересылается второй раз
+func (uc *UseCase) ExecuteInbound(ctx context.Context, msg *tgbotapi.Message) error {
+	tgUserID := msg.From.ID
+
+	text := messageText(msg)
+	message, err := uc.supportService.SaveInbound(ctx, tgUserID, msg.MessageID, text)
+	if err != nil {
+		return fmt.Errorf("usecase.SupportInbound: save message of user %d: %w", tgUserID, err)
+	}
+
+	if uc.operatorChatID == 0 || message.OperatorMessageID != nil {
+		return nil
+	}
+
+	forward := &domain.TelegramMessage{
+		ChatID:      uc.operatorChatID,
+		MessageText: uc.operatorText(ctx, msg.From, text),
+		ParseMode:   domain.ParseModeHTML,
+	}
+	sent, err := uc.telegramService.SendMessage(forward)
+	if err != nil {
+		return fmt.Errorf("usecase.SupportInbound: forward message of user %d to operator chat %d: %w", tgUserID, uc.operatorChatID, err)
+	}
+	if len(sent) == 0 {
+		return nil
+	}
+
+	// Сообщение уже у операторов: повтор переслал бы его ещё раз
+	if err := uc.supportService.SetForwarded(ctx, message.ID, uc.operatorChatID, sent[0].MessageID); err != nil {
+		return fmt.Errorf("usecase.SupportInbound: %w: save forwarded message of user %d: %w", domain.ErrUpdatePartiallyHandled, tgUserID, err)
+	}
+
+	return nil
+}
+
+// ExecuteOperatorReply отправляет ответ оператора пользователю
+// Ответы на сообщения, не относящиеся к переписке (обсуждение операторов), игнорируются
+// После ответа пользователю или оператору ошибки помечаются domain.ErrUpdatePartiallyHandled, чтобы повтор не отправил ответ снова
+func (uc *UseCase) ExecuteOperatorReply(ctx context.Context, msg *tgbotapi.Message) error {
+	original, err := uc.supportService.FindForwarded(ctx, msg.Chat.ID, msg.ReplyToMessage.MessageID)
+	if err != nil {
+		if errors.Is(err, support.ErrMessageNotFound) {
+			return nil
+		}
+		return fmt.Errorf("usecase.SupportOperatorReply: find forwarded message %d: %w", msg.ReplyToMessage.MessageID, err)
+	}
+
+	text := msg.Text
+	if text == "" {
+		text = msg.Caption
+	}
+	if text == "" {
+		return uc.notifyOperator(msg.MessageID, operatorEmptyReply)
+	}
+
+	// Текст оператора отправляется как есть, без разметки
+	sent, err := uc.telegramService.SendMessage(&domain.TelegramMessage{
+		ChatID:      original.TgUserID,
+		MessageText: text,
+		ParseMode:   domain.ParseModePlain,
+	})
+	if err != nil {
+		if reason, ok := telegram.UnreachableReason(err); ok {
+			if markErr := uc.reachability.MarkUnreachable(ctx, original.TgUserID, reason, domain.ReachabilitySourceSendError, err.Error()); markErr != nil {
+				return fmt.Errorf("usecase.SupportOperatorReply: mark chat %d unreachable: %w", original.TgUserID, markErr)
+			}
+			return uc.notifyOperator(msg.MessageID, operatorUnreachable)
+		}
+		if notifyErr := uc.notifyOperator(msg.MessageID, fmt.Sprintf(operatorReplyFailed, err.Error())); notifyErr != nil {
+			return fmt.Errorf("usecase.SupportOperatorReply: send reply to user %d: %w (notify operator: %v)", original.TgUserID, err, notifyErr)
+		}
+		// Оператор знает, что ответ не доставлен, и отправит его снова сам
+		return fmt.Errorf("usecase.SupportOperatorReply: %w: send reply to user %d: %w", domain.ErrUpdatePartiallyHandled, original.TgUserID, err)
+	}
+
+	operatorChatID := msg.Chat.ID
+	operatorMessageID := msg.MessageID
+	reply := &domain.SupportMessage{
+		TgUserID:          original.TgUserID,
+		Text:              text,
+		OperatorChatID:    &operatorChatID,
+		OperatorMessageID: &operatorMessageID,
+	}
+	if len(sent) > 0 {
+		reply.UserMessageID = &sent[0].MessageID
+	}
+	if msg.From != nil {
+		operatorID := msg.From.ID
+		reply.OperatorTgUserID = &operatorID
+	}
+
+	if err := uc.supportService.SaveReply(ctx, reply); err != nil {
+		return fmt.Errorf("usecase.SupportOperatorReply: %w: save reply to user %d: %w", domain.ErrUpdatePartiallyHandled, original.TgUserID, err)
+	}
+
+	return nil
+}
+
+// operatorText формирует сообщение для чата операторов: кто написал и что
+// Данные пользователя берутся из UserService, при недоступности - из профиля Telegram
+func (uc *UseCase) operatorText(ctx context.Context, from *tgbotapi.User, text string) string {
+	name := strings.TrimSpace(from.FirstName + " " + from.LastName)
+	var phone string
+
+	// Пользователь не найден или UserService недоступен: пересылаем с данными Telegram, чтобы сообщение не потерялось
+	if user, err := uc.userServiceClient.GetUser(ctx, from.ID); err == nil && user != nil {
+		if user.Name != "" {
+			name = user.Name
+		}
+		phone = user.PhoneNumber
+	}
+	if name == "" {
+		name = operatorUnknownUser
+	}
+
+	lines := []string{fmt.Sprintf(operatorHeader, html.EscapeString(name))}
+	if from.UserName != "" {
+		lines = append(lines, fmt.Sprintf(operatorUsername, html.EscapeString("@"+from.UserName), from.ID))
+	} else {
+		lines = append(lines, fmt.Sprintf(operatorUserID, from.ID))
+	}
+	if phone != "" {
+		lines = append(lines, fmt.Sprintf(operatorPhone, html.EscapeString(phone)))
+	}
+
+	return strings.Join(lines, "\n") + "\n\n" + html.EscapeString(truncate(text, maxForwardedTextRunes)) + "\n\n" + operatorReplyHint
+}
+
+// notifyOperator отвечает в чате операторов на сообщение оператора
+func (uc *UseCase) notifyOperator(replyToMessageID int, text string) error {
+	_, err := uc.telegramService.SendMessage(&domain.TelegramMessage{
+		ChatID:      uc.operatorChatID,
+		MessageText: text,
+		ParseMode:   domain.ParseModePlain,
+		Options:     &domain.DeliveryOptions{ReplyToMessageID: &replyToMessageID},
+	})
+	if err != nil {
+		return fmt.Errorf("notify operator chat %d: %w", uc.operatorChatID, err)
+	}
+	return nil
+}
+
+// messageText возвращает текст сообщения пользователя: текст, подпись к вложению или пометку о вложении
+func messageText(msg *tgbotapi.Message) string {
+	if msg.Text != "" {
+		return msg.Text
+	}
+	if msg.Caption != "" {
+		return msg.Caption
+	}
+	return attachmentLabel
+}
+
+// truncate обрезает текст до limit символов
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit]) + "…"
+}
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
@@ -20,25 +21,94 @@ type NotificationRepository interface {
 	UpdateStatus(ctx context.Context, id int64, status domain.NotificationStatus) error
 
 	// MarkAsSent помечает уведомление как отправленное
-	MarkAsSent(ctx context.Context, id int64, sentAt time.Time) error
+	MarkAsSent(ctx context.Context, id int64, sentAt time.Time, messages domain.SentMessages) error
+
+	// SaveSentMessages сохраняет сообщения, доставленные до ошибки отправки
+	SaveSentMessages(ctx context.Context, id int64, messages domain.SentMessages) error
 
 	// MarkAsFailed помечает уведомление как неудачное
 	// Параметр incrementRetry указывает, нужно ли увеличить счётчик попыток
 	MarkAsFailed(ctx context.Context, id int64, errorMsg string, incrementRetry bool) error
 
+	// MarkForRetry возвращает уведомление в очередь для повторной отправки не раньше nextAttemptAt
+	MarkForRetry(ctx context.Context, id int64, errorMsg string, nextAttemptAt time.Time) error
+
+	// MarkAsSkipped помечает уведомление как пропущенное по настройкам получателя
+	MarkAsSkipped(ctx context.Context, id int64, reason domain.SkipReason) error
+
+	// Defer откладывает pending уведомление до конца тихих часов получателя
+	Defer(ctx context.Context, id int64, until time.Time) error
+
 	// GetByID получает уведомление по ID
 	GetByID(ctx context.Context, id int64) (*domain.Notification, error)
 }
 
+// BotUpdateRepository интерфейс очереди обновлений Telegram
+type BotUpdateRepository interface {
+	// Enqueue сохраняет обновление; возвращает false, если обновление уже сохранено после dedupSince
+	Enqueue(ctx context.Context, updateID int64, payload json.RawMessage, at, dedupSince time.Time) (bool, error)
+
+	// DeleteProcessedBefore удаляет обработанные обновления, сохранённые раньше before
+	DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error)
+
+	// GetDue получает pending обновления, время обработки которых наступило
+	GetDue(ctx context.Context, now time.Time, limit int) ([]*domain.BotUpdate, error)
+
+	// MarkDone помечает обновление обработанным
+	MarkDone(ctx context.Context, updateID int64, processedAt time.Time) error
+
+	// MarkRetry откладывает повторную обработку обновления
+	MarkRetry(ctx context.Context, updateID int64, errorMsg string, nextAttemptAt time.Time) error
+
+	// MarkFailed помечает обновление необработанным после исчерпания попыток
+	MarkFailed(ctx context.Context, updateID int64, errorMsg string) error
+}
+
+// PollingOffsetRepository интерфейс хранения смещения long polling
+type PollingOffsetRepository interface {
+	// SavePollingOffset сохраняет offset для getUpdates (последний сохранённый update_id + 1)
+	SavePollingOffset(ctx context.Context, botID int64, offset int64) error
+}
+
 // TelegramService интерфейс для отправки сообщений через Telegram Bot API
 type TelegramService interface {
-	// SendMessage отправляет уведомление через Telegram
-	SendMessage(msg *domain.TelegramMessage) error
+	// SendMessage отправляет уведомление через Telegram и возвращает ID отправленных сообщений
+	SendMessage(msg *domain.TelegramMessage) (domain.SentMessages, error)
+}
+
+// PreferencesService интерфейс настроек уведомлений пользователей
+type PreferencesService interface {
+	// SkipReason возвращает причину пропуска, если получатель отключил тип уведомлений или канал
+	SkipReason(ctx context.Context, tgUserID int64, t domain.NotificationType, c domain.NotificationChannel) (domain.SkipReason, bool, error)
+
+	// QuietUntil возвращает конец тихих часов получателя, если уведомление нужно отложить
+	QuietUntil(ctx context.Context, tgUserID int64, t domain.NotificationType, now time.Time) (time.Time, bool, error)
+}
+
+// ReachabilityService интерфейс доступности чатов
+type ReachabilityService interface {
+	// IsUnreachable проверяет, помечен ли чат недоступным (бот заблокирован или удалён из чата)
+	IsUnreachable(ctx context.Context, chatID int64) (bool, error)
+
+	// MarkUnreachable помечает чат недоступным
+	MarkUnreachable(ctx context.Context, chatID int64, reason domain.UnreachableReason, source domain.ReachabilitySource, errorMessage string) error
+}
+
+// MessageBuilder интерфейс для подготовки сообщения к отправке
+// Рендерит шаблон уведомления (если указан) в момент отправки
+type MessageBuilder interface {
+	BuildMessage(ctx context.Context, notification *domain.Notification) (*domain.TelegramMessage, error)
+}
+
+// CallbackSigner интерфейс для подписи callback_data кнопок
+// Подпись привязывает действие кнопки к ID уведомления
+type CallbackSigner interface {
+	Sign(payload string, notificationID int64) (string, error)
 }
 
-// StartMessageUseCase интерфейс для обработки команды /start
-type StartMessageUseCase interface {
-	Execute(ctx context.Context, from *tgbotapi.User, chatID int64) error
+// UpdateDispatcher интерфейс для обработки обновлений Telegram
+type UpdateDispatcher interface {
+	Dispatch(ctx context.Context, update tgbotapi.Update) error
 }
 
 // Logger интерфейс для логирования
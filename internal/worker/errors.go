@@ -0,0 +1,17 @@
+package worker
+
+import "errors"
+
+var (
+	// ErrSkipped возвращается Sender, если уведомление не отправлено по настройкам получателя
+	// Уведомление уже помечено как skipped, ошибкой отправки это не считается
+	ErrSkipped = errors.New("worker: notification skipped by recipient preferences")
+
+	// ErrUnreachable возвращается Sender, если бот не может писать в чат (заблокирован или удалён из чата)
+	// Уведомление помечено как skipped с причиной chat_unreachable и повторно не отправляется
+	ErrUnreachable = errors.New("worker: notification skipped, chat is unreachable")
+
+	// ErrDeferred возвращается Sender, если у получателя тихие часы
+	// Уведомление остаётся pending и будет отправлено processor'ом после deferred_until
+	ErrDeferred = errors.New("worker: notification deferred until the end of recipient quiet hours")
+)
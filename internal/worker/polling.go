@@ -2,21 +2,37 @@ package worker
 
 import (
 	"context"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
-// PollingHandler обрабатывает входящие сообщения от Telegram в режиме long polling
+const (
+	// enqueueRetryBaseDelay задержка перед повторным сохранением обновления (удваивается с каждой попыткой)
+	enqueueRetryBaseDelay = time.Second
+
+	// enqueueRetryMaxDelay максимальная задержка между попытками сохранения
+	enqueueRetryMaxDelay = time.Minute
+)
+
+// PollingHandler получает обновления от Telegram в режиме long polling и сохраняет их в очередь обработки
+// Как и в режиме webhook, обновление с уже сохранённым update_id не обрабатывается повторно
+// Обновления сохраняются строго по порядку: пока текущее не сохранено, следующие не читаются
 type PollingHandler struct {
-	startMessageUseCase StartMessageUseCase
-	logger              Logger
+	queue   *UpdateProcessor
+	offsets PollingOffsetRepository
+	botID   int64
+	logger  Logger
 }
 
 // NewPollingHandler создаёт новый обработчик для long polling
-func NewPollingHandler(startMessageUseCase StartMessageUseCase, logger Logger) *PollingHandler {
+// botID - ID бота, для которого сохраняется смещение getUpdates
+func NewPollingHandler(queue *UpdateProcessor, offsets PollingOffsetRepository, botID int64, logger Logger) *PollingHandler {
 	return &PollingHandler{
-		startMessageUseCase: startMessageUseCase,
-		logger:              logger,
+		queue:   queue,
+		offsets: offsets,
+		botID:   botID,
+		logger:  logger,
 	}
 }
 
@@ -37,28 +53,40 @@ func (h *PollingHandler) Start(ctx context.Context, updatesChan tgbotapi.Updates
 	}
 }
 
-// handleUpdate обрабатывает одно обновление от Telegram
+// handleUpdate сохраняет обновление в очередь и сдвигает смещение long polling
+// Смещение сохраняется только после записи обновления: после перезапуска getUpdates продолжит с него
 func (h *PollingHandler) handleUpdate(ctx context.Context, update tgbotapi.Update) {
-	// Обрабатываем только текстовые сообщения
-	if update.Message == nil || update.Message.Text == "" {
+	if !h.enqueue(ctx, update) {
 		return
 	}
 
-	// Обрабатываем только команду /start
-	if update.Message.Text != "/start" {
-		return
+	if err := h.offsets.SavePollingOffset(ctx, h.botID, int64(update.UpdateID)+1); err != nil {
+		h.logger.Error("Failed to save polling offset after update %d: %v", update.UpdateID, err)
 	}
+}
 
-	chatID := update.Message.Chat.ID
-	tgUserID := update.Message.From.ID
+// enqueue сохраняет обновление в очередь, повторяя попытки с растущей задержкой до успеха или остановки
+// tgbotapi уже сдвинул смещение в памяти за это обновление и больше его не запросит, поэтому следующее
+// обновление не читается, пока это не сохранено, а смещение не сохраняется дальше несохранённого обновления
+// Возвращает false, если обработчик остановлен раньше, чем обновление удалось сохранить
+func (h *PollingHandler) enqueue(ctx context.Context, update tgbotapi.Update) bool {
+	delay := enqueueRetryBaseDelay
 
-	h.logger.Info("Received /start command from user %d (chat %d)", tgUserID, chatID)
+	for attempt := 1; ; attempt++ {
+		err := h.queue.Enqueue(ctx, update)
+		if err == nil {
+			return true
+		}
 
-	// Обрабатываем команду через use case
-	if err := h.startMessageUseCase.Execute(ctx, update.Message.From, chatID); err != nil {
-		h.logger.Error("Failed to handle /start command for user %d: %v", tgUserID, err)
-		return
-	}
+		h.logger.Error("Failed to enqueue polled update %d (attempt %d), retrying in %s: %v", update.UpdateID, attempt, delay, err)
+
+		select {
+		case <-ctx.Done():
+			h.logger.Warn("Polled update %d is not saved: handler stopped", update.UpdateID)
+			return false
+		case <-time.After(delay):
+		}
 
-	h.logger.Info("Successfully processed /start command for user %d (chat %d)", tgUserID, chatID)
+		delay = min(delay*2, enqueueRetryMaxDelay)
+	}
 }
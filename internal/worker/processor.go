@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
@@ -10,28 +11,28 @@ import (
 
 // Processor обработчик pending уведомлений
 type Processor struct {
-	repo            NotificationRepository
-	telegramService TelegramService
-	logger          Logger
-	interval        time.Duration // Интервал опроса БД (по умолчанию 30 секунд)
-	batchSize       int           // Количество уведомлений за один опрос
-	ctx             context.Context
-	cancel          context.CancelFunc
-	wg              sync.WaitGroup
+	repo      NotificationRepository
+	sender    *Sender
+	logger    Logger
+	interval  time.Duration // Интервал опроса БД (по умолчанию 30 секунд)
+	batchSize int           // Количество уведомлений за один опрос
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
 }
 
 // NewProcessor создает новый экземпляр обработчика
-func NewProcessor(repo NotificationRepository, telegramService TelegramService, logger Logger, interval time.Duration, batchSize int) *Processor {
+func NewProcessor(repo NotificationRepository, sender *Sender, logger Logger, interval time.Duration, batchSize int) *Processor {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	return &Processor{
-		repo:            repo,
-		telegramService: telegramService,
-		logger:          logger,
-		interval:        interval,
-		batchSize:       batchSize,
-		ctx:             ctx,
-		cancel:          cancel,
+		repo:      repo,
+		sender:    sender,
+		logger:    logger,
+		interval:  interval,
+		batchSize: batchSize,
+		ctx:       ctx,
+		cancel:    cancel,
 	}
 }
 
@@ -124,24 +125,20 @@ func (p *Processor) processNotification(ctx context.Context, notification *domai
 		notification.ChatID,
 	)
 
-	// Преобразуем в Telegram сообщение
-	telegramMsg := domain.NewTelegramMessage(notification)
-
-	// Отправляем через Telegram API
-	if err := p.telegramService.SendMessage(telegramMsg); err != nil {
-		p.logger.Error("Failed to send notification %d: %v", notification.ID, err)
-
-		// Помечаем как failed с увеличением счётчика попыток
-		if markErr := p.repo.MarkAsFailed(ctx, notification.ID, err.Error(), true); markErr != nil {
-			p.logger.Error("Failed to mark notification %d as failed: %v", notification.ID, markErr)
+	if err := p.sender.Send(ctx, notification); err != nil {
+		if errors.Is(err, ErrSkipped) {
+			p.logger.Info("Skipped notification %d: %v", notification.ID, err)
+			return
 		}
-
-		return
-	}
-
-	// Помечаем как отправленное
-	if err := p.repo.MarkAsSent(ctx, notification.ID, time.Now()); err != nil {
-		p.logger.Error("Failed to mark notification %d as sent: %v", notification.ID, err)
+		if errors.Is(err, ErrUnreachable) {
+			p.logger.Warn("Skipped notification %d: %v", notification.ID, err)
+			return
+		}
+		if errors.Is(err, ErrDeferred) {
+			p.logger.Info("Deferred notification %d: %v", notification.ID, err)
+			return
+		}
+		p.logger.Error("Failed to send notification %d: %v", notification.ID, err)
 		return
 	}
 
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -12,28 +13,28 @@ import (
 
 // Scheduler планировщик для отложенных уведомлений
 type Scheduler struct {
-	repo            NotificationRepository
-	telegramService TelegramService
-	logger          Logger
-	scheduler       *gocron.Scheduler
-	jobs            map[int64]*gocron.Job // notification_id -> job
-	mu              sync.RWMutex
-	ctx             context.Context
-	cancel          context.CancelFunc
+	repo      NotificationRepository
+	sender    *Sender
+	logger    Logger
+	scheduler *gocron.Scheduler
+	jobs      map[int64]*gocron.Job // notification_id -> job
+	mu        sync.RWMutex
+	ctx       context.Context
+	cancel    context.CancelFunc
 }
 
 // NewScheduler создает новый экземпляр планировщика
-func NewScheduler(repo NotificationRepository, telegramService TelegramService, logger Logger) *Scheduler {
+func NewScheduler(repo NotificationRepository, sender *Sender, logger Logger) *Scheduler {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	return &Scheduler{
-		repo:            repo,
-		telegramService: telegramService,
-		logger:          logger,
-		scheduler:       gocron.NewScheduler(time.UTC),
-		jobs:            make(map[int64]*gocron.Job),
-		ctx:             ctx,
-		cancel:          cancel,
+		repo:      repo,
+		sender:    sender,
+		logger:    logger,
+		scheduler: gocron.NewScheduler(time.UTC),
+		jobs:      make(map[int64]*gocron.Job),
+		ctx:       ctx,
+		cancel:    cancel,
 	}
 }
 
@@ -153,32 +154,28 @@ func (s *Scheduler) sendScheduledNotification(notificationID int64) {
 		return
 	}
 
-	// Преобразуем в Telegram сообщение
-	// TelegramMessage содержит все нужные поля (ImageURLs, InlineButtons),
-	// а TelegramService.SendMessage() автоматически определит тип отправки:
-	// - текст (если нет изображений)
-	// - фото (если 1 изображение)
-	// - медиагруппа (если 2-10 изображений)
-	telegramMsg := domain.NewTelegramMessage(notification)
-
-	// Отправляем через Telegram API
-	if err := s.telegramService.SendMessage(telegramMsg); err != nil {
-		s.logger.Error("Failed to send notification %d: %v", notificationID, err)
-
-		// Помечаем как failed с увеличением счётчика попыток
-		if markErr := s.repo.MarkAsFailed(ctx, notificationID, err.Error(), true); markErr != nil {
-			s.logger.Error("Failed to mark notification %d as failed: %v", notificationID, markErr)
+	if err := s.sender.Send(ctx, notification); err != nil {
+		if errors.Is(err, ErrSkipped) {
+			s.logger.Info("Skipped scheduled notification %d: %v", notificationID, err)
+			s.removeJob(notificationID)
+			return
 		}
-
+		if errors.Is(err, ErrUnreachable) {
+			s.logger.Warn("Skipped scheduled notification %d: %v", notificationID, err)
+			s.removeJob(notificationID)
+			return
+		}
+		// Уведомление осталось pending с deferred_until - его отправит processor
+		if errors.Is(err, ErrDeferred) {
+			s.logger.Info("Deferred scheduled notification %d to processor: %v", notificationID, err)
+			s.removeJob(notificationID)
+			return
+		}
+		s.logger.Error("Failed to send notification %d: %v", notificationID, err)
 		s.removeJob(notificationID)
 		return
 	}
 
-	// Помечаем как отправленное
-	if err := s.repo.MarkAsSent(ctx, notificationID, time.Now()); err != nil {
-		s.logger.Error("Failed to mark notification %d as sent: %v", notificationID, err)
-	}
-
 	s.logger.Info("Successfully sent scheduled notification %d", notificationID)
 	s.removeJob(notificationID)
 }
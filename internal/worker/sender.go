@@ -0,0 +1,253 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/service/telegram"
+)
+
+const (
+	// sendRetryBaseDelay задержка перед первой повторной отправкой (удваивается с каждой попыткой)
+	sendRetryBaseDelay = 30 * time.Second
+
+	// sendRetryMaxDelay максимальная задержка между попытками отправки
+	sendRetryMaxDelay = 30 * time.Minute
+)
+
+// Sender отправляет одно уведомление и фиксирует результат в БД
+// Общая логика отправки для Processor и Scheduler
+// Ошибка отправки возвращает уведомление в очередь с растущей задержкой, пока не исчерпаны попытки;
+// повтор текстового уведомления продолжается с первой недоставленной части
+type Sender struct {
+	repo            NotificationRepository
+	messageBuilder  MessageBuilder
+	telegramService TelegramService
+	callbackSigner  CallbackSigner
+	preferences     PreferencesService
+	reachability    ReachabilityService
+	logger          Logger
+	maxAttempts     int // Количество попыток отправки одного уведомления
+}
+
+// NewSender создает новый экземпляр отправителя уведомлений
+func NewSender(repo NotificationRepository, messageBuilder MessageBuilder, telegramService TelegramService, callbackSigner CallbackSigner, preferences PreferencesService, reachability ReachabilityService, logger Logger, maxAttempts int) *Sender {
+	return &Sender{
+		repo:            repo,
+		messageBuilder:  messageBuilder,
+		telegramService: telegramService,
+		callbackSigner:  callbackSigner,
+		preferences:     preferences,
+		reachability:    reachability,
+		logger:          logger,
+		maxAttempts:     maxAttempts,
+	}
+}
+
+// Send формирует сообщение, отправляет его через Telegram и обновляет статус уведомления
+// Возвращает ошибку отправки для логирования на уровне вызывающего компонента
+// Если получатель отключил уведомление, оно помечается как skipped и возвращается ErrSkipped
+// Если бот не может писать в чат, уведомление помечается как skipped и возвращается ErrUnreachable
+// Если у получателя тихие часы, несрочное уведомление откладывается и возвращается ErrDeferred
+func (s *Sender) Send(ctx context.Context, notification *domain.Notification) error {
+	if err := s.checkReachability(ctx, notification); err != nil {
+		return err
+	}
+	// Настройки проверяются и при отправке: отложенное уведомление могли отключить после создания
+	if err := s.checkPreferences(ctx, notification); err != nil {
+		return err
+	}
+	if err := s.checkQuietHours(ctx, notification); err != nil {
+		return err
+	}
+
+	// Формируем Telegram сообщение (рендеринг шаблона выполняется здесь, а не при создании)
+	// TelegramService.SendMessage() автоматически определит тип отправки:
+	// - текст (если нет изображений)
+	// - фото (если 1 изображение)
+	// - медиагруппа (если 2-10 изображений)
+	telegramMsg, err := s.messageBuilder.BuildMessage(ctx, notification)
+	if err != nil {
+		s.markFailed(ctx, notification, err, true)
+		return fmt.Errorf("build message: %w", err)
+	}
+
+	// Действия callback-кнопок подписываются вместе с ID уведомления
+	if err := s.signCallbacks(telegramMsg, notification.ID); err != nil {
+		// Подпись не зависит от внешних сервисов - повтор не поможет
+		s.markFailed(ctx, notification, err, false)
+		return fmt.Errorf("sign callbacks: %w", err)
+	}
+
+	// Прошлая попытка доставила часть длинного текста - продолжаем с первой недоставленной части
+	resumable := !telegramMsg.HasImages() && !telegramMsg.HasAttachments()
+	if resumable {
+		telegramMsg.Delivered = notification.SentMessages
+	}
+
+	// Отправляем через Telegram API
+	messages, err := s.telegramService.SendMessage(telegramMsg)
+
+	// Telegram не смог разобрать разметку, и ничего не доставлено - повторяем отправку без форматирования
+	// Разметку частей длинного текста TelegramService заменяет сам, начиная с отклонённой части
+	if errors.Is(err, telegram.ErrParseEntities) && telegramMsg.IsHTML() && len(messages) == 0 {
+		s.logger.Warn("Notification %d: Telegram rejected HTML markup, resending as plain text: %v", notification.ID, err)
+		messages, err = s.telegramService.SendMessage(telegramMsg.AsPlainText())
+	}
+
+	// Часть сообщений доставлена до ошибки - сохраняем их, чтобы повторная отправка их не дублировала
+	if err != nil && len(messages) > 0 {
+		if saveErr := s.repo.SaveSentMessages(ctx, notification.ID, messages); saveErr != nil {
+			s.logger.Error("Failed to save partially sent messages of notification %d: %v", notification.ID, saveErr)
+		}
+	}
+
+	// Пользователь заблокировал бота или бота удалили из чата - больше не пишем в этот чат
+	if reason, ok := telegram.UnreachableReason(err); ok {
+		return s.markUnreachable(ctx, notification, reason, err)
+	}
+
+	if err != nil {
+		// Уведомление с вложениями нельзя дослать частично: повтор отправил бы уже доставленные фото и файлы
+		// второй раз, поэтому после частичной доставки оно сразу помечается failed (доставленное можно удалить через API)
+		retry := resumable || len(messages) == 0
+		s.markFailed(ctx, notification, err, retry)
+		return fmt.Errorf("send message: %w", err)
+	}
+
+	// Длинный текст отправляется несколькими сообщениями
+	if len(messages) > 1 {
+		s.logger.Info("Notification %d delivered as %d messages (ids=%v)", notification.ID, len(messages), messages.IDs())
+	}
+
+	// Помечаем как отправленное и сохраняем ID сообщений для редактирования и удаления
+	if err := s.repo.MarkAsSent(ctx, notification.ID, time.Now(), messages); err != nil {
+		return fmt.Errorf("mark as sent: %w", err)
+	}
+
+	return nil
+}
+
+// checkPreferences помечает уведомление пропущенным, если получатель отключил его тип или канал
+func (s *Sender) checkPreferences(ctx context.Context, notification *domain.Notification) error {
+	if notification.TelegramUserID == nil {
+		return nil
+	}
+
+	reason, skip, err := s.preferences.SkipReason(ctx, *notification.TelegramUserID, notification.Type, domain.NotificationChannelTelegram)
+	if err != nil {
+		s.markFailed(ctx, notification, err, true)
+		return fmt.Errorf("check preferences: %w", err)
+	}
+	if !skip {
+		return nil
+	}
+
+	if err := s.repo.MarkAsSkipped(ctx, notification.ID, reason); err != nil {
+		return fmt.Errorf("mark as skipped: %w", err)
+	}
+
+	return fmt.Errorf("%w: %s", ErrSkipped, reason)
+}
+
+// checkReachability помечает уведомление пропущенным, если чат получателя недоступен
+func (s *Sender) checkReachability(ctx context.Context, notification *domain.Notification) error {
+	unreachable, err := s.reachability.IsUnreachable(ctx, notification.GetChatID())
+	if err != nil {
+		s.markFailed(ctx, notification, err, true)
+		return fmt.Errorf("check reachability: %w", err)
+	}
+	if !unreachable {
+		return nil
+	}
+
+	if err := s.repo.MarkAsSkipped(ctx, notification.ID, domain.SkipReasonChatUnreachable); err != nil {
+		return fmt.Errorf("mark as skipped: %w", err)
+	}
+
+	return fmt.Errorf("%w: chat %d", ErrUnreachable, notification.GetChatID())
+}
+
+// markUnreachable запоминает недоступность чата после отказа Telegram и помечает уведомление пропущенным
+func (s *Sender) markUnreachable(ctx context.Context, notification *domain.Notification, reason domain.UnreachableReason, cause error) error {
+	chatID := notification.GetChatID()
+
+	if err := s.reachability.MarkUnreachable(ctx, chatID, reason, domain.ReachabilitySourceSendError, cause.Error()); err != nil {
+		s.logger.Error("Failed to mark chat %d as unreachable: %v", chatID, err)
+	}
+
+	if err := s.repo.MarkAsSkipped(ctx, notification.ID, domain.SkipReasonChatUnreachable); err != nil {
+		return fmt.Errorf("mark as skipped: %w", err)
+	}
+
+	return fmt.Errorf("%w: chat %d (%s): %v", ErrUnreachable, chatID, reason, cause)
+}
+
+// checkQuietHours откладывает несрочное уведомление до конца тихих часов получателя
+func (s *Sender) checkQuietHours(ctx context.Context, notification *domain.Notification) error {
+	if notification.TelegramUserID == nil {
+		return nil
+	}
+
+	until, quiet, err := s.preferences.QuietUntil(ctx, *notification.TelegramUserID, notification.Type, time.Now())
+	if err != nil {
+		s.markFailed(ctx, notification, err, true)
+		return fmt.Errorf("check quiet hours: %w", err)
+	}
+	if !quiet {
+		return nil
+	}
+
+	if err := s.repo.Defer(ctx, notification.ID, until); err != nil {
+		return fmt.Errorf("defer: %w", err)
+	}
+
+	return fmt.Errorf("%w: %s", ErrDeferred, until.Format(time.RFC3339))
+}
+
+// signCallbacks заменяет действия callback-кнопок подписанными callback_data
+func (s *Sender) signCallbacks(msg *domain.TelegramMessage, notificationID int64) error {
+	buttons, err := domain.InlineButtons(msg.InlineButtons).WithSignedCallbacks(func(action string) (string, error) {
+		return s.callbackSigner.Sign(action, notificationID)
+	})
+	if err != nil {
+		return err
+	}
+
+	msg.InlineButtons = buttons
+	return nil
+}
+
+// markFailed возвращает уведомление в очередь для повтора или, если попытки исчерпаны или повтор невозможен,
+// помечает его как failed; счётчик попыток увеличивается в обоих случаях
+func (s *Sender) markFailed(ctx context.Context, notification *domain.Notification, cause error, retry bool) {
+	attempt := notification.RetryCount + 1
+
+	if retry && attempt < s.maxAttempts {
+		delay := sendRetryDelay(attempt)
+		s.logger.Warn("Notification %d failed (attempt %d/%d), retrying in %s", notification.ID, attempt, s.maxAttempts, delay)
+		if markErr := s.repo.MarkForRetry(ctx, notification.ID, cause.Error(), time.Now().Add(delay)); markErr != nil {
+			s.logger.Error("Failed to schedule retry of notification %d: %v", notification.ID, markErr)
+		}
+		return
+	}
+
+	if markErr := s.repo.MarkAsFailed(ctx, notification.ID, cause.Error(), true); markErr != nil {
+		s.logger.Error("Failed to mark notification %d as failed: %v", notification.ID, markErr)
+	}
+}
+
+// sendRetryDelay возвращает задержку перед следующей попыткой отправки: 30s, 1m, 2m, ... но не больше sendRetryMaxDelay
+func sendRetryDelay(attempt int) time.Duration {
+	delay := sendRetryBaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= sendRetryMaxDelay {
+			return sendRetryMaxDelay
+		}
+	}
+	return delay
+}
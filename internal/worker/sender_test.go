@@ -0,0 +1,178 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+	"github.com/m04kA/SMC-NotificationService/internal/service/telegram"
+	"github.com/m04kA/SMC-NotificationService/pkg/tghtml"
+)
+
+func TestSender_ResumesSplitTextFromFailedPart(t *testing.T) {
+	parts := []string{
+		strings.Repeat("a", 3000),
+		strings.Repeat("b", 3000),
+		strings.Repeat("c", 3000),
+	}
+	text := strings.Join(parts, "\n\n")
+	require.Len(t, tghtml.Split(text, tghtml.MaxMessageLength, false), 3)
+
+	bot := &fakeBot{failOn: 2}
+	repo := &fakeNotificationRepo{}
+	sender := NewSender(repo, fakeMessageBuilder{}, telegram.NewService(bot, nil, nil, nil), fakeCallbackSigner{},
+		fakePreferences{}, fakeReachability{}, fakeLogger{}, 3)
+
+	chatID := int64(42)
+	plain := domain.ParseModePlain
+	notification := &domain.Notification{ID: 1, ChatID: &chatID, MessageText: text, ParseMode: &plain}
+
+	// Первая попытка: вторая часть не доставлена, первая сохранена, уведомление возвращено в очередь
+	err := sender.Send(context.Background(), notification)
+	require.Error(t, err)
+	require.Len(t, repo.saved, 1)
+	assert.Equal(t, 1, repo.saved[0].MessageID)
+	assert.True(t, repo.retried)
+	assert.False(t, repo.failed)
+
+	// Повтор: уведомление перечитано из БД с доставленной частью
+	notification.SentMessages = repo.saved
+	notification.RetryCount = 1
+	bot.failOn = 0
+
+	err = sender.Send(context.Background(), notification)
+	require.NoError(t, err)
+
+	// Первая часть не отправлена повторно: Telegram получил часть 1, затем (после ошибки) части 2 и 3
+	assert.Equal(t, []string{parts[0], parts[1], parts[1], parts[2]}, bot.texts)
+	assert.Equal(t, []int{1, 3, 4}, repo.sent.IDs())
+}
+
+func TestSender_DoesNotRetryPartiallySentMedia(t *testing.T) {
+	bot := &fakeBot{failOn: 2}
+	repo := &fakeNotificationRepo{}
+	sender := NewSender(repo, fakeMessageBuilder{}, telegram.NewService(bot, fakeFileCache{}, nil, nil), fakeCallbackSigner{},
+		fakePreferences{}, fakeReachability{}, fakeLogger{}, 3)
+
+	chatID := int64(42)
+	plain := domain.ParseModePlain
+	notification := &domain.Notification{
+		ID:          1,
+		ChatID:      &chatID,
+		MessageText: strings.Repeat("a", tghtml.MaxCaptionLength+1),
+		ParseMode:   &plain,
+		ImageURLs:   []string{"https://example.com/1.jpg"},
+	}
+
+	// Фото доставлено, текст после него - нет: повтор продублировал бы фото
+	err := sender.Send(context.Background(), notification)
+	require.Error(t, err)
+	assert.Len(t, repo.saved, 1)
+	assert.False(t, repo.retried)
+	assert.True(t, repo.failed)
+}
+
+func TestSendRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, sendRetryDelay(1))
+	assert.Equal(t, time.Minute, sendRetryDelay(2))
+	assert.Equal(t, 2*time.Minute, sendRetryDelay(3))
+	assert.Equal(t, sendRetryMaxDelay, sendRetryDelay(20))
+}
+
+// fakeBot считает отправленные сообщения и отказывает в отправке с номером failOn
+type fakeBot struct {
+	telegram.BotAPI
+	failOn int
+	calls  int
+	texts  []string
+}
+
+func (b *fakeBot) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	b.calls++
+	if msg, ok := c.(tgbotapi.MessageConfig); ok {
+		b.texts = append(b.texts, msg.Text)
+	}
+	if b.calls == b.failOn {
+		return tgbotapi.Message{}, errors.New("connection reset by peer")
+	}
+	return tgbotapi.Message{MessageID: b.calls}, nil
+}
+
+type fakeFileCache struct{}
+
+func (fakeFileCache) Get(string) (string, bool)                  { return "", false }
+func (fakeFileCache) Save(string, string, domain.AttachmentType) {}
+func (fakeFileCache) Forget(string)                              {}
+
+type fakeNotificationRepo struct {
+	NotificationRepository
+	saved   domain.SentMessages
+	sent    domain.SentMessages
+	retried bool
+	failed  bool
+}
+
+func (r *fakeNotificationRepo) SaveSentMessages(_ context.Context, _ int64, messages domain.SentMessages) error {
+	r.saved = append(domain.SentMessages(nil), messages...)
+	return nil
+}
+
+func (r *fakeNotificationRepo) MarkAsSent(_ context.Context, _ int64, _ time.Time, messages domain.SentMessages) error {
+	r.sent = messages
+	return nil
+}
+
+func (r *fakeNotificationRepo) MarkForRetry(context.Context, int64, string, time.Time) error {
+	r.retried = true
+	return nil
+}
+
+func (r *fakeNotificationRepo) MarkAsFailed(context.Context, int64, string, bool) error {
+	r.failed = true
+	return nil
+}
+
+type fakeMessageBuilder struct{}
+
+func (fakeMessageBuilder) BuildMessage(_ context.Context, n *domain.Notification) (*domain.TelegramMessage, error) {
+	return domain.NewTelegramMessage(n), nil
+}
+
+type fakeCallbackSigner struct{}
+
+func (fakeCallbackSigner) Sign(payload string, _ int64) (string, error) {
+	return payload, nil
+}
+
+type fakePreferences struct{}
+
+func (fakePreferences) SkipReason(context.Context, int64, domain.NotificationType, domain.NotificationChannel) (domain.SkipReason, bool, error) {
+	return "", false, nil
+}
+
+func (fakePreferences) QuietUntil(context.Context, int64, domain.NotificationType, time.Time) (time.Time, bool, error) {
+	return time.Time{}, false, nil
+}
+
+type fakeReachability struct{}
+
+func (fakeReachability) IsUnreachable(context.Context, int64) (bool, error) {
+	return false, nil
+}
+
+func (fakeReachability) MarkUnreachable(context.Context, int64, domain.UnreachableReason, domain.ReachabilitySource, string) error {
+	return nil
+}
+
+type fakeLogger struct{}
+
+func (fakeLogger) Info(string, ...interface{})  {}
+func (fakeLogger) Warn(string, ...interface{})  {}
+func (fakeLogger) Error(string, ...interface{}) {}
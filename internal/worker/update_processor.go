@@ -0,0 +1,237 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/m04kA/SMC-NotificationService/internal/domain"
+)
+
+const (
+	// updateBatchSize количество обновлений за один опрос очереди
+	updateBatchSize = 100
+
+	// updateTimeout время на обработку одного обновления
+	updateTimeout = 30 * time.Second
+
+	// updateRetryBaseDelay задержка перед первой повторной обработкой (удваивается с каждой попыткой)
+	updateRetryBaseDelay = 5 * time.Second
+
+	// updateRetryMaxDelay максимальная задержка между попытками
+	updateRetryMaxDelay = 10 * time.Minute
+
+	// updateCleanupInterval интервал удаления обработанных обновлений старше срока хранения
+	updateCleanupInterval = time.Hour
+)
+
+// UpdateProcessor обрабатывает сохранённые обновления Telegram в фоне
+// Webhook сохраняет обновление через Enqueue и сразу отвечает Telegram; ошибки обработки повторяются с растущей задержкой,
+// кроме ошибок domain.ErrUpdatePartiallyHandled: пользователь уже получил ответ, и повтор отправил бы его снова
+type UpdateProcessor struct {
+	repo        BotUpdateRepository
+	dispatcher  UpdateDispatcher
+	logger      Logger
+	interval    time.Duration // Интервал опроса очереди (для повторов и обновлений, сохранённых до перезапуска)
+	maxAttempts int           // Количество попыток обработки одного обновления
+	retention   time.Duration // Срок хранения обработанных обновлений; в его пределах повторная доставка отбрасывается
+	wake        chan struct{} // Сигнал о новом обновлении: обработка начинается без ожидания тика
+	ctx         context.Context
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+// NewUpdateProcessor создает новый обработчик очереди обновлений
+func NewUpdateProcessor(repo BotUpdateRepository, dispatcher UpdateDispatcher, logger Logger, interval time.Duration, maxAttempts int, retention time.Duration) *UpdateProcessor {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &UpdateProcessor{
+		repo:        repo,
+		dispatcher:  dispatcher,
+		logger:      logger,
+		interval:    interval,
+		maxAttempts: maxAttempts,
+		retention:   retention,
+		wake:        make(chan struct{}, 1),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// Start запускает обработчик в отдельной goroutine
+func (p *UpdateProcessor) Start() {
+	p.logger.Info("Starting bot update processor (interval: %s, max attempts: %d, retention: %s)", p.interval, p.maxAttempts, p.retention)
+
+	p.wg.Add(1)
+	go p.run()
+}
+
+// Stop останавливает обработчик; необработанные обновления остаются в очереди до следующего запуска
+func (p *UpdateProcessor) Stop() {
+	p.logger.Info("Stopping bot update processor")
+	p.cancel()
+	p.wg.Wait()
+	p.logger.Info("Bot update processor stopped")
+}
+
+// Enqueue сохраняет обновление в очередь и будит обработчик
+// Повторно доставленное в пределах срока хранения обновление (тот же update_id) не сохраняется и не обрабатывается второй раз
+func (p *UpdateProcessor) Enqueue(ctx context.Context, update tgbotapi.Update) error {
+	payload, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("marshal update %d: %w", update.UpdateID, err)
+	}
+
+	now := time.Now()
+	created, err := p.repo.Enqueue(ctx, int64(update.UpdateID), payload, now, now.Add(-p.retention))
+	if err != nil {
+		return fmt.Errorf("enqueue update %d: %w", update.UpdateID, err)
+	}
+	if !created {
+		p.logger.Info("Update %d is already queued, skipping redelivery", update.UpdateID)
+		return nil
+	}
+
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// run основной цикл обработки очереди
+func (p *UpdateProcessor) run() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	cleanupTicker := time.NewTicker(updateCleanupInterval)
+	defer cleanupTicker.Stop()
+
+	// Первый запуск сразу: обрабатываем обновления, сохранённые до перезапуска
+	p.deleteExpiredUpdates()
+	p.processDueUpdates()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.processDueUpdates()
+		case <-cleanupTicker.C:
+			p.deleteExpiredUpdates()
+		case <-p.wake:
+			p.processDueUpdates()
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+// deleteExpiredUpdates удаляет обработанные обновления старше срока хранения
+func (p *UpdateProcessor) deleteExpiredUpdates() {
+	deleted, err := p.repo.DeleteProcessedBefore(p.ctx, time.Now().Add(-p.retention))
+	if err != nil {
+		if p.ctx.Err() == nil {
+			p.logger.Error("Failed to delete expired bot updates: %v", err)
+		}
+		return
+	}
+
+	if deleted > 0 {
+		p.logger.Info("Deleted %d expired bot updates", deleted)
+	}
+}
+
+// processDueUpdates обрабатывает обновления, время обработки которых наступило
+func (p *UpdateProcessor) processDueUpdates() {
+	for {
+		updates, err := p.repo.GetDue(p.ctx, time.Now(), updateBatchSize)
+		if err != nil {
+			if p.ctx.Err() == nil {
+				p.logger.Error("Failed to fetch queued bot updates: %v", err)
+			}
+			return
+		}
+
+		for _, update := range updates {
+			if p.ctx.Err() != nil {
+				return
+			}
+			p.processUpdate(update)
+		}
+
+		// Неполный батч - очередь разобрана
+		if len(updates) < updateBatchSize {
+			return
+		}
+	}
+}
+
+// processUpdate обрабатывает одно обновление и фиксирует результат
+func (p *UpdateProcessor) processUpdate(queued *domain.BotUpdate) {
+	dispatchCtx, cancelDispatch := context.WithTimeout(p.ctx, updateTimeout)
+	defer cancelDispatch()
+
+	// Результат фиксируется и после истечения таймаута или остановки: иначе обновление обработается повторно
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(p.ctx), updateTimeout)
+	defer cancel()
+
+	var update tgbotapi.Update
+	if err := json.Unmarshal(queued.Payload, &update); err != nil {
+		// Повтор не поможет - сразу помечаем как failed
+		p.logger.Error("Failed to decode queued update %d: %v", queued.UpdateID, err)
+		if markErr := p.repo.MarkFailed(ctx, queued.UpdateID, err.Error()); markErr != nil {
+			p.logger.Error("Failed to mark update %d as failed: %v", queued.UpdateID, markErr)
+		}
+		return
+	}
+
+	// Ошибки и паники обработчиков логируются диспетчером
+	// update_id в контексте позволяет обработчикам запомнить неповторяемые шаги (например, отправленное приветствие)
+	err := p.dispatcher.Dispatch(domain.WithUpdateID(dispatchCtx, queued.UpdateID), update)
+
+	// Обработчик уже ответил пользователю: повтор продублировал бы ответ, поэтому обновление считается обработанным
+	if errors.Is(err, domain.ErrUpdatePartiallyHandled) {
+		p.logger.Warn("Update %d handled partially, not retrying: %v", queued.UpdateID, err)
+		err = nil
+	}
+
+	if err == nil {
+		if markErr := p.repo.MarkDone(ctx, queued.UpdateID, time.Now()); markErr != nil {
+			p.logger.Error("Failed to mark update %d as done: %v", queued.UpdateID, markErr)
+		}
+		return
+	}
+
+	attempt := queued.Attempts + 1
+	if attempt >= p.maxAttempts {
+		p.logger.Error("Giving up on update %d after %d attempts: %v", queued.UpdateID, attempt, err)
+		if markErr := p.repo.MarkFailed(ctx, queued.UpdateID, err.Error()); markErr != nil {
+			p.logger.Error("Failed to mark update %d as failed: %v", queued.UpdateID, markErr)
+		}
+		return
+	}
+
+	delay := retryDelay(attempt)
+	p.logger.Warn("Update %d failed (attempt %d/%d), retrying in %s", queued.UpdateID, attempt, p.maxAttempts, delay)
+	if markErr := p.repo.MarkRetry(ctx, queued.UpdateID, err.Error(), time.Now().Add(delay)); markErr != nil {
+		p.logger.Error("Failed to schedule retry of update %d: %v", queued.UpdateID, markErr)
+	}
+}
+
+// retryDelay возвращает задержку перед следующей попыткой: 5s, 10s, 20s, ... но не больше updateRetryMaxDelay
+func retryDelay(attempt int) time.Duration {
+	delay := updateRetryBaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= updateRetryMaxDelay {
+			return updateRetryMaxDelay
+		}
+	}
+	return delay
+}
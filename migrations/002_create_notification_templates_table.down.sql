@@ -0,0 +1,10 @@
+-- Удаление реестра шаблонов
+
+ALTER TABLE notifications ALTER COLUMN message_text DROP DEFAULT;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS template_variables,
+    DROP COLUMN IF EXISTS template_version,
+    DROP COLUMN IF EXISTS template_name;
+
+DROP TABLE IF EXISTS notification_templates;
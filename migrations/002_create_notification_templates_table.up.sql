@@ -0,0 +1,45 @@
+-- Реестр шаблонов уведомлений
+
+-- Создание таблицы шаблонов (каждое изменение создаёт новую версию)
+CREATE TABLE IF NOT EXISTS notification_templates (
+    id BIGSERIAL PRIMARY KEY,
+
+    -- Идентификация шаблона
+    name VARCHAR(128) NOT NULL,                 -- Уникальное имя шаблона (например, booking_confirmed)
+    version INT NOT NULL DEFAULT 1,             -- Номер версии (увеличивается при каждом изменении)
+    notification_type notification_type NOT NULL,
+
+    -- Содержимое шаблона (синтаксис Go text/template / html/template)
+    parse_mode VARCHAR(16) NOT NULL DEFAULT 'HTML', -- Режим парсинга Telegram: HTML или пустая строка (plain)
+    text_template TEXT NOT NULL,                -- Шаблон текста сообщения
+    inline_buttons JSONB,                       -- Шаблоны inline-кнопок: [{"text": "...", "url": "..."}]
+    description TEXT,                           -- Описание шаблона для администраторов
+
+    -- Аудит
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP,                       -- Время удаления (soft delete, версии сохраняются для уже созданных уведомлений)
+
+    CONSTRAINT uq_notification_templates_name_version UNIQUE (name, version),
+    CONSTRAINT chk_notification_templates_parse_mode CHECK (parse_mode IN ('HTML', ''))
+);
+
+-- Для API: получение последней версии шаблона по имени
+CREATE INDEX idx_notification_templates_active ON notification_templates(name, version DESC)
+WHERE deleted_at IS NULL;
+
+-- Ссылка на шаблон в уведомлениях (рендеринг выполняется в момент отправки)
+ALTER TABLE notifications
+    ADD COLUMN template_name VARCHAR(128),
+    ADD COLUMN template_version INT,
+    ADD COLUMN template_variables JSONB;
+
+ALTER TABLE notifications ALTER COLUMN message_text SET DEFAULT '';
+
+COMMENT ON TABLE notification_templates IS 'Версионированные шаблоны уведомлений (Go text/template, html/template для parse_mode=HTML)';
+COMMENT ON COLUMN notification_templates.name IS 'Имя шаблона, по которому на него ссылаются уведомления';
+COMMENT ON COLUMN notification_templates.version IS 'Версия шаблона; уведомление фиксирует версию при создании';
+COMMENT ON COLUMN notification_templates.text_template IS 'Шаблон текста сообщения с переменными вида {{.name}}';
+COMMENT ON COLUMN notification_templates.inline_buttons IS 'Шаблоны inline-кнопок в формате JSON (text и url поддерживают переменные)';
+COMMENT ON COLUMN notifications.template_name IS 'Имя шаблона (NULL, если текст передан напрямую)';
+COMMENT ON COLUMN notifications.template_version IS 'Версия шаблона, зафиксированная при создании уведомления';
+COMMENT ON COLUMN notifications.template_variables IS 'Переменные для рендеринга шаблона в момент отправки';
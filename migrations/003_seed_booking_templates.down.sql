@@ -0,0 +1,4 @@
+-- Удаление встроенных шаблонов бронирований (только исходные версии)
+DELETE FROM notification_templates
+WHERE version = 1
+  AND name IN ('booking_created', 'booking_confirmed', 'booking_reminder', 'booking_cancelled');
@@ -0,0 +1,31 @@
+-- Встроенные шаблоны уведомлений о бронированиях
+-- Используются, когда уведомление создаётся только с type и metadata.booking_id:
+-- данные бронирования подтягиваются из BookingService (getBooking) в момент отправки
+
+INSERT INTO notification_templates (name, version, notification_type, parse_mode, text_template, inline_buttons, description)
+VALUES
+(
+    'booking_created', 1, 'booking_created', 'HTML',
+    E'<b>Запись создана</b>\n\n📅 {{.booking_date}}, {{.start_time}}–{{.end_time}}\n{{if .address}}📍 {{.address}}\n{{end}}🧽 {{.service_name}} — {{.service_price}} ₽\n{{if .car}}🚗 {{.car}}\n{{end}}\nМы сообщим, когда запись будет подтверждена.',
+    '[{"kind": "web_app", "text": "Открыть запись", "url": "{{.booking_url}}"}]',
+    'Встроенный шаблон: бронирование создано'
+),
+(
+    'booking_confirmed', 1, 'booking_confirmed', 'HTML',
+    E'<b>Запись подтверждена</b> ✅\n\n📅 {{.booking_date}}, {{.start_time}}–{{.end_time}}\n{{if .address}}📍 {{.address}}\n{{end}}🧽 {{.service_name}} — {{.service_price}} ₽\n{{if .car}}🚗 {{.car}}\n{{end}}\nЖдём вас!',
+    '[{"kind": "web_app", "text": "Открыть запись", "url": "{{.booking_url}}"}]',
+    'Встроенный шаблон: бронирование подтверждено'
+),
+(
+    'booking_reminder', 1, 'booking_reminder', 'HTML',
+    E'<b>Напоминание о записи</b> ⏰\n\n📅 {{.booking_date}}, {{.start_time}}–{{.end_time}}\n{{if .address}}📍 {{.address}}\n{{end}}🧽 {{.service_name}}\n{{if .car}}🚗 {{.car}}\n{{end}}',
+    '[{"kind": "web_app", "text": "Открыть запись", "url": "{{.booking_url}}"}]',
+    'Встроенный шаблон: напоминание о бронировании'
+),
+(
+    'booking_cancelled', 1, 'booking_cancelled', 'HTML',
+    E'<b>Запись отменена</b> ❌\n\n📅 {{.booking_date}}, {{.start_time}}–{{.end_time}}\n{{if .address}}📍 {{.address}}\n{{end}}🧽 {{.service_name}}\n{{if .cancellation_reason}}\nПричина: {{.cancellation_reason}}\n{{end}}',
+    '[{"kind": "web_app", "text": "Открыть запись", "url": "{{.booking_url}}"}]',
+    'Встроенный шаблон: бронирование отменено'
+)
+ON CONFLICT (name, version) DO NOTHING;
@@ -0,0 +1,19 @@
+-- Удаление локализации (остаются только варианты на языке по умолчанию)
+
+DROP TABLE IF EXISTS bot_users;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS locale;
+
+DELETE FROM notification_templates WHERE locale <> 'ru';
+
+DROP INDEX IF EXISTS idx_notification_templates_active;
+CREATE INDEX idx_notification_templates_active ON notification_templates(name, version DESC)
+WHERE deleted_at IS NULL;
+
+ALTER TABLE notification_templates
+    DROP CONSTRAINT uq_notification_templates_name_locale_version,
+    ADD CONSTRAINT uq_notification_templates_name_version UNIQUE (name, version);
+
+ALTER TABLE notification_templates
+    DROP COLUMN IF EXISTS locale;
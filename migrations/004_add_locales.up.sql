@@ -0,0 +1,65 @@
+-- Локализация уведомлений
+
+-- Языковые варианты шаблонов: версии нумеруются отдельно для каждого языка
+ALTER TABLE notification_templates
+    ADD COLUMN locale VARCHAR(16) NOT NULL DEFAULT 'ru';
+
+ALTER TABLE notification_templates
+    DROP CONSTRAINT uq_notification_templates_name_version,
+    ADD CONSTRAINT uq_notification_templates_name_locale_version UNIQUE (name, locale, version);
+
+DROP INDEX IF EXISTS idx_notification_templates_active;
+CREATE INDEX idx_notification_templates_active ON notification_templates(name, locale, version DESC)
+WHERE deleted_at IS NULL;
+
+-- Язык уведомления (для шаблона - язык выбранного варианта, чтобы версия однозначно определяла текст)
+ALTER TABLE notifications
+    ADD COLUMN locale VARCHAR(16);
+
+-- Пользователи бота: настройки, известные только NotificationService
+CREATE TABLE IF NOT EXISTS bot_users (
+    tg_user_id BIGINT PRIMARY KEY,              -- Telegram ID пользователя
+    language_code VARCHAR(16),                  -- Язык интерфейса Telegram (сохраняется при /start)
+
+    -- Аудит
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER trg_bot_users_updated_at
+    BEFORE UPDATE ON bot_users
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Английские варианты встроенных шаблонов бронирований
+INSERT INTO notification_templates (name, version, locale, notification_type, parse_mode, text_template, inline_buttons, description)
+VALUES
+(
+    'booking_created', 1, 'en', 'booking_created', 'HTML',
+    E'<b>Booking created</b>\n\n📅 {{.booking_date}}, {{.start_time}}–{{.end_time}}\n{{if .address}}📍 {{.address}}\n{{end}}🧽 {{.service_name}} — {{.service_price}} ₽\n{{if .car}}🚗 {{.car}}\n{{end}}\nWe will let you know once the booking is confirmed.',
+    '[{"kind": "web_app", "text": "Open booking", "url": "{{.booking_url}}"}]',
+    'Built-in template: booking created'
+),
+(
+    'booking_confirmed', 1, 'en', 'booking_confirmed', 'HTML',
+    E'<b>Booking confirmed</b> ✅\n\n📅 {{.booking_date}}, {{.start_time}}–{{.end_time}}\n{{if .address}}📍 {{.address}}\n{{end}}🧽 {{.service_name}} — {{.service_price}} ₽\n{{if .car}}🚗 {{.car}}\n{{end}}\nSee you soon!',
+    '[{"kind": "web_app", "text": "Open booking", "url": "{{.booking_url}}"}]',
+    'Built-in template: booking confirmed'
+),
+(
+    'booking_reminder', 1, 'en', 'booking_reminder', 'HTML',
+    E'<b>Booking reminder</b> ⏰\n\n📅 {{.booking_date}}, {{.start_time}}–{{.end_time}}\n{{if .address}}📍 {{.address}}\n{{end}}🧽 {{.service_name}}\n{{if .car}}🚗 {{.car}}\n{{end}}',
+    '[{"kind": "web_app", "text": "Open booking", "url": "{{.booking_url}}"}]',
+    'Built-in template: booking reminder'
+),
+(
+    'booking_cancelled', 1, 'en', 'booking_cancelled', 'HTML',
+    E'<b>Booking cancelled</b> ❌\n\n📅 {{.booking_date}}, {{.start_time}}–{{.end_time}}\n{{if .address}}📍 {{.address}}\n{{end}}🧽 {{.service_name}}\n{{if .cancellation_reason}}\nReason: {{.cancellation_reason}}\n{{end}}',
+    '[{"kind": "web_app", "text": "Open booking", "url": "{{.booking_url}}"}]',
+    'Built-in template: booking cancelled'
+)
+ON CONFLICT (name, locale, version) DO NOTHING;
+
+COMMENT ON COLUMN notification_templates.locale IS 'Язык варианта шаблона; при отсутствии варианта для языка получателя используется язык по умолчанию';
+COMMENT ON COLUMN notifications.locale IS 'Язык уведомления (явно из запроса или язык получателя из bot_users)';
+COMMENT ON TABLE bot_users IS 'Пользователи бота и их настройки (язык интерфейса)';
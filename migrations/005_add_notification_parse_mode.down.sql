@@ -0,0 +1,5 @@
+-- Удаление режима парсинга текста
+
+ALTER TABLE notifications
+    DROP CONSTRAINT IF EXISTS chk_notifications_parse_mode,
+    DROP COLUMN IF EXISTS parse_mode;
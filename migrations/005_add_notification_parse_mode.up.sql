@@ -0,0 +1,7 @@
+-- Режим парсинга текста для каждого уведомления
+
+ALTER TABLE notifications
+    ADD COLUMN parse_mode VARCHAR(16),
+    ADD CONSTRAINT chk_notifications_parse_mode CHECK (parse_mode IS NULL OR parse_mode IN ('HTML', ''));
+
+COMMENT ON COLUMN notifications.parse_mode IS 'Режим парсинга Telegram: HTML (по умолчанию, текст очищается до поддерживаемого подмножества) или пустая строка (без форматирования)';
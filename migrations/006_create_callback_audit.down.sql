@@ -0,0 +1,5 @@
+-- Удаление журнала callback-действий
+
+DELETE FROM notification_templates WHERE name = 'booking_reminder' AND version = 2;
+
+DROP TABLE IF EXISTS callback_audit;
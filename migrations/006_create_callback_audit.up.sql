@@ -0,0 +1,42 @@
+-- Журнал действий по callback-кнопкам
+
+CREATE TABLE IF NOT EXISTS callback_audit (
+    id BIGSERIAL PRIMARY KEY,
+    callback_query_id VARCHAR(64) NOT NULL,                                -- ID callback-запроса Telegram
+    notification_id BIGINT REFERENCES notifications(id) ON DELETE SET NULL, -- Уведомление, к которому привязана кнопка
+    tg_user_id BIGINT NOT NULL,                                            -- Кто нажал кнопку
+    action VARCHAR(32),                                                    -- Действие (NULL, если callback_data не прошли проверку)
+    arg VARCHAR(32),                                                       -- Аргумент действия
+    status VARCHAR(16) NOT NULL,                                           -- Результат обработки
+    error_message TEXT,                                                    -- Причина отказа или ошибки
+
+    -- Аудит
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_callback_audit_status CHECK (status IN ('success', 'rejected', 'failed'))
+);
+
+CREATE INDEX idx_callback_audit_notification ON callback_audit(notification_id);
+CREATE INDEX idx_callback_audit_tg_user ON callback_audit(tg_user_id, created_at DESC);
+
+-- Действие по уведомлению выполняется один раз: повторное нажатие той же кнопки отклоняется
+CREATE UNIQUE INDEX idx_callback_audit_success ON callback_audit(notification_id, action) WHERE status = 'success';
+
+COMMENT ON TABLE callback_audit IS 'Журнал нажатий callback-кнопок: каждое действие фиксируется вместе с результатом';
+COMMENT ON COLUMN callback_audit.status IS 'success - действие выполнено, rejected - запрос отклонён (подпись, чужое уведомление), failed - ошибка выполнения';
+
+-- Напоминание с кнопками подтверждения и отмены в одном ряду
+INSERT INTO notification_templates (name, version, locale, notification_type, parse_mode, text_template, inline_buttons, description)
+VALUES
+(
+    'booking_reminder', 2, 'ru', 'booking_reminder', 'HTML',
+    E'<b>Напоминание о записи</b> ⏰\n\n📅 {{.booking_date}}, {{.start_time}}–{{.end_time}}\n{{if .address}}📍 {{.address}}\n{{end}}🧽 {{.service_name}}\n{{if .car}}🚗 {{.car}}\n{{end}}',
+    '[{"kind": "callback_data", "text": "✅ Подтвердить", "callback_data": "confirm_booking", "row": 1}, {"kind": "callback_data", "text": "❌ Отменить", "callback_data": "cancel_booking", "row": 1}, {"kind": "callback_data", "text": "⏰ Напомнить через час", "callback_data": "snooze:60", "row": 2}, {"kind": "web_app", "text": "Открыть запись", "url": "{{.booking_url}}"}]',
+    'Встроенный шаблон: напоминание о бронировании с подтверждением и отменой'
+),
+(
+    'booking_reminder', 2, 'en', 'booking_reminder', 'HTML',
+    E'<b>Booking reminder</b> ⏰\n\n📅 {{.booking_date}}, {{.start_time}}–{{.end_time}}\n{{if .address}}📍 {{.address}}\n{{end}}🧽 {{.service_name}}\n{{if .car}}🚗 {{.car}}\n{{end}}',
+    '[{"kind": "callback_data", "text": "✅ Confirm", "callback_data": "confirm_booking", "row": 1}, {"kind": "callback_data", "text": "❌ Cancel", "callback_data": "cancel_booking", "row": 1}, {"kind": "callback_data", "text": "⏰ Remind in an hour", "callback_data": "snooze:60", "row": 2}, {"kind": "web_app", "text": "Open booking", "url": "{{.booking_url}}"}]',
+    'Built-in template: booking reminder with confirm and cancel'
+);
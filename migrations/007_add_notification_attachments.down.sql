@@ -0,0 +1,4 @@
+-- Удаление вложений уведомлений
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS attachments;
@@ -0,0 +1,6 @@
+-- Вложения уведомлений: документы, видео, аудио, точки на карте и контакты
+
+ALTER TABLE notifications
+    ADD COLUMN attachments JSONB;
+
+COMMENT ON COLUMN notifications.attachments IS 'Вложения: [{"type": "document|video|audio|photo|location|venue|contact", "url"|"file_id", "caption", "filename", ...}]';
@@ -0,0 +1,3 @@
+-- Удаление кэша file_id
+
+DROP TABLE IF EXISTS telegram_files;
@@ -0,0 +1,18 @@
+-- Кэш file_id загруженных в Telegram файлов
+
+CREATE TABLE IF NOT EXISTS telegram_files (
+    cache_key TEXT PRIMARY KEY,         -- "<тип>:url:<URL>[#<имя файла>]" или "<тип>:sha256:<хэш содержимого>"
+    file_id TEXT NOT NULL,              -- file_id из ответа Telegram на первую отправку
+    media_type VARCHAR(16) NOT NULL,    -- Тип отправки (photo, video, document, audio)
+
+    -- Аудит
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER trg_telegram_files_updated_at
+    BEFORE UPDATE ON telegram_files
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE telegram_files IS 'Кэш file_id: повторные отправки файла используют file_id вместо загрузки с диска или скачивания по URL';
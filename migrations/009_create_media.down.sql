@@ -0,0 +1,3 @@
+-- Удаление медиатеки
+
+DROP TABLE IF EXISTS media;
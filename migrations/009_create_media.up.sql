@@ -0,0 +1,14 @@
+-- Медиатека: файлы, загруженные через API для использования во вложениях уведомлений
+
+CREATE TABLE IF NOT EXISTS media (
+    id UUID PRIMARY KEY,                -- media_id, он же ключ содержимого в blob-хранилище
+    media_type VARCHAR(16) NOT NULL,    -- Тип вложения (photo, video, document, audio)
+    filename TEXT NOT NULL,             -- Имя, под которым файл отправляется в Telegram
+    content_type VARCHAR(255) NOT NULL, -- MIME-тип, определённый по содержимому
+    size_bytes BIGINT NOT NULL,
+
+    -- Аудит
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE media IS 'Медиатека: уведомления ссылаются на файлы через media_id вложения';
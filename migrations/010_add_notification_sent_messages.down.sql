@@ -0,0 +1,6 @@
+-- Удаление сведений об отправленных сообщениях уведомлений
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS messages_deleted_at,
+    DROP COLUMN IF EXISTS messages_edited_at,
+    DROP COLUMN IF EXISTS sent_messages;
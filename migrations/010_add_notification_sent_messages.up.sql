@@ -0,0 +1,10 @@
+-- Сообщения Telegram, отправленные по уведомлению, для последующего редактирования и удаления
+
+ALTER TABLE notifications
+    ADD COLUMN sent_messages JSONB,
+    ADD COLUMN messages_edited_at TIMESTAMP,
+    ADD COLUMN messages_deleted_at TIMESTAMP;
+
+COMMENT ON COLUMN notifications.sent_messages IS 'Отправленные сообщения в порядке отправки: [{"message_id": 123, "role": "text|caption|file|buttons|standalone", "has_keyboard": true}]';
+COMMENT ON COLUMN notifications.messages_edited_at IS 'Время последнего редактирования отправленных сообщений';
+COMMENT ON COLUMN notifications.messages_deleted_at IS 'Время удаления отправленных сообщений из чата';
@@ -0,0 +1,4 @@
+-- Удаление параметров доставки уведомлений
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS delivery_options;
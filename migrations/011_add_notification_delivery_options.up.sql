@@ -0,0 +1,6 @@
+-- Параметры доставки уведомлений: без звука, защита от пересылки, превью ссылок, ответ на сообщение и тема форума
+
+ALTER TABLE notifications
+    ADD COLUMN delivery_options JSONB;
+
+COMMENT ON COLUMN notifications.delivery_options IS 'Параметры доставки: {"disable_notification", "protect_content", "link_preview": {"is_disabled", "url", "prefer_small_media", "prefer_large_media", "show_above_text"}, "reply_to_message_id", "message_thread_id"}';
@@ -0,0 +1,9 @@
+-- Удаление атрибуции пользователей бота
+
+DROP INDEX IF EXISTS idx_bot_users_start;
+
+ALTER TABLE bot_users
+    DROP COLUMN IF EXISTS attributed_at,
+    DROP COLUMN IF EXISTS start_param,
+    DROP COLUMN IF EXISTS start_value,
+    DROP COLUMN IF EXISTS start_kind;
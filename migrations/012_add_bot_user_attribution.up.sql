@@ -0,0 +1,14 @@
+-- Атрибуция пользователей бота: первая deep-link ссылка /start (приглашение компании, бронирование, реферал, метка источника)
+
+ALTER TABLE bot_users
+    ADD COLUMN start_kind VARCHAR(16),
+    ADD COLUMN start_value VARCHAR(64),
+    ADD COLUMN start_param VARCHAR(64),
+    ADD COLUMN attributed_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_bot_users_start ON bot_users(start_kind, start_value) WHERE start_kind IS NOT NULL;
+
+COMMENT ON COLUMN bot_users.start_kind IS 'Вид первой deep-link ссылки: company, booking, ref, source';
+COMMENT ON COLUMN bot_users.start_value IS 'Значение ссылки: ID компании или бронирования, реферальный код, метка источника';
+COMMENT ON COLUMN bot_users.start_param IS 'Исходный параметр /start';
+COMMENT ON COLUMN bot_users.attributed_at IS 'Время первого перехода по deep-link ссылке (последующие переходы не перезаписывают атрибуцию)';
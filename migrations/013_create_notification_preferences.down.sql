@@ -0,0 +1,9 @@
+-- Удаление настроек уведомлений
+-- Значение skipped остаётся в типе notification_status: PostgreSQL не поддерживает удаление значений enum
+
+DROP TABLE IF EXISTS notification_preferences;
+
+UPDATE notifications SET status = 'cancelled' WHERE status = 'skipped';
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS skip_reason;
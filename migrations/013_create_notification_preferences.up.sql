@@ -0,0 +1,27 @@
+-- Настройки уведомлений пользователей: отключённые типы и каналы доставки
+-- Уведомления, отключённые получателем, не отправляются и получают статус skipped с причиной
+
+ALTER TYPE notification_status ADD VALUE IF NOT EXISTS 'skipped';
+
+ALTER TABLE notifications
+    ADD COLUMN skip_reason VARCHAR(32);
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    tg_user_id BIGINT PRIMARY KEY,                  -- Telegram ID пользователя
+    disabled_types TEXT[] NOT NULL DEFAULT '{}',    -- Отключённые типы уведомлений
+    disabled_channels TEXT[] NOT NULL DEFAULT '{}', -- Отключённые каналы доставки
+
+    -- Аудит
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER trg_notification_preferences_updated_at
+    BEFORE UPDATE ON notification_preferences
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE notification_preferences IS 'Настройки уведомлений пользователей (/settings и API); уведомления о бронированиях обязательны и не отключаются';
+COMMENT ON COLUMN notification_preferences.disabled_types IS 'Отключённые типы уведомлений (promo, welcome); новые типы по умолчанию включены';
+COMMENT ON COLUMN notification_preferences.disabled_channels IS 'Отключённые каналы доставки (telegram)';
+COMMENT ON COLUMN notifications.skip_reason IS 'Причина пропуска для статуса skipped: type_disabled, channel_disabled';
@@ -0,0 +1,10 @@
+-- Удаление тихих часов и часового пояса пользователя
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS deferred_until;
+
+ALTER TABLE notification_preferences
+    DROP CONSTRAINT IF EXISTS chk_notification_preferences_quiet_hours,
+    DROP COLUMN IF EXISTS quiet_hours_end,
+    DROP COLUMN IF EXISTS quiet_hours_start,
+    DROP COLUMN IF EXISTS time_zone;
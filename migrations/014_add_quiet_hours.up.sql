@@ -0,0 +1,17 @@
+-- Тихие часы и часовой пояс пользователя
+-- Несрочные уведомления, попавшие в тихие часы, откладываются processor'ом до конца окна
+
+ALTER TABLE notification_preferences
+    ADD COLUMN time_zone VARCHAR(64),
+    ADD COLUMN quiet_hours_start TIME,
+    ADD COLUMN quiet_hours_end TIME,
+    ADD CONSTRAINT chk_notification_preferences_quiet_hours
+        CHECK ((quiet_hours_start IS NULL) = (quiet_hours_end IS NULL));
+
+ALTER TABLE notifications
+    ADD COLUMN deferred_until TIMESTAMP;
+
+COMMENT ON COLUMN notification_preferences.time_zone IS 'Часовой пояс IANA (например, Europe/Moscow); NULL - UTC';
+COMMENT ON COLUMN notification_preferences.quiet_hours_start IS 'Начало тихих часов по часовому поясу пользователя';
+COMMENT ON COLUMN notification_preferences.quiet_hours_end IS 'Конец тихих часов; окно может переходить через полночь (23:00-08:00)';
+COMMENT ON COLUMN notifications.deferred_until IS 'Pending уведомление отложено до этого времени (UTC): конец тихих часов получателя или повторная отправка после ошибки';
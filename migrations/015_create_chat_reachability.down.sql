@@ -0,0 +1,5 @@
+-- Удаление доступности чатов
+
+DROP TABLE IF EXISTS chat_reachability;
+
+COMMENT ON COLUMN notifications.skip_reason IS 'Причина пропуска для статуса skipped: type_disabled, channel_disabled';
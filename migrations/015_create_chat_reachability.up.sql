@@ -0,0 +1,30 @@
+-- Доступность чатов для отправки: заблокировавшие бота пользователи и группы, из которых бота удалили
+-- Уведомления в недоступный чат не отправляются и получают статус skipped с причиной chat_unreachable
+
+CREATE TABLE IF NOT EXISTS chat_reachability (
+    chat_id BIGINT PRIMARY KEY,           -- ID чата Telegram (для личного чата совпадает с tg_user_id)
+    reachable BOOLEAN NOT NULL,           -- Может ли бот писать в чат
+    reason VARCHAR(32),                   -- Причина недоступности
+    source VARCHAR(32) NOT NULL,          -- Источник последнего изменения
+    error_message TEXT,                   -- Описание ошибки Telegram
+    unreachable_since TIMESTAMP,          -- Когда чат стал недоступен
+    restored_at TIMESTAMP,                -- Когда доступность восстановлена
+
+    -- Аудит
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Список недоступных чатов (API) и проверка перед отправкой
+CREATE INDEX idx_chat_reachability_unreachable ON chat_reachability(unreachable_since DESC)
+    WHERE reachable = FALSE;
+
+CREATE TRIGGER trg_chat_reachability_updated_at
+    BEFORE UPDATE ON chat_reachability
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE chat_reachability IS 'Доступность чатов: ошибки 403 при отправке и обновления my_chat_member; /start восстанавливает доступность';
+COMMENT ON COLUMN chat_reachability.reason IS 'Причина недоступности: blocked, kicked, deactivated, forbidden';
+COMMENT ON COLUMN chat_reachability.source IS 'Источник: send_error, my_chat_member, start';
+COMMENT ON COLUMN notifications.skip_reason IS 'Причина пропуска для статуса skipped: type_disabled, channel_disabled, chat_unreachable';
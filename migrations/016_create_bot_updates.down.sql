@@ -0,0 +1,3 @@
+-- Удаление очереди обновлений Telegram
+
+DROP TABLE IF EXISTS bot_updates;
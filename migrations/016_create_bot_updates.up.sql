@@ -0,0 +1,37 @@
+-- Очередь обновлений Telegram, полученных через webhook и long polling
+-- Webhook сохраняет обновление и сразу отвечает 200, обработка с повторами выполняется в фоне
+-- Обработанные обновления удаляются после срока хранения (worker.update_retention_days)
+
+CREATE TABLE IF NOT EXISTS bot_updates (
+    update_id BIGINT PRIMARY KEY,                     -- update_id из Telegram (повторная доставка в пределах срока хранения не создаёт дубль)
+    payload JSONB NOT NULL,                           -- Обновление в формате Bot API
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',    -- pending, done, failed
+    attempts INTEGER NOT NULL DEFAULT 0,              -- Выполненные попытки обработки
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(), -- Время следующей попытки
+    last_error TEXT,                                  -- Ошибка последней попытки
+    processed_at TIMESTAMP,                           -- Время успешной обработки
+    completed_steps TEXT[] NOT NULL DEFAULT '{}',     -- Неповторяемые шаги обработки, уже выполненные (например, welcome)
+
+    -- Аудит
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_bot_updates_status CHECK (status IN ('pending', 'done', 'failed'))
+);
+
+-- Выборка обновлений, готовых к обработке
+CREATE INDEX idx_bot_updates_pending ON bot_updates(next_attempt_at, update_id)
+    WHERE status = 'pending';
+
+-- Удаление обработанных обновлений после срока хранения
+CREATE INDEX idx_bot_updates_processed ON bot_updates(created_at)
+    WHERE status <> 'pending';
+
+CREATE TRIGGER trg_bot_updates_updated_at
+    BEFORE UPDATE ON bot_updates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE bot_updates IS 'Обновления Telegram из webhook и long polling: сохраняются до обработки, update_id защищает от повторной обработки в пределах срока хранения';
+COMMENT ON COLUMN bot_updates.status IS 'pending - ожидает обработки, done - обработано, failed - исчерпаны попытки';
+COMMENT ON COLUMN bot_updates.completed_steps IS 'Шаги, которые повторная обработка пропускает: отправленный ответ не дублируется, остальные действия повторяются';
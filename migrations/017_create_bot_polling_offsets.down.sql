@@ -0,0 +1,3 @@
+-- Удаление смещения long polling
+
+DROP TABLE IF EXISTS bot_polling_offsets;
@@ -0,0 +1,17 @@
+-- Смещение long polling: после перезапуска getUpdates продолжается с последнего сохранённого обновления
+
+CREATE TABLE IF NOT EXISTS bot_polling_offsets (
+    bot_id BIGINT PRIMARY KEY,          -- ID бота (смена токена на другого бота начинает с нуля)
+    update_offset BIGINT NOT NULL,      -- offset для getUpdates: последний сохранённый update_id + 1
+
+    -- Аудит
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER trg_bot_polling_offsets_updated_at
+    BEFORE UPDATE ON bot_polling_offsets
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE bot_polling_offsets IS 'Смещение long polling по ботам; обработанные update_id хранятся в bot_updates';
@@ -0,0 +1,3 @@
+-- Удаление переписки с поддержкой
+
+DROP TABLE IF EXISTS support_messages;
@@ -0,0 +1,32 @@
+-- Переписка пользователей с поддержкой
+-- Сообщения пользователя боту пересылаются в чат операторов, ответы операторов отправляются пользователю
+
+CREATE TABLE IF NOT EXISTS support_messages (
+    id BIGSERIAL PRIMARY KEY,
+    tg_user_id BIGINT NOT NULL,         -- Пользователь (личный чат с ботом)
+    direction VARCHAR(16) NOT NULL,     -- inbound - от пользователя, outbound - ответ оператора
+    text TEXT NOT NULL,                 -- Текст сообщения
+    user_message_id INTEGER,            -- ID сообщения в чате пользователя
+    operator_chat_id BIGINT,            -- Чат операторов
+    operator_message_id INTEGER,        -- ID сообщения в чате операторов
+    operator_tg_user_id BIGINT,         -- Оператор, ответивший пользователю
+
+    -- Аудит
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_support_messages_direction CHECK (direction IN ('inbound', 'outbound'))
+);
+
+-- Сообщения переписки и список переписок по последнему сообщению
+CREATE INDEX idx_support_messages_user ON support_messages(tg_user_id, created_at DESC);
+
+-- Сообщение пользователя сохраняется один раз: повторная обработка обновления не создаёт вторую запись
+CREATE UNIQUE INDEX idx_support_messages_inbound ON support_messages(tg_user_id, user_message_id)
+    WHERE direction = 'inbound';
+
+-- Поиск пересланного сообщения по ответу оператора
+CREATE INDEX idx_support_messages_operator_message ON support_messages(operator_chat_id, operator_message_id)
+    WHERE operator_message_id IS NOT NULL;
+
+COMMENT ON TABLE support_messages IS 'Переписка пользователей с поддержкой через бота';
+COMMENT ON COLUMN support_messages.operator_message_id IS 'Для inbound - пересланное в чат операторов сообщение (на него отвечают), для outbound - ответ оператора';
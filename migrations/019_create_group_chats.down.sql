@@ -0,0 +1,3 @@
+-- Удаление реестра групповых чатов
+
+DROP TABLE IF EXISTS group_chats;
@@ -0,0 +1,32 @@
+-- Групповые чаты, в которые добавлен бот, и их привязка к компаниям
+-- Чат регистрируется по обновлению my_chat_member, к компании привязывается командой /link <код компании>
+
+CREATE TABLE IF NOT EXISTS group_chats (
+    chat_id BIGINT PRIMARY KEY,           -- ID чата Telegram
+    type VARCHAR(16) NOT NULL,            -- group, supergroup
+    title TEXT NOT NULL DEFAULT '',       -- Название чата на момент последнего обновления
+    active BOOLEAN NOT NULL DEFAULT TRUE, -- Бот состоит в чате
+    company_id BIGINT,                    -- Компания, к которой привязан чат
+    added_by_tg_user_id BIGINT,           -- Кто добавил бота в чат
+    linked_by_tg_user_id BIGINT,          -- Кто привязал чат к компании
+    linked_at TIMESTAMP,                  -- Когда чат привязан к компании
+    migrated_to_chat_id BIGINT,           -- Новый ID после преобразования группы в супергруппу
+
+    -- Аудит
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_group_chats_type CHECK (type IN ('group', 'supergroup'))
+);
+
+-- Чаты компании для уведомлений сотрудникам (API)
+CREATE INDEX idx_group_chats_company ON group_chats(company_id, linked_at DESC)
+    WHERE company_id IS NOT NULL AND active = TRUE;
+
+CREATE TRIGGER trg_group_chats_updated_at
+    BEFORE UPDATE ON group_chats
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE group_chats IS 'Групповые чаты с ботом: регистрация по my_chat_member, привязка к компании командой /link';
+COMMENT ON COLUMN group_chats.migrated_to_chat_id IS 'Группа преобразована в супергруппу (migrate_to_chat_id): привязка перенесена на новый чат, старый неактивен';
@@ -0,0 +1,125 @@
+// Package callbackdata подписывает callback_data inline-кнопок
+//
+// Формат: "<action>[:<arg>]|<notification_id в base36>|<подпись>"
+// Подпись - усечённый HMAC-SHA256, поэтому пользователь не может подменить действие или ID уведомления
+package callbackdata
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxDataLength лимит Telegram на callback_data (в байтах)
+	MaxDataLength = 64
+
+	// MaxPayloadLength максимальная длина "<action>[:<arg>]", при которой подписанные данные укладываются в MaxDataLength
+	MaxPayloadLength = 24
+
+	separator    = "|"
+	argSeparator = ":"
+
+	// signatureBytes длина усечённого HMAC (12 байт = 16 символов base64url)
+	signatureBytes = 12
+)
+
+var (
+	// ErrInvalidPayload возвращается при некорректном формате действия
+	ErrInvalidPayload = errors.New("callbackdata: invalid payload")
+
+	// ErrInvalidData возвращается, если callback_data не соответствует формату
+	ErrInvalidData = errors.New("callbackdata: invalid data")
+
+	// ErrInvalidSignature возвращается при несовпадении подписи
+	ErrInvalidSignature = errors.New("callbackdata: invalid signature")
+)
+
+// actionPattern допустимое имя действия
+var actionPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Data разобранные данные callback-запроса
+type Data struct {
+	Action         string
+	Arg            string
+	NotificationID int64
+}
+
+// Signer подписывает и проверяет callback_data
+type Signer struct {
+	key []byte
+}
+
+// NewSigner создает подписчика с секретным ключом
+func NewSigner(secret string) *Signer {
+	return &Signer{key: []byte(secret)}
+}
+
+// ParsePayload разбирает "<action>[:<arg>]" и проверяет длину
+func ParsePayload(payload string) (action, arg string, err error) {
+	if payload == "" || len(payload) > MaxPayloadLength {
+		return "", "", fmt.Errorf("%w: length must be 1-%d bytes", ErrInvalidPayload, MaxPayloadLength)
+	}
+	if strings.Contains(payload, separator) {
+		return "", "", fmt.Errorf("%w: %q is not allowed", ErrInvalidPayload, separator)
+	}
+
+	action, arg, _ = strings.Cut(payload, argSeparator)
+	if !actionPattern.MatchString(action) {
+		return "", "", fmt.Errorf("%w: invalid action %q", ErrInvalidPayload, action)
+	}
+
+	return action, arg, nil
+}
+
+// Sign формирует подписанные callback_data для уведомления
+func (s *Signer) Sign(payload string, notificationID int64) (string, error) {
+	if _, _, err := ParsePayload(payload); err != nil {
+		return "", err
+	}
+
+	body := payload + separator + strconv.FormatInt(notificationID, 36)
+	return body + separator + s.signature(body), nil
+}
+
+// Verify проверяет подпись и разбирает callback_data
+func (s *Signer) Verify(data string) (*Data, error) {
+	idx := strings.LastIndex(data, separator)
+	if idx < 0 {
+		return nil, ErrInvalidData
+	}
+	body, signature := data[:idx], data[idx+1:]
+
+	if !hmac.Equal([]byte(signature), []byte(s.signature(body))) {
+		return nil, ErrInvalidSignature
+	}
+
+	payload, rawID, ok := strings.Cut(body, separator)
+	if !ok {
+		return nil, ErrInvalidData
+	}
+
+	notificationID, err := strconv.ParseInt(rawID, 36, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: notification id: %v", ErrInvalidData, err)
+	}
+
+	action, arg, err := ParsePayload(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidData, err)
+	}
+
+	return &Data{Action: action, Arg: arg, NotificationID: notificationID}, nil
+}
+
+// signature вычисляет усечённый HMAC-SHA256
+func (s *Signer) signature(body string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(body))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:signatureBytes])
+}
@@ -0,0 +1,89 @@
+package callbackdata
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner_SignVerify(t *testing.T) {
+	signer := NewSigner("secret")
+
+	data, err := signer.Sign("snooze:30", 12345)
+	require.NoError(t, err)
+
+	parsed, err := signer.Verify(data)
+	require.NoError(t, err)
+	assert.Equal(t, &Data{Action: "snooze", Arg: "30", NotificationID: 12345}, parsed)
+}
+
+func TestSigner_FitsTelegramLimit(t *testing.T) {
+	data, err := NewSigner("secret").Sign(strings.Repeat("a", MaxPayloadLength), math.MaxInt64)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(data), MaxDataLength)
+}
+
+func TestSigner_Verify_Tampered(t *testing.T) {
+	signer := NewSigner("secret")
+
+	data, err := signer.Sign("cancel_booking", 1)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{"other notification", strings.Replace(data, "|1|", "|2|", 1), ErrInvalidSignature},
+		{"other action", strings.Replace(data, "cancel_booking", "confirm_booking", 1), ErrInvalidSignature},
+		{"other key", mustSign(t, NewSigner("other"), "cancel_booking", 1), ErrInvalidSignature},
+		{"no separator", "cancel_booking", ErrInvalidData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := signer.Verify(tt.data)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestParsePayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		action  string
+		arg     string
+		wantErr bool
+	}{
+		{"action only", "confirm_booking", "confirm_booking", "", false},
+		{"action with arg", "snooze:15", "snooze", "15", false},
+		{"empty", "", "", "", true},
+		{"too long", strings.Repeat("a", MaxPayloadLength+1), "", "", true},
+		{"separator", "snooze|1", "", "", true},
+		{"invalid action", "Snooze", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, arg, err := ParsePayload(tt.payload)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPayload)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.action, action)
+			assert.Equal(t, tt.arg, arg)
+		})
+	}
+}
+
+func mustSign(t *testing.T, signer *Signer, payload string, notificationID int64) string {
+	t.Helper()
+	data, err := signer.Sign(payload, notificationID)
+	require.NoError(t, err)
+	return data
+}
@@ -19,6 +19,10 @@ type Metrics struct {
 	DBConnectionsActive prometheus.Gauge
 	DBConnectionsIdle   prometheus.Gauge
 	DBConnectionsMax    prometheus.Gauge
+
+	// Telegram Bot метрики
+	BotUpdatesTotal   *prometheus.CounterVec
+	BotUpdateDuration *prometheus.HistogramVec
 }
 
 // New создаёт новый экземпляр метрик с автоматической регистрацией в Prometheus
@@ -105,6 +109,24 @@ func New(serviceName string) *Metrics {
 				},
 			},
 		),
+
+		// Telegram Bot метрики
+		BotUpdatesTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "bot_updates_total",
+				Help: "Total number of Telegram bot updates",
+			},
+			[]string{"service", "update_type", "handler", "status"},
+		),
+
+		BotUpdateDuration: promauto.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "bot_update_duration_seconds",
+				Help:    "Telegram bot update handling duration in seconds",
+				Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+			},
+			[]string{"service", "update_type", "handler"},
+		),
 	}
 
 	return m
@@ -132,6 +154,12 @@ func (m *Metrics) RecordDBError(service, operation, table, errorType string) {
 	m.DBErrorsTotal.WithLabelValues(service, operation, table, errorType).Inc()
 }
 
+// RecordBotUpdate записывает метрики обработки обновления Telegram
+func (m *Metrics) RecordBotUpdate(service, updateType, handler, status string, duration float64) {
+	m.BotUpdatesTotal.WithLabelValues(service, updateType, handler, status).Inc()
+	m.BotUpdateDuration.WithLabelValues(service, updateType, handler).Observe(duration)
+}
+
 // UpdateDBConnectionStats обновляет метрики connection pool
 func (m *Metrics) UpdateDBConnectionStats(active, idle, max int) {
 	m.DBConnectionsActive.Set(float64(active))
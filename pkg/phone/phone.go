@@ -0,0 +1,47 @@
+// Package phone нормализует номера телефонов к формату E.164 (+<код страны><номер>)
+//
+// Telegram передаёт номер из контакта без ведущего "+", пользователи вводят его с пробелами,
+// скобками и дефисами. Номер без кода страны проверить нельзя, поэтому он считается некорректным.
+package phone
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	// MinDigits минимальное количество цифр номера вместе с кодом страны
+	MinDigits = 8
+
+	// MaxDigits максимальное количество цифр номера по E.164
+	MaxDigits = 15
+)
+
+// ErrInvalid возвращается для строки, не являющейся номером телефона
+var ErrInvalid = errors.New("phone: invalid phone number")
+
+// Normalize приводит номер к формату E.164: "+" и только цифры
+// Допускаются пробелы, скобки, дефисы и точки между цифрами
+func Normalize(raw string) (string, error) {
+	value := strings.TrimSpace(raw)
+	value = strings.TrimPrefix(value, "+")
+
+	var digits strings.Builder
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '(' || r == ')' || r == '.':
+			// Разделители игнорируем
+		default:
+			return "", ErrInvalid
+		}
+	}
+
+	number := digits.String()
+	if len(number) < MinDigits || len(number) > MaxDigits || number[0] == '0' {
+		return "", ErrInvalid
+	}
+
+	return "+" + number, nil
+}
@@ -0,0 +1,47 @@
+package phone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"79991234567", "+79991234567"},
+		{"+79991234567", "+79991234567"},
+		{" +7 (999) 123-45-67 ", "+79991234567"},
+		{"44.20.7946.0958", "+442079460958"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := Normalize(tt.raw)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNormalize_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"+",
+		"1234567",
+		"1234567890123456",
+		"089991234567",
+		"+7 999 ABC 45 67",
+		"++79991234567",
+	}
+
+	for _, raw := range tests {
+		t.Run(raw, func(t *testing.T) {
+			_, err := Normalize(raw)
+			assert.ErrorIs(t, err, ErrInvalid)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+// Package startparam разбирает и подписывает параметры deep-link ссылок на бота (t.me/<bot>?start=<param>)
+//
+// Подписанный формат: "<kind>-<value>-<подпись>", где kind - company (приглашение компании),
+// booking (ссылка на бронирование), ref (реферальный код) или link (код привязки группы к компании). Подпись - усечённый HMAC-SHA256,
+// поэтому пользователь не может подставить чужую компанию или бронирование.
+// Любой другой допустимый параметр считается неподписанной меткой источника (например, рекламной кампании).
+package startparam
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxParamLength лимит Telegram на параметр start
+	MaxParamLength = 64
+
+	// MaxReferralLength максимальная длина реферального кода
+	MaxReferralLength = 24
+
+	separator = "-"
+
+	// signatureBytes длина усечённого HMAC (12 байт = 16 символов base64url)
+	signatureBytes = 12
+
+	// signatureDomain отделяет подписи ссылок от других подписей тем же ключом (например, callback_data)
+	signatureDomain = "start:"
+)
+
+// Виды параметров
+const (
+	KindCompany  = "company" // Приглашение компании: value - ID компании
+	KindBooking  = "booking" // Ссылка на бронирование: value - ID бронирования
+	KindReferral = "ref"     // Реферальный код
+	KindLink     = "link"    // Код привязки группы: value - ID компании и срок действия (LinkValue)
+	KindSource   = "source"  // Неподписанная метка источника: value - исходный параметр
+)
+
+// linkSeparator разделяет ID компании и срок действия в значении кода привязки
+const linkSeparator = "_"
+
+var (
+	// ErrInvalidParam возвращается, если параметр не соответствует ограничениям Telegram или формату вида
+	ErrInvalidParam = errors.New("startparam: invalid start parameter")
+
+	// ErrInvalidSignature возвращается при несовпадении подписи
+	ErrInvalidSignature = errors.New("startparam: invalid signature")
+)
+
+var (
+	// paramPattern символы, которые Telegram допускает в параметре start
+	paramPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+	// referralPattern допустимый реферальный код
+	referralPattern = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+)
+
+// Payload разобранный параметр start
+type Payload struct {
+	Kind   string
+	Value  string
+	Signed bool // Подпись проверена (для KindSource - всегда false)
+}
+
+// ID возвращает числовое значение для company и booking
+func (p *Payload) ID() (int64, bool) {
+	if p.Kind != KindCompany && p.Kind != KindBooking {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(p.Value, 10, 64)
+	return id, err == nil
+}
+
+// Link возвращает ID компании и срок действия кода привязки
+func (p *Payload) Link() (int64, time.Time, bool) {
+	if p.Kind != KindLink {
+		return 0, time.Time{}, false
+	}
+	return parseLinkValue(p.Value)
+}
+
+// LinkValue формирует значение кода привязки: ID компании и срок действия (unix-время)
+func LinkValue(companyID int64, expiresAt time.Time) string {
+	return strconv.FormatInt(companyID, 10) + linkSeparator + strconv.FormatInt(expiresAt.Unix(), 10)
+}
+
+// Signer подписывает и проверяет параметры start
+type Signer struct {
+	key []byte
+}
+
+// NewSigner создает подписчика с секретным ключом
+func NewSigner(secret string) *Signer {
+	return &Signer{key: []byte(secret)}
+}
+
+// Sign формирует подписанный параметр start
+func (s *Signer) Sign(kind, value string) (string, error) {
+	if err := validateValue(kind, value); err != nil {
+		return "", err
+	}
+
+	body := kind + separator + value
+	return body + separator + s.signature(body), nil
+}
+
+// Parse разбирает параметр start
+// Параметр подписанного вида с неверной подписью отклоняется, остальные параметры возвращаются как KindSource
+func (s *Signer) Parse(param string) (*Payload, error) {
+	if param == "" || len(param) > MaxParamLength || !paramPattern.MatchString(param) {
+		return nil, fmt.Errorf("%w: 1-%d characters A-Z, a-z, 0-9, _ and - expected", ErrInvalidParam, MaxParamLength)
+	}
+
+	kind, rest, ok := strings.Cut(param, separator)
+	if !ok || !isSigned(kind) {
+		return &Payload{Kind: KindSource, Value: param}, nil
+	}
+
+	// Подпись в base64url может содержать "-", поэтому значение - до следующего разделителя
+	value, signature, ok := strings.Cut(rest, separator)
+	if !ok {
+		return nil, fmt.Errorf("%w: signature is missing", ErrInvalidParam)
+	}
+
+	body := kind + separator + value
+	if !hmac.Equal([]byte(signature), []byte(s.signature(body))) {
+		return nil, ErrInvalidSignature
+	}
+
+	if err := validateValue(kind, value); err != nil {
+		return nil, err
+	}
+
+	return &Payload{Kind: kind, Value: value, Signed: true}, nil
+}
+
+// isSigned проверяет, требует ли вид подписи
+func isSigned(kind string) bool {
+	return kind == KindCompany || kind == KindBooking || kind == KindReferral || kind == KindLink
+}
+
+// validateValue проверяет значение подписываемого вида
+func validateValue(kind, value string) error {
+	switch kind {
+	case KindCompany, KindBooking:
+		if _, ok := parsePositive(value); !ok {
+			return fmt.Errorf("%w: %s must be a positive integer", ErrInvalidParam, kind)
+		}
+	case KindLink:
+		if _, _, ok := parseLinkValue(value); !ok {
+			return fmt.Errorf("%w: link must be <company_id>%s<expires_at>", ErrInvalidParam, linkSeparator)
+		}
+	case KindReferral:
+		if len(value) > MaxReferralLength || !referralPattern.MatchString(value) {
+			return fmt.Errorf("%w: referral code must be 1-%d characters A-Z, a-z, 0-9", ErrInvalidParam, MaxReferralLength)
+		}
+	default:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidParam, kind)
+	}
+	return nil
+}
+
+// parseLinkValue разбирает значение кода привязки
+func parseLinkValue(value string) (int64, time.Time, bool) {
+	company, expires, ok := strings.Cut(value, linkSeparator)
+	if !ok {
+		return 0, time.Time{}, false
+	}
+
+	companyID, ok := parsePositive(company)
+	if !ok {
+		return 0, time.Time{}, false
+	}
+	expiresAt, ok := parsePositive(expires)
+	if !ok {
+		return 0, time.Time{}, false
+	}
+
+	return companyID, time.Unix(expiresAt, 0).UTC(), true
+}
+
+// parsePositive разбирает положительное число без ведущих нулей и знака
+func parsePositive(value string) (int64, bool) {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n <= 0 || strconv.FormatInt(n, 10) != value {
+		return 0, false
+	}
+	return n, true
+}
+
+// signature вычисляет усечённый HMAC-SHA256
+func (s *Signer) signature(body string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(signatureDomain + body))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:signatureBytes])
+}
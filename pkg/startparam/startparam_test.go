@@ -0,0 +1,136 @@
+package startparam
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner_SignParse(t *testing.T) {
+	signer := NewSigner("secret")
+
+	tests := []struct {
+		kind  string
+		value string
+	}{
+		{KindCompany, "42"},
+		{KindBooking, "1001"},
+		{KindReferral, "FRIEND2024"},
+		{KindLink, "42_1792400400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			param, err := signer.Sign(tt.kind, tt.value)
+			require.NoError(t, err)
+
+			payload, err := signer.Parse(param)
+			require.NoError(t, err)
+			assert.Equal(t, &Payload{Kind: tt.kind, Value: tt.value, Signed: true}, payload)
+		})
+	}
+}
+
+func TestSigner_FitsTelegramLimit(t *testing.T) {
+	signer := NewSigner("secret")
+
+	param, err := signer.Sign(KindBooking, strconv.FormatInt(math.MaxInt64, 10))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(param), MaxParamLength)
+
+	param, err = signer.Sign(KindReferral, strings.Repeat("a", MaxReferralLength))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(param), MaxParamLength)
+}
+
+func TestSigner_Parse_Tampered(t *testing.T) {
+	signer := NewSigner("secret")
+
+	param, err := signer.Sign(KindCompany, "42")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		param string
+	}{
+		{"other company", strings.Replace(param, "-42-", "-43-", 1)},
+		{"other kind", strings.Replace(param, KindCompany, KindBooking, 1)},
+		{"other key", mustSign(t, NewSigner("other"), KindCompany, "42")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := signer.Parse(tt.param)
+			assert.ErrorIs(t, err, ErrInvalidSignature)
+		})
+	}
+}
+
+func TestSigner_Parse_Source(t *testing.T) {
+	payload, err := NewSigner("secret").Parse("promo_spring")
+	require.NoError(t, err)
+	assert.Equal(t, &Payload{Kind: KindSource, Value: "promo_spring"}, payload)
+
+	_, ok := payload.ID()
+	assert.False(t, ok)
+}
+
+func TestSigner_Parse_Invalid(t *testing.T) {
+	signer := NewSigner("secret")
+
+	for _, param := range []string{"", "with space", "кириллица", strings.Repeat("a", MaxParamLength+1), "company-42"} {
+		_, err := signer.Parse(param)
+		assert.ErrorIs(t, err, ErrInvalidParam, param)
+	}
+}
+
+func TestSigner_Sign_Invalid(t *testing.T) {
+	signer := NewSigner("secret")
+
+	for _, tt := range [][2]string{{KindCompany, "0"}, {KindBooking, "-1"}, {KindBooking, "007"}, {KindReferral, "with-dash"}, {KindLink, "42"}, {KindLink, "42_0"}, {KindSource, "x"}} {
+		_, err := signer.Sign(tt[0], tt[1])
+		assert.ErrorIs(t, err, ErrInvalidParam, tt)
+	}
+}
+
+func TestPayload_ID(t *testing.T) {
+	signer := NewSigner("secret")
+
+	payload, err := signer.Parse(mustSign(t, signer, KindBooking, "77"))
+	require.NoError(t, err)
+
+	id, ok := payload.ID()
+	assert.True(t, ok)
+	assert.Equal(t, int64(77), id)
+}
+
+func TestPayload_Link(t *testing.T) {
+	signer := NewSigner("secret")
+	expiresAt := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+
+	param := mustSign(t, signer, KindLink, LinkValue(42, expiresAt))
+	assert.LessOrEqual(t, len(param), MaxParamLength)
+
+	payload, err := signer.Parse(param)
+	require.NoError(t, err)
+
+	companyID, gotExpiresAt, ok := payload.Link()
+	assert.True(t, ok)
+	assert.Equal(t, int64(42), companyID)
+	assert.Equal(t, expiresAt, gotExpiresAt)
+
+	_, ok = payload.ID()
+	assert.False(t, ok)
+}
+
+func mustSign(t *testing.T, signer *Signer, kind, value string) string {
+	t.Helper()
+	param, err := signer.Sign(kind, value)
+	require.NoError(t, err)
+	return param
+}
@@ -0,0 +1,259 @@
+package tghtml
+
+import (
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Лимиты длины текста Telegram Bot API (в UTF-16 code units)
+const (
+	MaxMessageLength = 4096 // Текст сообщения
+	MaxCaptionLength = 1024 // Подпись к фото и медиагруппе
+)
+
+// Приоритеты мест разреза: чем выше, тем предпочтительнее
+const (
+	cutNone = iota
+	cutWord
+	cutSentence
+	cutLine
+	cutParagraph
+)
+
+// cutPoint возможное место разреза
+type cutPoint struct {
+	pos   int     // Индекс токена, с которого начнётся следующая часть
+	size  int     // Длина части до разреза
+	stack []token // Открытые теги в месте разреза
+}
+
+// token элемент текста: символ, HTML-сущность или тег
+type token struct {
+	raw     string
+	tagName string // Имя тега для открывающих и закрывающих тегов
+	closing bool
+	size    int // Длина в UTF-16 code units
+}
+
+func (t token) isTag() bool {
+	return t.tagName != ""
+}
+
+// Length возвращает длину текста в единицах, которыми Telegram считает лимиты (UTF-16 code units)
+// Для HTML учитывается и разметка, поэтому оценка консервативна
+func Length(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+// Split разбивает текст на части не длиннее limit
+// Разрез выполняется по границам абзацев, затем строк, предложений и слов
+// Для HTML незакрытые в части теги закрываются в её конце и открываются заново в следующей
+func Split(text string, limit int, isHTML bool) []string {
+	if Length(text) <= limit {
+		return []string{text}
+	}
+
+	tokens := tokenize(text, isHTML)
+	parts := make([]string, 0, 2)
+
+	// Открытые теги на момент начала текущей части
+	var open []token
+
+	for i := 0; i < len(tokens); {
+		prefix := joinTokens(open)
+		stack := append([]token(nil), open...)
+		size := Length(prefix)
+
+		// Последнее место разреза для каждого приоритета
+		var cuts [cutParagraph + 1]cutPoint
+		hard := cutPoint{pos: -1}
+
+		j := i
+		for ; j < len(tokens); j++ {
+			t := tokens[j]
+			nextStack := applyTag(stack, t)
+
+			// Часть должна уместиться вместе с закрывающими тегами
+			if size+t.size+closingSize(nextStack) > limit {
+				break
+			}
+
+			size += t.size
+			stack = nextStack
+			hard = cutPoint{pos: j + 1, size: size, stack: stack}
+
+			// Разрез после разделителя, но не внутри <pre>/<code>
+			if priority := cutPriority(tokens, j); priority != cutNone && !inCode(stack) {
+				cuts[priority] = cutPoint{pos: j + 1, size: size, stack: stack}
+			}
+		}
+
+		// Остаток текста помещается целиком
+		if j == len(tokens) {
+			parts = append(parts, strings.TrimSpace(prefix+joinTokens(tokens[i:])))
+			break
+		}
+
+		cut := chooseCut(cuts[:], hard, limit)
+		end, endStack := cut.pos, cut.stack
+		// Даже один токен не помещается (например, лимит меньше длины тега) - берём его целиком
+		if end <= i {
+			end, endStack = i+1, applyTag(open, tokens[i])
+		}
+
+		part := strings.TrimSpace(prefix + joinTokens(tokens[i:end]) + closeTags(endStack))
+		if part != "" {
+			parts = append(parts, part)
+		}
+
+		open = endStack
+		i = end
+		// Пропускаем пробельные символы в начале следующей части
+		for i < len(tokens) && !tokens[i].isTag() && strings.TrimSpace(tokens[i].raw) == "" {
+			i++
+		}
+	}
+
+	return parts
+}
+
+// chooseCut выбирает место разреза
+// Предпочтение - разделителю с наивысшим приоритетом, при котором часть заполнена хотя бы наполовину
+func chooseCut(cuts []cutPoint, hard cutPoint, limit int) cutPoint {
+	for p := len(cuts) - 1; p > cutNone; p-- {
+		if cuts[p].pos > 0 && cuts[p].size*2 >= limit {
+			return cuts[p]
+		}
+	}
+
+	for p := len(cuts) - 1; p > cutNone; p-- {
+		if cuts[p].pos > 0 {
+			return cuts[p]
+		}
+	}
+
+	return hard
+}
+
+// tokenize разбивает текст на символы, сущности и (для HTML) теги
+func tokenize(text string, isHTML bool) []token {
+	tokens := make([]token, 0, len(text))
+
+	for i := 0; i < len(text); {
+		if isHTML {
+			switch text[i] {
+			case '<':
+				if m := tagPattern.FindStringSubmatch(text[i:]); m != nil {
+					tokens = append(tokens, token{
+						raw:     m[0],
+						tagName: strings.ToLower(m[2]),
+						closing: m[1] == "/",
+						size:    Length(m[0]),
+					})
+					i += len(m[0])
+					continue
+				}
+			case '&':
+				if entity := entityPattern.FindString(text[i:]); entity != "" {
+					tokens = append(tokens, token{raw: entity, size: len(entity)})
+					i += len(entity)
+					continue
+				}
+			}
+		}
+
+		r, width := utf8.DecodeRuneInString(text[i:])
+		tokens = append(tokens, token{raw: text[i : i+width], size: utf16.RuneLen(r)})
+		i += width
+	}
+
+	return tokens
+}
+
+// cutPriority оценивает место разреза после токена j
+func cutPriority(tokens []token, j int) int {
+	t := tokens[j]
+	if t.isTag() {
+		return cutNone
+	}
+
+	switch t.raw {
+	case "\n":
+		if j > 0 && tokens[j-1].raw == "\n" {
+			return cutParagraph
+		}
+		return cutLine
+	case " ", "\t":
+		if j > 0 {
+			switch tokens[j-1].raw {
+			case ".", "!", "?", "…":
+				return cutSentence
+			}
+		}
+		return cutWord
+	}
+
+	return cutNone
+}
+
+// applyTag возвращает стек открытых тегов после токена
+func applyTag(stack []token, t token) []token {
+	if !t.isTag() {
+		return stack
+	}
+
+	if !t.closing {
+		next := make([]token, len(stack), len(stack)+1)
+		copy(next, stack)
+		return append(next, t)
+	}
+
+	for k := len(stack) - 1; k >= 0; k-- {
+		if stack[k].tagName == t.tagName {
+			return stack[:k:k]
+		}
+	}
+	return stack
+}
+
+// inCode проверяет, находится ли позиция внутри <pre> или <code>
+func inCode(stack []token) bool {
+	for _, t := range stack {
+		if t.tagName == "pre" || t.tagName == "code" {
+			return true
+		}
+	}
+	return false
+}
+
+// closeTags формирует закрывающие теги для открытых тегов в обратном порядке
+func closeTags(stack []token) string {
+	var b strings.Builder
+	for k := len(stack) - 1; k >= 0; k-- {
+		b.WriteString("</" + stack[k].tagName + ">")
+	}
+	return b.String()
+}
+
+// closingSize длина закрывающих тегов для стека
+func closingSize(stack []token) int {
+	n := 0
+	for _, t := range stack {
+		n += len(t.tagName) + 3
+	}
+	return n
+}
+
+// joinTokens склеивает токены в строку
+func joinTokens(tokens []token) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteString(t.raw)
+	}
+	return b.String()
+}
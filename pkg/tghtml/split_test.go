@@ -0,0 +1,51 @@
+package tghtml
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplit_ShortText(t *testing.T) {
+	assert.Equal(t, []string{"<b>x</b>"}, Split("<b>x</b>", 10, true))
+}
+
+func TestSplit_ParagraphBoundary(t *testing.T) {
+	text := strings.Repeat("а", 30) + "\n\n" + strings.Repeat("б", 30)
+
+	parts := Split(text, 40, false)
+
+	assert.Equal(t, []string{strings.Repeat("а", 30), strings.Repeat("б", 30)}, parts)
+}
+
+func TestSplit_SentenceBoundary(t *testing.T) {
+	parts := Split("Первое предложение. Второе предложение подлиннее.", 30, false)
+
+	assert.Equal(t, []string{"Первое предложение.", "Второе предложение подлиннее."}, parts)
+}
+
+func TestSplit_ReopensTags(t *testing.T) {
+	parts := Split("<b>"+strings.Repeat("слово ", 10)+"</b>", 40, true)
+
+	assert.Greater(t, len(parts), 1)
+	for _, part := range parts {
+		assert.LessOrEqual(t, Length(part), 40)
+		assert.True(t, strings.HasPrefix(part, "<b>"), part)
+		assert.True(t, strings.HasSuffix(part, "</b>"), part)
+	}
+}
+
+func TestSplit_HardCut(t *testing.T) {
+	parts := Split(strings.Repeat("x", 25), 10, false)
+
+	assert.Equal(t, []string{"xxxxxxxxxx", "xxxxxxxxxx", "xxxxx"}, parts)
+}
+
+func TestSplit_EntitiesNotBroken(t *testing.T) {
+	parts := Split(strings.Repeat("&amp;", 5), 12, true)
+
+	for _, part := range parts {
+		assert.Equal(t, "", strings.ReplaceAll(part, "&amp;", ""))
+	}
+}
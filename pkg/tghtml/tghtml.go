@@ -0,0 +1,209 @@
+package tghtml
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Поддерживаемое Telegram подмножество HTML:
+// https://core.telegram.org/bots/api#html-style
+var allowedTags = map[string]bool{
+	"b":          true,
+	"strong":     true,
+	"i":          true,
+	"em":         true,
+	"u":          true,
+	"ins":        true,
+	"s":          true,
+	"strike":     true,
+	"del":        true,
+	"span":       true, // только class="tg-spoiler"
+	"tg-spoiler": true,
+	"a":          true,
+	"tg-emoji":   true,
+	"code":       true,
+	"pre":        true,
+	"blockquote": true,
+}
+
+var (
+	// tagPattern открывающий или закрывающий тег с атрибутами
+	tagPattern = regexp.MustCompile(`^<(/?)([a-zA-Z][a-zA-Z0-9-]*)((?:\s+[a-zA-Z][a-zA-Z0-9-]*(?:\s*=\s*(?:"[^"]*"|'[^']*'|[^\s"'>]+))?)*)\s*(/?)>`)
+
+	// attrPattern один атрибут тега
+	attrPattern = regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9-]*)(?:\s*=\s*("[^"]*"|'[^']*'|[^\s"'>]+))?`)
+
+	// entityPattern допустимые в Telegram HTML-сущности
+	entityPattern = regexp.MustCompile(`^&(?:lt|gt|amp|quot|#[0-9]{1,7}|#x[0-9a-fA-F]{1,6});`)
+
+	// anyTagPattern любой тег (для удаления разметки)
+	anyTagPattern = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Escape экранирует текст для вставки в сообщение с parse_mode=HTML
+func Escape(s string) string {
+	return html.EscapeString(s)
+}
+
+// StripTags удаляет разметку и раскодирует HTML-сущности
+// Используется для повторной отправки сообщения без форматирования
+func StripTags(s string) string {
+	return html.UnescapeString(anyTagPattern.ReplaceAllString(s, ""))
+}
+
+// Sanitize приводит текст к подмножеству HTML, которое принимает Telegram
+// - неподдерживаемые теги и одиночные "<", ">", "&" экранируются и остаются видимыми как текст
+// - у поддерживаемых тегов остаются только допустимые атрибуты
+// - закрывающие теги без пары экранируются, незакрытые теги закрываются в конце текста
+func Sanitize(s string) string {
+	var out strings.Builder
+	out.Grow(len(s))
+
+	var stack []string
+
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '&':
+			if entity := entityPattern.FindString(s[i:]); entity != "" {
+				out.WriteString(entity)
+				i += len(entity)
+				continue
+			}
+			out.WriteString("&amp;")
+			i++
+
+		case '>':
+			out.WriteString("&gt;")
+			i++
+
+		case '<':
+			m := tagPattern.FindStringSubmatch(s[i:])
+			if m == nil {
+				out.WriteString("&lt;")
+				i++
+				continue
+			}
+
+			closing, name, attrs := m[1] == "/", strings.ToLower(m[2]), m[3]
+			if closing {
+				if n := closeTag(&out, &stack, name); n {
+					i += len(m[0])
+					continue
+				}
+			} else if tag, ok := openTag(name, attrs, stack); ok {
+				out.WriteString(tag)
+				stack = append(stack, name)
+				i += len(m[0])
+				continue
+			}
+
+			// Тег не поддерживается в этом месте - показываем его как текст
+			out.WriteString("&lt;")
+			i++
+
+		default:
+			out.WriteByte(s[i])
+			i++
+		}
+	}
+
+	// Закрываем незакрытые теги
+	for j := len(stack) - 1; j >= 0; j-- {
+		out.WriteString("</" + stack[j] + ">")
+	}
+
+	return out.String()
+}
+
+// openTag формирует нормализованный открывающий тег
+// Возвращает false, если тег не поддерживается или недопустим в текущем контексте
+func openTag(name, rawAttrs string, stack []string) (string, bool) {
+	if !allowedTags[name] {
+		return "", false
+	}
+
+	// Внутри <code> разметка не допускается, внутри <pre> - только <code>
+	if len(stack) > 0 {
+		switch stack[len(stack)-1] {
+		case "code":
+			return "", false
+		case "pre":
+			if name != "code" {
+				return "", false
+			}
+		}
+	}
+
+	attrs := parseAttrs(rawAttrs)
+
+	switch name {
+	case "a":
+		href := attrs["href"]
+		if href == "" {
+			return "", false
+		}
+		return `<a href="` + html.EscapeString(href) + `">`, true
+
+	case "span":
+		if attrs["class"] != "tg-spoiler" {
+			return "", false
+		}
+		return `<span class="tg-spoiler">`, true
+
+	case "tg-emoji":
+		emojiID := attrs["emoji-id"]
+		if emojiID == "" {
+			return "", false
+		}
+		return `<tg-emoji emoji-id="` + html.EscapeString(emojiID) + `">`, true
+
+	case "code":
+		if class := attrs["class"]; strings.HasPrefix(class, "language-") && len(stack) > 0 && stack[len(stack)-1] == "pre" {
+			return `<code class="` + html.EscapeString(class) + `">`, true
+		}
+		return "<code>", true
+
+	case "blockquote":
+		if _, ok := attrs["expandable"]; ok {
+			return "<blockquote expandable>", true
+		}
+		return "<blockquote>", true
+	}
+
+	return "<" + name + ">", true
+}
+
+// closeTag закрывает тег, если он открыт
+// Вложенные незакрытые теги закрываются автоматически, чтобы сохранить корректную вложенность
+func closeTag(out *strings.Builder, stack *[]string, name string) bool {
+	idx := -1
+	for j := len(*stack) - 1; j >= 0; j-- {
+		if (*stack)[j] == name {
+			idx = j
+			break
+		}
+	}
+	if idx < 0 {
+		return false
+	}
+
+	for j := len(*stack) - 1; j >= idx; j-- {
+		out.WriteString("</" + (*stack)[j] + ">")
+	}
+	*stack = (*stack)[:idx]
+	return true
+}
+
+// parseAttrs разбирает атрибуты тега; значения раскодируются из HTML-сущностей
+func parseAttrs(raw string) map[string]string {
+	attrs := make(map[string]string)
+	for _, m := range attrPattern.FindAllStringSubmatch(raw, -1) {
+		value := m[2]
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') {
+			value = value[1 : len(value)-1]
+		}
+		attrs[strings.ToLower(m[1])] = html.UnescapeString(value)
+	}
+	return attrs
+}
@@ -0,0 +1,50 @@
+package tghtml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain text", "Привет", "Привет"},
+		{"supported tags", "<b>жирный</b> и <i>курсив</i>", "<b>жирный</b> и <i>курсив</i>"},
+		{"stray lt and amp", "a < b & c > d", "a &lt; b &amp; c &gt; d"},
+		{"valid entities", "&lt;tag&gt; &amp; &#128512;", "&lt;tag&gt; &amp; &#128512;"},
+		{"unsupported tag", "<div>текст</div>", "&lt;div&gt;текст&lt;/div&gt;"},
+		{"unclosed tag", "<b>жирный", "<b>жирный</b>"},
+		{"stray closing tag", "текст</b>", "текст&lt;/b&gt;"},
+		{"misnested tags", "<b><i>x</b>", "<b><i>x</i></b>"},
+		{"link attributes", `<a href="https://x.ru/?a=1&amp;b=2" target="_blank">ссылка</a>`, `<a href="https://x.ru/?a=1&amp;b=2">ссылка</a>`},
+		{"link without href", "<a>ссылка</a>", "&lt;a&gt;ссылка&lt;/a&gt;"},
+		{"spoiler span", `<span class="tg-spoiler">x</span><span>y</span>`, `<span class="tg-spoiler">x</span>&lt;span&gt;y&lt;/span&gt;`},
+		{"code inside pre", `<pre><code class="language-go">x := 1</code></pre>`, `<pre><code class="language-go">x := 1</code></pre>`},
+		{"tags inside code", "<code><b>x</b></code>", "<code>&lt;b&gt;x&lt;/b&gt;</code>"},
+		{"uppercase tag", "<B>x</B>", "<b>x</b>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Sanitize(tt.in))
+		})
+	}
+}
+
+func TestStripTags(t *testing.T) {
+	assert.Equal(t, "a < b & жирный", StripTags("a &lt; b &amp; <b>жирный</b>"))
+}
+
+func FuzzSanitize_Idempotent(f *testing.F) {
+	f.Add("<b>x</b>")
+	f.Add("a < b & c")
+	f.Add("<a href='x'>y")
+	f.Fuzz(func(t *testing.T, s string) {
+		once := Sanitize(s)
+		assert.Equal(t, once, Sanitize(once))
+	})
+}
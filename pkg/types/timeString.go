@@ -43,16 +43,24 @@ func (t *TimeString) Scan(value interface{}) error {
 		*t = TimeString(v.Format(TimeFormat))
 		return nil
 	case []byte:
-		*t = TimeString(v)
+		*t = fromDatabaseTime(string(v))
 		return nil
 	case string:
-		*t = TimeString(v)
+		*t = fromDatabaseTime(v)
 		return nil
 	default:
 		return fmt.Errorf("cannot scan type %T into TimeString", value)
 	}
 }
 
+// fromDatabaseTime приводит значение TIME из PostgreSQL ("23:00:00") к формату HH:MM
+func fromDatabaseTime(s string) TimeString {
+	if parsed, err := time.Parse("15:04:05", s); err == nil {
+		return NewTimeString(parsed)
+	}
+	return TimeString(s)
+}
+
 // Value implements driver.Valuer interface
 // Поддерживает запись в PostgreSQL TIME поля
 func (t TimeString) Value() (driver.Value, error) {
@@ -172,6 +180,21 @@ func (t TimeString) Equal(other TimeString) bool {
 	return string(t) == string(other)
 }
 
+// Clock возвращает часы и минуты времени
+// Используется для построения времени в нужном часовом поясе (Parse всегда возвращает UTC)
+func (t TimeString) Clock() (hour, minute int, err error) {
+	if t.IsZero() {
+		return 0, 0, ErrInvalidTimeValue
+	}
+
+	parsed, err := time.Parse(TimeFormat, string(t))
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %v", ErrInvalidTimeFormat, err)
+	}
+
+	return parsed.Hour(), parsed.Minute(), nil
+}
+
 // AddMinutes добавляет минуты к времени
 // Возвращает новый TimeString
 func (t TimeString) AddMinutes(minutes int) (TimeString, error) {